@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type InterestAccrualHandler struct {
+	InterestAccrualUsecase domain.InterestAccrualUsecase
+}
+
+func NewInterestAccrualHandler(g *gin.Engine, a domain.InterestAccrualUsecase) {
+	handler := &InterestAccrualHandler{InterestAccrualUsecase: a}
+
+	g.POST("/interest/accrue", handler.AccrueInterest)
+	g.GET("/interest/accrued", handler.GetAccruedInterest)
+}
+
+// AccrueInterest runs the interest accrual for the date in the query string,
+// or for today when none is given.
+func (a *InterestAccrualHandler) AccrueInterest(c *gin.Context) {
+	date, ok := bindDate(c)
+	if !ok {
+		return
+	}
+
+	response, err := a.InterestAccrualUsecase.AccrueInterest(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAccruedInterest reports the interest accrued but not yet due as of the
+// date in the query string, or as of today when none is given.
+func (a *InterestAccrualHandler) GetAccruedInterest(c *gin.Context) {
+	date, ok := bindDate(c)
+	if !ok {
+		return
+	}
+
+	response, err := a.InterestAccrualUsecase.GetAccruedInterest(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindDate parses the optional date in the query string, defaulting to today.
+// It writes the error response itself and reports whether the handler should
+// continue.
+func bindDate(c *gin.Context) (time.Time, bool) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("date"); query != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return time.Time{}, false
+		}
+	}
+	return date, true
+}
@@ -0,0 +1,167 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	accrualHttp "github.com/greekrode/loan-engine-amartha/accrual/delivery/http"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.InterestAccrualUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := accrualHttp.InterestAccrualHandler{
+		InterestAccrualUsecase: mockUCase,
+	}
+	router.POST("/interest/accrue", handler.AccrueInterest)
+	router.GET("/interest/accrued", handler.GetAccruedInterest)
+	return router
+}
+
+func TestAccrueInterest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accrualDate := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockUsecase    *mocks.InterestAccrualUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Valid Accrual",
+			query: "?date=2024-01-11",
+			mockUsecase: func() *mocks.InterestAccrualUsecase {
+				mockUsecase := new(mocks.InterestAccrualUsecase)
+				mockUsecase.On("AccrueInterest", mock.Anything, accrualDate).Return(&dto.AccrueInterestResponse{
+					AccruedOn:     accrualDate,
+					LoansAccrued:  1,
+					TotalInterest: money.FromFloat(1),
+					Accruals: []dto.InterestAccrualResponse{
+						{LoanID: 1, PaymentScheduleID: 11, DayCount: "act/365", Amount: money.FromFloat(1)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"accrued_on": "2024-01-11T00:00:00Z",
+				"loans_accrued": 1,
+				"total_interest": 1,
+				"accruals": [{"loan_id": 1, "payment_schedule_id": 11, "day_count": "act/365", "amount": 1}]
+			}`,
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?date=11-01-2024",
+			mockUsecase:    new(mocks.InterestAccrualUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:  "Usecase Error",
+			query: "?date=2024-01-11",
+			mockUsecase: func() *mocks.InterestAccrualUsecase {
+				mockUsecase := new(mocks.InterestAccrualUsecase)
+				mockUsecase.On("AccrueInterest", mock.Anything, accrualDate).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/interest/accrue"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetAccruedInterest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reportDate := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockUsecase    *mocks.InterestAccrualUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Valid Report",
+			query: "?date=2024-01-31",
+			mockUsecase: func() *mocks.InterestAccrualUsecase {
+				mockUsecase := new(mocks.InterestAccrualUsecase)
+				mockUsecase.On("GetAccruedInterest", mock.Anything, reportDate).Return(&dto.AccruedInterestReportResponse{
+					Date:                 reportDate,
+					TotalAccruedInterest: money.FromFloat(29),
+					Loans: []dto.LoanAccruedInterestResponse{
+						{LoanID: 2, DayCount: "30/360", AccruedInterest: money.FromFloat(29)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"date": "2024-01-31T00:00:00Z",
+				"total_accrued_interest": 29,
+				"loans": [{"loan_id": 2, "day_count": "30/360", "accrued_interest": 29}]
+			}`,
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?date=31-01-2024",
+			mockUsecase:    new(mocks.InterestAccrualUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:  "Usecase Error",
+			query: "?date=2024-01-31",
+			mockUsecase: func() *mocks.InterestAccrualUsecase {
+				mockUsecase := new(mocks.InterestAccrualUsecase)
+				mockUsecase.On("GetAccruedInterest", mock.Anything, reportDate).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", "/interest/accrued"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqliteInterestAccrualRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteInterestAccrualRepository(tm db.TransactionManager) *sqliteInterestAccrualRepository {
+	return &sqliteInterestAccrualRepository{TransactionManager: tm}
+}
+
+func (s *sqliteInterestAccrualRepository) CreateInterestAccruals(ctx context.Context, accruals []domain.InterestAccrual, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&accruals).Error
+}
+
+// GetAccruedInterestBySchedule totals the interest accrued so far on every
+// installment of the loans.
+func (s *sqliteInterestAccrualRepository) GetAccruedInterestBySchedule(ctx context.Context, loanIDs []uint) ([]domain.AccruedInterest, error) {
+	var accrued []domain.AccruedInterest
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Model(&domain.InterestAccrual{}).
+		Select("loan_id, payment_schedule_id, SUM(amount) AS amount").
+		Where("loan_id IN ?", loanIDs).
+		Group("loan_id, payment_schedule_id").
+		Scan(&accrued).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return accrued, nil
+}
+
+// GetAccruedInterestNotDue totals per loan the interest accrued on or before
+// date on installments of the current repayment plan that fall due after it.
+// Only installments still unpaid at the end of date, of loans that were
+// disbursed at that time, count: interest on installments paid by then, and
+// on loans already closed, refinanced or written off, was no longer
+// receivable. Later payments and status changes do not alter the answer.
+func (s *sqliteInterestAccrualRepository) GetAccruedInterestNotDue(ctx context.Context, date time.Time) ([]domain.AccruedInterest, error) {
+	var accrued []domain.AccruedInterest
+	endOfDay := date.AddDate(0, 0, 1)
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Model(&domain.InterestAccrual{}).
+		Select("interest_accruals.loan_id, loans.day_count, SUM(interest_accruals.amount) AS amount").
+		Joins("JOIN payment_schedules ON payment_schedules.id = interest_accruals.payment_schedule_id AND payment_schedules.deleted_at IS NULL").
+		Joins("JOIN loans ON loans.id = interest_accruals.loan_id AND loans.deleted_at IS NULL").
+		Where("interest_accruals.accrued_on < ? AND payment_schedules.due_date > ? AND payment_schedules.superseded = ?", endOfDay, date, false).
+		Where("payment_schedules.paid = ? OR payment_schedules.paid_at >= ?", false, endOfDay).
+		Where("(SELECT loan_status_changes.to_status FROM loan_status_changes "+
+			"WHERE loan_status_changes.loan_id = loans.id AND loan_status_changes.created_at < ? AND loan_status_changes.deleted_at IS NULL "+
+			"ORDER BY loan_status_changes.created_at DESC, loan_status_changes.id DESC LIMIT 1) = ?", endOfDay, domain.LoanDisbursed).
+		Group("interest_accruals.loan_id, loans.day_count").
+		Order("interest_accruals.loan_id").
+		Scan(&accrued).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return accrued, nil
+}
@@ -0,0 +1,115 @@
+package sqlite_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/greekrode/loan-engine-amartha/accrual/repository/sqlite"
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/greekrode/loan-engine-amartha/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type InterestAccrualRepositorySuite struct {
+	suite.Suite
+	tm   db.TransactionManager
+	mock sqlmock.Sqlmock
+}
+
+func (s *InterestAccrualRepositorySuite) SetupSuite() {
+	var err error
+	s.tm, s.mock, err = utils.SetupMockDB(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *InterestAccrualRepositorySuite) AfterTest(_, _ string) {
+	s.Require().NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *InterestAccrualRepositorySuite) TestCreateInterestAccruals() {
+	accruedOn := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("INSERT INTO `interest_accruals`").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, 11, money.FromFloat(1), domain.DayCountActual365, accruedOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	repo := sqlite.NewSQLiteInterestAccrualRepository(s.tm)
+	err := repo.CreateInterestAccruals(context.TODO(), []domain.InterestAccrual{
+		{LoanID: 1, PaymentScheduleID: 11, Amount: money.FromFloat(1), DayCount: domain.DayCountActual365, AccruedOn: accruedOn},
+	}, nil)
+	s.NoError(err)
+}
+
+func (s *InterestAccrualRepositorySuite) TestGetAccruedInterestBySchedule() {
+	query := regexp.QuoteMeta("SELECT loan_id, payment_schedule_id, SUM(amount) AS amount FROM `interest_accruals` " +
+		"WHERE loan_id IN (?,?) AND `interest_accruals`.`deleted_at` IS NULL GROUP BY loan_id, payment_schedule_id")
+
+	s.mock.ExpectQuery(query).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"loan_id", "payment_schedule_id", "amount"}).
+		AddRow(1, 11, 300).
+		AddRow(2, 20, 1000))
+
+	repo := sqlite.NewSQLiteInterestAccrualRepository(s.tm)
+	accrued, err := repo.GetAccruedInterestBySchedule(context.TODO(), []uint{1, 2})
+	s.NoError(err)
+	s.Equal([]domain.AccruedInterest{
+		{LoanID: 1, PaymentScheduleID: 11, Amount: money.FromFloat(3)},
+		{LoanID: 2, PaymentScheduleID: 20, Amount: money.FromFloat(10)},
+	}, accrued)
+}
+
+func (s *InterestAccrualRepositorySuite) TestGetAccruedInterestNotDue() {
+	date := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	endOfDay := date.AddDate(0, 0, 1)
+	query := regexp.QuoteMeta("SELECT interest_accruals.loan_id, loans.day_count, SUM(interest_accruals.amount) AS amount FROM `interest_accruals` " +
+		"JOIN payment_schedules ON payment_schedules.id = interest_accruals.payment_schedule_id AND payment_schedules.deleted_at IS NULL " +
+		"JOIN loans ON loans.id = interest_accruals.loan_id AND loans.deleted_at IS NULL " +
+		"WHERE (interest_accruals.accrued_on < ? AND payment_schedules.due_date > ? AND payment_schedules.superseded = ?) " +
+		"AND (payment_schedules.paid = ? OR payment_schedules.paid_at >= ?) " +
+		"AND ((SELECT loan_status_changes.to_status FROM loan_status_changes " +
+		"WHERE loan_status_changes.loan_id = loans.id AND loan_status_changes.created_at < ? AND loan_status_changes.deleted_at IS NULL " +
+		"ORDER BY loan_status_changes.created_at DESC, loan_status_changes.id DESC LIMIT 1) = ?) " +
+		"AND `interest_accruals`.`deleted_at` IS NULL " +
+		"GROUP BY interest_accruals.loan_id, loans.day_count ORDER BY interest_accruals.loan_id")
+
+	tests := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		expected []domain.AccruedInterest
+	}{
+		{
+			name: "Disbursed Loan",
+			rows: sqlmock.NewRows([]string{"loan_id", "day_count", "amount"}).AddRow(2, "30/360", 2900),
+			expected: []domain.AccruedInterest{
+				{LoanID: 2, DayCount: domain.DayCount30360, Amount: money.FromFloat(29)},
+			},
+		},
+		{
+			// A loan paid off by the end of date was closed with every
+			// installment paid, so nothing accrued on it was left receivable.
+			name: "Loan Paid Off By The Date",
+			rows: sqlmock.NewRows([]string{"loan_id", "day_count", "amount"}),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.mock.ExpectQuery(query).WithArgs(endOfDay, date, false, false, endOfDay, endOfDay, domain.LoanDisbursed).WillReturnRows(tt.rows)
+
+			repo := sqlite.NewSQLiteInterestAccrualRepository(s.tm)
+			accrued, err := repo.GetAccruedInterestNotDue(context.TODO(), date)
+			s.NoError(err)
+			s.Equal(tt.expected, accrued)
+		})
+	}
+}
+
+func TestInterestAccrualRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InterestAccrualRepositorySuite))
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type interestAccrualUsecase struct {
+	accrualRepo    domain.InterestAccrualRepository
+	loanRepo       domain.LoanRepository
+	contextTimeout time.Duration
+}
+
+func NewInterestAccrualUsecase(a domain.InterestAccrualRepository, l domain.LoanRepository, timeout time.Duration) domain.InterestAccrualUsecase {
+	return &interestAccrualUsecase{
+		accrualRepo:    a,
+		loanRepo:       l,
+		contextTimeout: timeout,
+	}
+}
+
+// AccrueInterest records the interest every unpaid installment of the
+// disbursed loans has earned up to the given date under its loan's day count.
+// An installment earns its interest from the previous due date, or from the
+// loan's start date for the first one, until its own due date. Only what
+// earlier runs have not accrued yet is recorded, so running it again for the
+// same date accrues nothing more and a missed day is caught up by the next
+// run.
+func (a *interestAccrualUsecase) AccrueInterest(ctx context.Context, date time.Time) (*dto.AccrueInterestResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	loans, err := a.loanRepo.GetDisbursedLoans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AccrueInterestResponse{
+		AccruedOn: date,
+		Accruals:  []dto.InterestAccrualResponse{},
+	}
+	if len(loans) == 0 {
+		return response, nil
+	}
+
+	loanIDs := make([]uint, len(loans))
+	for i, loan := range loans {
+		loanIDs[i] = loan.ID
+	}
+
+	accruedBySchedule, err := a.accrualRepo.GetAccruedInterestBySchedule(ctx, loanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	accrued := make(map[uint]money.Money, len(accruedBySchedule))
+	for _, schedule := range accruedBySchedule {
+		accrued[schedule.PaymentScheduleID] = schedule.Amount
+	}
+
+	var accruals []domain.InterestAccrual
+	for _, loan := range loans {
+		accruedBefore := len(accruals)
+		periodStart := loan.StartDate
+		for _, schedule := range loan.PaymentSchedules {
+			start := periodStart
+			periodStart = schedule.DueDate
+			if schedule.Paid {
+				continue
+			}
+
+			amount := loan.DayCount.Accrue(schedule.InterestAmount, start, schedule.DueDate, date) - accrued[schedule.ID]
+			if amount <= 0 {
+				continue
+			}
+
+			accruals = append(accruals, domain.InterestAccrual{
+				LoanID:            loan.ID,
+				PaymentScheduleID: schedule.ID,
+				Amount:            amount,
+				DayCount:          loan.DayCount,
+				AccruedOn:         date,
+			})
+		}
+
+		if len(accruals) > accruedBefore {
+			response.LoansAccrued++
+		}
+	}
+
+	if len(accruals) == 0 {
+		return response, nil
+	}
+
+	if err := a.accrualRepo.CreateInterestAccruals(ctx, accruals, nil); err != nil {
+		return nil, err
+	}
+
+	for _, accrual := range accruals {
+		response.TotalInterest += accrual.Amount
+		response.Accruals = append(response.Accruals, dto.InterestAccrualResponse{
+			LoanID:            accrual.LoanID,
+			PaymentScheduleID: accrual.PaymentScheduleID,
+			DayCount:          string(accrual.DayCount),
+			Amount:            accrual.Amount,
+		})
+	}
+
+	return response, nil
+}
+
+// GetAccruedInterest reports per loan the interest accrued by the end of date
+// on installments that are not due yet.
+func (a *interestAccrualUsecase) GetAccruedInterest(ctx context.Context, date time.Time) (*dto.AccruedInterestReportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	accrued, err := a.accrualRepo.GetAccruedInterestNotDue(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AccruedInterestReportResponse{
+		Date:  date,
+		Loans: make([]dto.LoanAccruedInterestResponse, len(accrued)),
+	}
+	for i, loan := range accrued {
+		response.TotalAccruedInterest += loan.Amount
+		response.Loans[i] = dto.LoanAccruedInterestResponse{
+			LoanID:          loan.LoanID,
+			DayCount:        string(loan.DayCount),
+			AccruedInterest: loan.Amount,
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,206 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accrualUsecase "github.com/greekrode/loan-engine-amartha/accrual/usecase"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type InterestAccrualUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *InterestAccrualUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *InterestAccrualUsecaseSuite) TestAccrueInterest() {
+	accrualDate := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// disbursedLoans returns a weekly ACT/365 loan whose second installment is
+	// three days into its period, and a monthly 30/360 loan ten days into its
+	// first period.
+	disbursedLoans := func() []domain.Loan {
+		return []domain.Loan{
+			{
+				Model:     gorm.Model{ID: 1},
+				Status:    domain.LoanDisbursed,
+				DayCount:  domain.DayCountActual365,
+				StartDate: start,
+				PaymentSchedules: []domain.PaymentSchedule{
+					{Model: gorm.Model{ID: 10}, LoanID: 1, InterestAmount: money.FromFloat(7), DueDate: start.AddDate(0, 0, 7), Paid: true},
+					{Model: gorm.Model{ID: 11}, LoanID: 1, InterestAmount: money.FromFloat(7), DueDate: start.AddDate(0, 0, 14)},
+					{Model: gorm.Model{ID: 12}, LoanID: 1, InterestAmount: money.FromFloat(7), DueDate: start.AddDate(0, 0, 21)},
+				},
+			},
+			{
+				Model:     gorm.Model{ID: 2},
+				Status:    domain.LoanDisbursed,
+				DayCount:  domain.DayCount30360,
+				StartDate: start,
+				PaymentSchedules: []domain.PaymentSchedule{
+					{Model: gorm.Model{ID: 20}, LoanID: 2, InterestAmount: money.FromFloat(30), DueDate: start.AddDate(0, 1, 0)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		accrued       []domain.AccruedInterest
+		setupMocks    func(*mocks.InterestAccrualRepository)
+		expected      *dto.AccrueInterestResponse
+		expectedError error
+	}{
+		{
+			name:    "Accrues Interest Earned Since The Last Run",
+			accrued: []domain.AccruedInterest{{LoanID: 1, PaymentScheduleID: 11, Amount: money.FromFloat(2)}},
+			setupMocks: func(mar *mocks.InterestAccrualRepository) {
+				mar.On("CreateInterestAccruals", mock.Anything, []domain.InterestAccrual{
+					{LoanID: 1, PaymentScheduleID: 11, Amount: money.FromFloat(1), DayCount: domain.DayCountActual365, AccruedOn: accrualDate},
+					{LoanID: 2, PaymentScheduleID: 20, Amount: money.FromFloat(10), DayCount: domain.DayCount30360, AccruedOn: accrualDate},
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.AccrueInterestResponse{
+				AccruedOn:     accrualDate,
+				LoansAccrued:  2,
+				TotalInterest: money.FromFloat(11),
+				Accruals: []dto.InterestAccrualResponse{
+					{LoanID: 1, PaymentScheduleID: 11, DayCount: "act/365", Amount: money.FromFloat(1)},
+					{LoanID: 2, PaymentScheduleID: 20, DayCount: "30/360", Amount: money.FromFloat(10)},
+				},
+			},
+		},
+		{
+			name: "Nothing To Accrue",
+			accrued: []domain.AccruedInterest{
+				{LoanID: 1, PaymentScheduleID: 11, Amount: money.FromFloat(3)},
+				{LoanID: 2, PaymentScheduleID: 20, Amount: money.FromFloat(10)},
+			},
+			setupMocks: func(*mocks.InterestAccrualRepository) {},
+			expected: &dto.AccrueInterestResponse{
+				AccruedOn: accrualDate,
+				Accruals:  []dto.InterestAccrualResponse{},
+			},
+		},
+		{
+			name: "Error Creating Interest Accruals",
+			setupMocks: func(mar *mocks.InterestAccrualRepository) {
+				mar.On("CreateInterestAccruals", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating interest accruals"))
+			},
+			expectedError: errors.New("error creating interest accruals"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockAccrualRepo := new(mocks.InterestAccrualRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+
+			uc := accrualUsecase.NewInterestAccrualUsecase(mockAccrualRepo, mockLoanRepo, s.timeout)
+
+			mockLoanRepo.On("GetDisbursedLoans", mock.Anything).Return(disbursedLoans(), nil)
+			mockAccrualRepo.On("GetAccruedInterestBySchedule", mock.Anything, []uint{1, 2}).Return(tt.accrued, nil)
+			tt.setupMocks(mockAccrualRepo)
+
+			result, err := uc.AccrueInterest(context.TODO(), accrualDate)
+			if tt.expectedError != nil {
+				assert.EqualError(s.T(), err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.expected, result)
+			mockAccrualRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *InterestAccrualUsecaseSuite) TestAccrueInterestNoLoans() {
+	mockLoanRepo := new(mocks.LoanRepository)
+	mockLoanRepo.On("GetDisbursedLoans", mock.Anything).Return([]domain.Loan{}, nil)
+
+	uc := accrualUsecase.NewInterestAccrualUsecase(new(mocks.InterestAccrualRepository), mockLoanRepo, s.timeout)
+
+	accrualDate := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+	result, err := uc.AccrueInterest(context.TODO(), accrualDate)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &dto.AccrueInterestResponse{AccruedOn: accrualDate, Accruals: []dto.InterestAccrualResponse{}}, result)
+}
+
+func (s *InterestAccrualUsecaseSuite) TestAccrueInterestRepositoryErrors() {
+	s.Run("Error Getting Loans", func() {
+		mockLoanRepo := new(mocks.LoanRepository)
+		mockLoanRepo.On("GetDisbursedLoans", mock.Anything).Return(nil, errors.New("database error"))
+
+		uc := accrualUsecase.NewInterestAccrualUsecase(new(mocks.InterestAccrualRepository), mockLoanRepo, s.timeout)
+
+		_, err := uc.AccrueInterest(context.TODO(), time.Now())
+		assert.EqualError(s.T(), err, "database error")
+	})
+
+	s.Run("Error Getting Accrued Interest", func() {
+		mockLoanRepo := new(mocks.LoanRepository)
+		mockLoanRepo.On("GetDisbursedLoans", mock.Anything).Return([]domain.Loan{{Model: gorm.Model{ID: 1}}}, nil)
+		mockAccrualRepo := new(mocks.InterestAccrualRepository)
+		mockAccrualRepo.On("GetAccruedInterestBySchedule", mock.Anything, []uint{1}).Return(nil, errors.New("database error"))
+
+		uc := accrualUsecase.NewInterestAccrualUsecase(mockAccrualRepo, mockLoanRepo, s.timeout)
+
+		_, err := uc.AccrueInterest(context.TODO(), time.Now())
+		assert.EqualError(s.T(), err, "database error")
+	})
+}
+
+func (s *InterestAccrualUsecaseSuite) TestGetAccruedInterest() {
+	reportDate := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	s.Run("Success", func() {
+		mockAccrualRepo := new(mocks.InterestAccrualRepository)
+		mockAccrualRepo.On("GetAccruedInterestNotDue", mock.Anything, reportDate).Return([]domain.AccruedInterest{
+			{LoanID: 1, DayCount: domain.DayCountActual365, Amount: money.FromFloat(4.50)},
+			{LoanID: 2, DayCount: domain.DayCount30360, Amount: money.FromFloat(29)},
+		}, nil)
+
+		uc := accrualUsecase.NewInterestAccrualUsecase(mockAccrualRepo, new(mocks.LoanRepository), s.timeout)
+
+		result, err := uc.GetAccruedInterest(context.TODO(), reportDate)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), &dto.AccruedInterestReportResponse{
+			Date:                 reportDate,
+			TotalAccruedInterest: money.FromFloat(33.50),
+			Loans: []dto.LoanAccruedInterestResponse{
+				{LoanID: 1, DayCount: "act/365", AccruedInterest: money.FromFloat(4.50)},
+				{LoanID: 2, DayCount: "30/360", AccruedInterest: money.FromFloat(29)},
+			},
+		}, result)
+	})
+
+	s.Run("Repository Error", func() {
+		mockAccrualRepo := new(mocks.InterestAccrualRepository)
+		mockAccrualRepo.On("GetAccruedInterestNotDue", mock.Anything, reportDate).Return(nil, errors.New("database error"))
+
+		uc := accrualUsecase.NewInterestAccrualUsecase(mockAccrualRepo, new(mocks.LoanRepository), s.timeout)
+
+		_, err := uc.GetAccruedInterest(context.TODO(), reportDate)
+		assert.EqualError(s.T(), err, "database error")
+	})
+}
+
+func TestInterestAccrualUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(InterestAccrualUsecaseSuite))
+}
@@ -0,0 +1,19 @@
+package amortization
+
+import (
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+)
+
+func NewScheduleCalculator(method domain.AmortizationMethod) (domain.ScheduleCalculator, error) {
+	switch method {
+	case domain.AmortizationFlat:
+		return &flatCalculator{}, nil
+	case domain.AmortizationDecliningBalance:
+		return &decliningBalanceCalculator{}, nil
+	case domain.AmortizationInterestOnly:
+		return &interestOnlyCalculator{}, nil
+	}
+	return nil, fmt.Errorf("unsupported amortization method: %s", method)
+}
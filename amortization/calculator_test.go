@@ -0,0 +1,130 @@
+package amortization_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/amortization"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalculatorSuite struct {
+	suite.Suite
+}
+
+func (s *CalculatorSuite) TestNewScheduleCalculator() {
+	tests := []struct {
+		name    string
+		method  domain.AmortizationMethod
+		wantErr bool
+	}{
+		{name: "Flat", method: domain.AmortizationFlat},
+		{name: "Declining Balance", method: domain.AmortizationDecliningBalance},
+		{name: "Interest Only", method: domain.AmortizationInterestOnly},
+		{name: "Unsupported", method: domain.AmortizationMethod("balloon"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			calculator, err := amortization.NewScheduleCalculator(tt.method)
+			if tt.wantErr {
+				s.Error(err)
+				s.Nil(calculator)
+			} else {
+				s.NoError(err)
+				s.NotNil(calculator)
+			}
+		})
+	}
+}
+
+func (s *CalculatorSuite) TestCalculate() {
+	tests := []struct {
+		name         string
+		method       domain.AmortizationMethod
+		principal    money.Money
+		periodicRate float64
+		periods      int
+		expected     []domain.Installment
+	}{
+		{
+			name:         "Flat",
+			method:       domain.AmortizationFlat,
+			principal:    money.FromFloat(1000),
+			periodicRate: 0.01,
+			periods:      2,
+			expected: []domain.Installment{
+				{Principal: money.FromFloat(500), Interest: money.FromFloat(10)},
+				{Principal: money.FromFloat(500), Interest: money.FromFloat(10)},
+			},
+		},
+		{
+			name:         "Flat With Remainder On Final Installment",
+			method:       domain.AmortizationFlat,
+			principal:    money.FromFloat(1000),
+			periodicRate: 0.001,
+			periods:      3,
+			expected: []domain.Installment{
+				{Principal: money.FromFloat(333.33), Interest: money.FromFloat(1)},
+				{Principal: money.FromFloat(333.33), Interest: money.FromFloat(1)},
+				{Principal: money.FromFloat(333.34), Interest: money.FromFloat(1)},
+			},
+		},
+		{
+			name:         "Declining Balance",
+			method:       domain.AmortizationDecliningBalance,
+			principal:    money.FromFloat(1000),
+			periodicRate: 0.01,
+			periods:      2,
+			expected: []domain.Installment{
+				{Principal: money.FromFloat(497.51), Interest: money.FromFloat(10)},
+				{Principal: money.FromFloat(502.49), Interest: money.FromFloat(5.02)},
+			},
+		},
+		{
+			name:         "Declining Balance Without Interest",
+			method:       domain.AmortizationDecliningBalance,
+			principal:    money.FromFloat(1000),
+			periodicRate: 0,
+			periods:      3,
+			expected: []domain.Installment{
+				{Principal: money.FromFloat(333.33)},
+				{Principal: money.FromFloat(333.33)},
+				{Principal: money.FromFloat(333.34)},
+			},
+		},
+		{
+			name:         "Interest Only",
+			method:       domain.AmortizationInterestOnly,
+			principal:    money.FromFloat(1000),
+			periodicRate: 0.01,
+			periods:      3,
+			expected: []domain.Installment{
+				{Interest: money.FromFloat(10)},
+				{Interest: money.FromFloat(10)},
+				{Principal: money.FromFloat(1000), Interest: money.FromFloat(10)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			calculator, err := amortization.NewScheduleCalculator(tt.method)
+			s.Require().NoError(err)
+
+			installments := calculator.Calculate(tt.principal, tt.periodicRate, tt.periods)
+			s.Equal(tt.expected, installments)
+
+			var totalPrincipal money.Money
+			for _, installment := range installments {
+				totalPrincipal += installment.Principal
+			}
+			s.Equal(tt.principal, totalPrincipal)
+		})
+	}
+}
+
+func TestCalculatorSuite(t *testing.T) {
+	suite.Run(t, new(CalculatorSuite))
+}
@@ -0,0 +1,42 @@
+package amortization
+
+import (
+	"math"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// decliningBalanceCalculator produces an annuity schedule: every installment has
+// the same amount, and interest is charged on the remaining balance only. The
+// final installment takes whatever balance is left after rounding.
+type decliningBalanceCalculator struct{}
+
+func (d *decliningBalanceCalculator) Calculate(principal money.Money, periodicRate float64, periods int) []domain.Installment {
+	installments := make([]domain.Installment, periods)
+	if periods == 0 {
+		return installments
+	}
+
+	payment := principal.Split(periods)[0]
+	if periodicRate > 0 {
+		payment = principal.MulRate(periodicRate / (1 - math.Pow(1+periodicRate, -float64(periods))))
+	}
+
+	balance := principal
+	for i := range installments {
+		interest := balance.MulRate(periodicRate)
+		principalPart := payment - interest
+		if i == periods-1 {
+			principalPart = balance
+		}
+		balance -= principalPart
+
+		installments[i] = domain.Installment{
+			Principal: principalPart,
+			Interest:  interest,
+		}
+	}
+
+	return installments
+}
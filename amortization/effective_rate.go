@@ -0,0 +1,38 @@
+package amortization
+
+import (
+	"math"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// EffectiveAnnualRate returns the annual percentage rate that discounts the
+// installments back to the principal, compounded once per installment period.
+// Unlike the nominal rate it reflects how the amortization method and fees
+// change the real cost of the loan.
+func EffectiveAnnualRate(principal money.Money, installments []money.Money, periodsPerYear int) float64 {
+	if principal <= 0 || len(installments) == 0 || periodsPerYear <= 0 {
+		return 0
+	}
+
+	presentValue := func(rate float64) float64 {
+		total := 0.0
+		for i, installment := range installments {
+			total += installment.Float64() / math.Pow(1+rate, float64(i+1))
+		}
+		return total
+	}
+
+	low, high := -0.99, 1.0
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		if presentValue(mid) > principal.Float64() {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	annual := (math.Pow(1+(low+high)/2, float64(periodsPerYear)) - 1) * 100
+	return math.Round(annual*100) / 100
+}
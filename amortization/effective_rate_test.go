@@ -0,0 +1,47 @@
+package amortization_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/amortization"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveAnnualRate(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      money.Money
+		installments   []money.Money
+		periodsPerYear int
+		expected       float64
+	}{
+		{
+			name:           "Monthly Annuity At 12 Percent",
+			principal:      money.FromFloat(1200),
+			installments:   []money.Money{money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62), money.FromFloat(106.62)},
+			periodsPerYear: 12,
+			expected:       12.69,
+		},
+		{
+			name:           "No Interest",
+			principal:      money.FromFloat(1000),
+			installments:   []money.Money{money.FromFloat(500), money.FromFloat(500)},
+			periodsPerYear: 52,
+			expected:       0,
+		},
+		{
+			name:           "Empty Schedule",
+			principal:      money.FromFloat(1000),
+			installments:   nil,
+			periodsPerYear: 52,
+			expected:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, amortization.EffectiveAnnualRate(tt.principal, tt.installments, tt.periodsPerYear))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package amortization
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// flatCalculator charges interest on the original principal for every period
+// and spreads the principal evenly across the tenor. Interest is computed once
+// for the whole tenor so the schedule adds up to principal plus interest exactly.
+type flatCalculator struct{}
+
+func (f *flatCalculator) Calculate(principal money.Money, periodicRate float64, periods int) []domain.Installment {
+	installments := make([]domain.Installment, periods)
+
+	principalParts := principal.Split(periods)
+	interestParts := principal.MulRate(periodicRate * float64(periods)).Split(periods)
+	for i := range installments {
+		installments[i] = domain.Installment{
+			Principal: principalParts[i],
+			Interest:  interestParts[i],
+		}
+	}
+
+	return installments
+}
@@ -0,0 +1,24 @@
+package amortization
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// interestOnlyCalculator charges interest every period and collects the whole
+// principal as a balloon on the final installment.
+type interestOnlyCalculator struct{}
+
+func (c *interestOnlyCalculator) Calculate(principal money.Money, periodicRate float64, periods int) []domain.Installment {
+	installments := make([]domain.Installment, periods)
+	for i := range installments {
+		installments[i] = domain.Installment{
+			Interest: principal.MulRate(periodicRate),
+		}
+	}
+	if periods > 0 {
+		installments[periods-1].Principal = principal
+	}
+
+	return installments
+}
@@ -1,14 +1,34 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	_accrualHttpDelivery "github.com/greekrode/loan-engine-amartha/accrual/delivery/http"
+	_accrualRepo "github.com/greekrode/loan-engine-amartha/accrual/repository/sqlite"
+	_accrualUsecase "github.com/greekrode/loan-engine-amartha/accrual/usecase"
 	_borrowerHttpDelivery "github.com/greekrode/loan-engine-amartha/borrower/delivery/http"
 	_borrowerRepo "github.com/greekrode/loan-engine-amartha/borrower/repository/sqlite"
 	_borrowerUseCase "github.com/greekrode/loan-engine-amartha/borrower/usecase"
+	_calendarHttpDelivery "github.com/greekrode/loan-engine-amartha/calendar/delivery/http"
+	_calendarRepo "github.com/greekrode/loan-engine-amartha/calendar/repository/sqlite"
+	_calendarUsecase "github.com/greekrode/loan-engine-amartha/calendar/usecase"
 	"github.com/greekrode/loan-engine-amartha/db"
+	_groupHttpDelivery "github.com/greekrode/loan-engine-amartha/group/delivery/http"
+	_groupRepo "github.com/greekrode/loan-engine-amartha/group/repository/sqlite"
+	_groupUsecase "github.com/greekrode/loan-engine-amartha/group/usecase"
+	_idempotencyHttpDelivery "github.com/greekrode/loan-engine-amartha/idempotency/delivery/http"
+	_idempotencyRepo "github.com/greekrode/loan-engine-amartha/idempotency/repository/sqlite"
+	_idempotencyUsecase "github.com/greekrode/loan-engine-amartha/idempotency/usecase"
+	_investorHttpDelivery "github.com/greekrode/loan-engine-amartha/investor/delivery/http"
+	_investorRepo "github.com/greekrode/loan-engine-amartha/investor/repository/sqlite"
+	_investorUsecase "github.com/greekrode/loan-engine-amartha/investor/usecase"
+	_ledgerHttpDelivery "github.com/greekrode/loan-engine-amartha/ledger/delivery/http"
+	_ledgerRepo "github.com/greekrode/loan-engine-amartha/ledger/repository/sqlite"
+	_ledgerUsecase "github.com/greekrode/loan-engine-amartha/ledger/usecase"
 	_loanHttpDelivery "github.com/greekrode/loan-engine-amartha/loan/delivery/http"
 	_loanRepo "github.com/greekrode/loan-engine-amartha/loan/repository/sqlite"
 	_loanUsecase "github.com/greekrode/loan-engine-amartha/loan/usecase"
@@ -16,6 +36,12 @@ import (
 	_paymentRepo "github.com/greekrode/loan-engine-amartha/payment/repository/sqlite"
 	_paymentUsecase "github.com/greekrode/loan-engine-amartha/payment/usecase"
 	_paymentScheduleRepo "github.com/greekrode/loan-engine-amartha/payment_schedule/repository/sqlite"
+	_penaltyHttpDelivery "github.com/greekrode/loan-engine-amartha/penalty/delivery/http"
+	_penaltyRepo "github.com/greekrode/loan-engine-amartha/penalty/repository/sqlite"
+	_penaltyUsecase "github.com/greekrode/loan-engine-amartha/penalty/usecase"
+	_productHttpDelivery "github.com/greekrode/loan-engine-amartha/product/delivery/http"
+	_productRepo "github.com/greekrode/loan-engine-amartha/product/repository/sqlite"
+	_productUsecase "github.com/greekrode/loan-engine-amartha/product/usecase"
 )
 
 func main() {
@@ -32,14 +58,45 @@ func main() {
 	borrowerRepo := _borrowerRepo.NewSQLiteBorrowerRepository(db.TrxManager)
 	paymentScheduleRepo := _paymentScheduleRepo.NewSQLitePaymentScheduleRepository(db.TrxManager)
 	paymentRepo := _paymentRepo.NewSQLitePaymentRepository(db.TrxManager)
+	calendarRepo := _calendarRepo.NewSQLiteHolidayCalendarRepository(db.TrxManager)
+	productRepo := _productRepo.NewSQLiteLoanProductRepository(db.TrxManager)
+	investorRepo := _investorRepo.NewSQLiteInvestorRepository(db.TrxManager)
+	penaltyRepo := _penaltyRepo.NewSQLitePenaltyRepository(db.TrxManager)
+	groupRepo := _groupRepo.NewSQLiteBorrowerGroupRepository(db.TrxManager)
+	ledgerRepo := _ledgerRepo.NewSQLiteLedgerRepository(db.TrxManager)
+	accrualRepo := _accrualRepo.NewSQLiteInterestAccrualRepository(db.TrxManager)
+	idempotencyRepo := _idempotencyRepo.NewSQLiteIdempotencyRepository(db.TrxManager)
 
-	loanUsecase := _loanUsecase.NewLoanUsecase(borrowerRepo, paymentScheduleRepo, loanRepo, db.TrxManager, timeoutCtx)
+	loanUsecase := _loanUsecase.NewLoanUsecase(borrowerRepo, paymentScheduleRepo, loanRepo, productRepo, calendarRepo, paymentRepo, investorRepo, ledgerRepo, db.TrxManager, timeoutCtx)
 	borrowerUseCase := _borrowerUseCase.NewBorrowerUsecase(borrowerRepo, loanRepo, timeoutCtx)
-	paymentUsecase := _paymentUsecase.NewPaymentUsecase(paymentRepo, paymentScheduleRepo, loanRepo, db.TrxManager, timeoutCtx)
+	paymentUsecase := _paymentUsecase.NewPaymentUsecase(paymentRepo, paymentScheduleRepo, loanRepo, investorRepo, groupRepo, ledgerRepo, db.TrxManager, timeoutCtx)
+	calendarUsecase := _calendarUsecase.NewHolidayCalendarUsecase(calendarRepo, db.TrxManager, timeoutCtx)
+	productUsecase := _productUsecase.NewLoanProductUsecase(productRepo, db.TrxManager, timeoutCtx)
+	investorUsecase := _investorUsecase.NewInvestorUsecase(investorRepo, loanRepo, db.TrxManager, timeoutCtx)
+	penaltyUsecase := _penaltyUsecase.NewPenaltyUsecase(penaltyRepo, paymentScheduleRepo, loanRepo, db.TrxManager, timeoutCtx)
+	groupUsecase := _groupUsecase.NewBorrowerGroupUsecase(groupRepo, borrowerRepo, loanRepo, db.TrxManager, timeoutCtx)
+	ledgerUsecase := _ledgerUsecase.NewLedgerUsecase(ledgerRepo, timeoutCtx)
+	accrualUsecase := _accrualUsecase.NewInterestAccrualUsecase(accrualRepo, loanRepo, timeoutCtx)
+	idempotencyUsecase := _idempotencyUsecase.NewIdempotencyUsecase(idempotencyRepo, timeoutCtx)
 
-	_loanHttpDelivery.NewLoanHandler(router, loanUsecase)
+	if path := os.Getenv("HOLIDAY_CALENDARS_FILE"); path != "" {
+		if err := calendarUsecase.LoadCalendarsFromFile(context.Background(), path); err != nil {
+			log.Fatalf("failed to load holiday calendars: %v", err)
+		}
+	}
+
+	idempotent := _idempotencyHttpDelivery.NewIdempotencyMiddleware(idempotencyUsecase)
+
+	_loanHttpDelivery.NewLoanHandler(router, loanUsecase, idempotent)
 	_borrowerHttpDelivery.NewBorrowerHandler(router, borrowerUseCase)
-	_paymentHttpDelivery.NewPaymentHandler(router, paymentUsecase)
+	_paymentHttpDelivery.NewPaymentHandler(router, paymentUsecase, idempotent)
+	_calendarHttpDelivery.NewHolidayCalendarHandler(router, calendarUsecase)
+	_productHttpDelivery.NewLoanProductHandler(router, productUsecase)
+	_investorHttpDelivery.NewInvestorHandler(router, investorUsecase)
+	_penaltyHttpDelivery.NewPenaltyHandler(router, penaltyUsecase)
+	_groupHttpDelivery.NewBorrowerGroupHandler(router, groupUsecase)
+	_ledgerHttpDelivery.NewLedgerHandler(router, ledgerUsecase)
+	_accrualHttpDelivery.NewInterestAccrualHandler(router, accrualUsecase)
 
 	log.Fatal(router.Run(":8080"))
 }
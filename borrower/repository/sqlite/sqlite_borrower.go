@@ -29,7 +29,7 @@ func (s *sqliteBorrowerRepository) CreateBorrower(ctx context.Context, borrower
 func (s *sqliteBorrowerRepository) FindBorrowerByID(ctx context.Context, borrowerID uint) (*domain.Borrower, error) {
 	var borrower domain.Borrower
 
-	err := s.TransactionManager.GetDB().WithContext(ctx).First(&borrower, borrowerID).Error
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Group").First(&borrower, borrowerID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("Borrower not found")
@@ -43,7 +43,7 @@ func (s *BorrowerRepositorySuite) TestCreateBorrower() {
 			name: "Success",
 			setup: func() {
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec("INSERT INTO `borrowers`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "John", "Doe", "john.doe@example.com").WillReturnResult(sqlmock.NewResult(1, 1))
+				s.mock.ExpectExec("INSERT INTO `borrowers`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "John", "Doe", "john.doe@example.com", nil).WillReturnResult(sqlmock.NewResult(1, 1))
 				s.mock.ExpectCommit()
 			},
 			borrower: domain.Borrower{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
@@ -53,7 +53,7 @@ func (s *BorrowerRepositorySuite) TestCreateBorrower() {
 			name: "Failure",
 			setup: func() {
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec("INSERT INTO `borrowers`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "John", "Doe", "john.doe@example.com").WillReturnError(fmt.Errorf("insert error"))
+				s.mock.ExpectExec("INSERT INTO `borrowers`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "John", "Doe", "john.doe@example.com", nil).WillReturnError(fmt.Errorf("insert error"))
 				s.mock.ExpectRollback()
 			},
 			borrower: domain.Borrower{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
@@ -41,6 +41,12 @@ func (b *borrowerUsecase) IsDelinquent(ctx context.Context, borrowerID uint) (bo
 	delinquentCount := 0
 
 	for _, loan := range loans {
+		// Only money that was lent and is still being repaid can be late.
+		// Written-off balances are collected as recoveries, not installments.
+		if loan.Status != domain.LoanDisbursed {
+			continue
+		}
+
 		for _, schedule := range loan.PaymentSchedules {
 			if schedule.DueDate.Before(today) && !schedule.Paid {
 				delinquentCount++
@@ -38,6 +38,7 @@ func (s *BorrowerUsecaseSuite) TestIsDelinquent() {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
 				mlr.On("GetLoansByBorrowerID", mock.Anything, uint(1)).Return([]domain.Loan{
 					{
+						Status: domain.LoanDisbursed,
 						PaymentSchedules: []domain.PaymentSchedule{
 							{DueDate: time.Now().Add(-24 * time.Hour), Paid: false},
 							{DueDate: time.Now().Add(-48 * time.Hour), Paid: false},
@@ -64,6 +65,66 @@ func (s *BorrowerUsecaseSuite) TestIsDelinquent() {
 			expected:      false,
 			expectedError: nil,
 		},
+		{
+			name:       "Deferred Installments Are Not Delinquent",
+			borrowerID: 2,
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(2)).Return(&domain.Borrower{}, nil)
+				mlr.On("GetLoansByBorrowerID", mock.Anything, uint(2)).Return([]domain.Loan{
+					{
+						Status: domain.LoanDisbursed,
+						PaymentSchedules: []domain.PaymentSchedule{
+							{DueDate: time.Now().Add(-24 * time.Hour), Paid: true, Deferred: true},
+							{DueDate: time.Now().Add(-48 * time.Hour), Paid: true, Deferred: true},
+						},
+					},
+				}, nil)
+			},
+			expected:      false,
+			expectedError: nil,
+		},
+		{
+			name:       "Written-Off Loans Are Not Delinquent",
+			borrowerID: 2,
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(2)).Return(&domain.Borrower{}, nil)
+				mlr.On("GetLoansByBorrowerID", mock.Anything, uint(2)).Return([]domain.Loan{
+					{
+						Status: domain.LoanWrittenOff,
+						PaymentSchedules: []domain.PaymentSchedule{
+							{DueDate: time.Now().Add(-24 * time.Hour), Paid: false},
+							{DueDate: time.Now().Add(-48 * time.Hour), Paid: false},
+						},
+					},
+				}, nil)
+			},
+			expected:      false,
+			expectedError: nil,
+		},
+		{
+			name:       "Loans Never Disbursed Are Not Delinquent",
+			borrowerID: 2,
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(2)).Return(&domain.Borrower{}, nil)
+				mlr.On("GetLoansByBorrowerID", mock.Anything, uint(2)).Return([]domain.Loan{
+					{
+						Status: domain.LoanCancelled,
+						PaymentSchedules: []domain.PaymentSchedule{
+							{DueDate: time.Now().Add(-24 * time.Hour), Paid: false},
+							{DueDate: time.Now().Add(-48 * time.Hour), Paid: false},
+						},
+					},
+					{
+						Status: domain.LoanProposed,
+						PaymentSchedules: []domain.PaymentSchedule{
+							{DueDate: time.Now().Add(-24 * time.Hour), Paid: false},
+						},
+					},
+				}, nil)
+			},
+			expected:      false,
+			expectedError: nil,
+		},
 		{
 			name:       "Error Finding Borrower",
 			borrowerID: 3,
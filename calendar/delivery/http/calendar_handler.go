@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type HolidayCalendarHandler struct {
+	HolidayCalendarUsecase domain.HolidayCalendarUsecase
+}
+
+func NewHolidayCalendarHandler(g *gin.Engine, h domain.HolidayCalendarUsecase) {
+	handler := &HolidayCalendarHandler{HolidayCalendarUsecase: h}
+
+	g.POST("/calendars", handler.SaveCalendar)
+	g.GET("/calendars/:name", handler.GetCalendar)
+	g.POST("/calendars/:name/holidays", handler.AddHoliday)
+}
+
+func (h *HolidayCalendarHandler) SaveCalendar(c *gin.Context) {
+	var req dto.SaveHolidayCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "calendar name is required"})
+		return
+	}
+
+	holidays := make([]domain.Holiday, len(req.Holidays))
+	for i, holidayReq := range req.Holidays {
+		date, err := time.Parse("2006-01-02", holidayReq.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return
+		}
+		holidays[i] = domain.Holiday{Date: date, Description: holidayReq.Description}
+	}
+
+	ctx := c.Request.Context()
+	calendarResponse, err := h.HolidayCalendarUsecase.SaveCalendar(ctx, req.Name, holidays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendarResponse)
+}
+
+func (h *HolidayCalendarHandler) GetCalendar(c *gin.Context) {
+	ctx := c.Request.Context()
+	calendarResponse, err := h.HolidayCalendarUsecase.GetCalendar(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendarResponse)
+}
+
+func (h *HolidayCalendarHandler) AddHoliday(c *gin.Context) {
+	var req dto.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	calendarResponse, err := h.HolidayCalendarUsecase.AddHoliday(ctx, c.Param("name"), domain.Holiday{Date: date, Description: req.Description})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, calendarResponse)
+}
@@ -0,0 +1,206 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	calendarHttp "github.com/greekrode/loan-engine-amartha/calendar/delivery/http"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.HolidayCalendarUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := calendarHttp.HolidayCalendarHandler{
+		HolidayCalendarUsecase: mockUCase,
+	}
+	router.POST("/calendars", handler.SaveCalendar)
+	router.GET("/calendars/:name", handler.GetCalendar)
+	router.POST("/calendars/:name/holidays", handler.AddHoliday)
+	return router
+}
+
+func TestSaveCalendar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	nyepi := domain.Holiday{Date: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), Description: "Nyepi"}
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.HolidayCalendarUsecase
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Calendar",
+			requestBody: `{"name": "ID", "holidays": [{"date": "2024-03-11", "description": "Nyepi"}]}`,
+			mockUsecase: func() *mocks.HolidayCalendarUsecase {
+				mockUsecase := new(mocks.HolidayCalendarUsecase)
+				mockUsecase.On("SaveCalendar", mock.Anything, "ID", []domain.Holiday{nyepi}).Return(&dto.GetHolidayCalendarResponse{
+					ID:       1,
+					Name:     "ID",
+					Holidays: []dto.GetHolidayResponse{{Date: "2024-03-11", Description: "Nyepi"}},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id": 1, "name": "ID", "holidays": [{"date": "2024-03-11", "description": "Nyepi"}]}`,
+		},
+		{
+			name:           "Missing Name",
+			requestBody:    `{"holidays": []}`,
+			mockUsecase:    new(mocks.HolidayCalendarUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"calendar name is required"}`,
+		},
+		{
+			name:           "Invalid Holiday Date",
+			requestBody:    `{"name": "ID", "holidays": [{"date": "11-03-2024", "description": "Nyepi"}]}`,
+			mockUsecase:    new(mocks.HolidayCalendarUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:        "Usecase Error",
+			requestBody: `{"name": "ID", "holidays": [{"date": "2024-03-11", "description": "Nyepi"}]}`,
+			mockUsecase: func() *mocks.HolidayCalendarUsecase {
+				mockUsecase := new(mocks.HolidayCalendarUsecase)
+				mockUsecase.On("SaveCalendar", mock.Anything, "ID", []domain.Holiday{nyepi}).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/calendars", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetCalendar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.HolidayCalendarUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Valid Calendar",
+			mockUsecase: func() *mocks.HolidayCalendarUsecase {
+				mockUsecase := new(mocks.HolidayCalendarUsecase)
+				mockUsecase.On("GetCalendar", mock.Anything, "ID").Return(&dto.GetHolidayCalendarResponse{
+					ID:       1,
+					Name:     "ID",
+					Holidays: []dto.GetHolidayResponse{{Date: "2024-03-11", Description: "Nyepi"}},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id": 1, "name": "ID", "holidays": [{"date": "2024-03-11", "description": "Nyepi"}]}`,
+		},
+		{
+			name: "Calendar Not Found",
+			mockUsecase: func() *mocks.HolidayCalendarUsecase {
+				mockUsecase := new(mocks.HolidayCalendarUsecase)
+				mockUsecase.On("GetCalendar", mock.Anything, "ID").Return(nil, errors.New("Holiday calendar not found"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"Holiday calendar not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", "/calendars/ID", nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestAddHoliday(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.HolidayCalendarUsecase
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Holiday",
+			requestBody: `{"date": "2024-04-10", "description": "Idul Fitri"}`,
+			mockUsecase: func() *mocks.HolidayCalendarUsecase {
+				mockUsecase := new(mocks.HolidayCalendarUsecase)
+				mockUsecase.On("AddHoliday", mock.Anything, "ID", domain.Holiday{
+					Date:        time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC),
+					Description: "Idul Fitri",
+				}).Return(&dto.GetHolidayCalendarResponse{
+					ID:       1,
+					Name:     "ID",
+					Holidays: []dto.GetHolidayResponse{{Date: "2024-04-10", Description: "Idul Fitri"}},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id": 1, "name": "ID", "holidays": [{"date": "2024-04-10", "description": "Idul Fitri"}]}`,
+		},
+		{
+			name:           "Invalid Date",
+			requestBody:    `{"date": "10/04/2024", "description": "Idul Fitri"}`,
+			mockUsecase:    new(mocks.HolidayCalendarUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/calendars/ID/holidays", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqliteHolidayCalendarRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteHolidayCalendarRepository(tm db.TransactionManager) *sqliteHolidayCalendarRepository {
+	return &sqliteHolidayCalendarRepository{TransactionManager: tm}
+}
+
+func (s *sqliteHolidayCalendarRepository) SaveCalendar(ctx context.Context, calendar *domain.HolidayCalendar, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Omit("Holidays").Where(domain.HolidayCalendar{Name: calendar.Name}).FirstOrCreate(calendar).Error
+}
+
+func (s *sqliteHolidayCalendarRepository) CreateHoliday(ctx context.Context, holiday *domain.Holiday, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(holiday).Error
+}
+
+func (s *sqliteHolidayCalendarRepository) ReplaceHolidays(ctx context.Context, calendarID uint, holidays []domain.Holiday, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	err := tx.WithContext(ctx).Where("calendar_id = ?", calendarID).Delete(&domain.Holiday{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(holidays) == 0 {
+		return nil
+	}
+
+	for i := range holidays {
+		holidays[i].CalendarID = calendarID
+	}
+
+	return tx.WithContext(ctx).Create(&holidays).Error
+}
+
+func (s *sqliteHolidayCalendarRepository) FindCalendarByName(ctx context.Context, name string) (*domain.HolidayCalendar, error) {
+	var calendar domain.HolidayCalendar
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("name = ?", name).Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date")
+	}).First(&calendar).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Holiday calendar not found")
+		}
+		return nil, err
+	}
+
+	return &calendar, nil
+}
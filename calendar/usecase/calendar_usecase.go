@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type holidayCalendarUsecase struct {
+	calendarRepo       domain.HolidayCalendarRepository
+	transactionManager db.TransactionManager
+	contextTimeout     time.Duration
+}
+
+func NewHolidayCalendarUsecase(c domain.HolidayCalendarRepository, tm db.TransactionManager, timeout time.Duration) domain.HolidayCalendarUsecase {
+	return &holidayCalendarUsecase{
+		calendarRepo:       c,
+		transactionManager: tm,
+		contextTimeout:     timeout,
+	}
+}
+
+func (h *holidayCalendarUsecase) SaveCalendar(ctx context.Context, name string, holidays []domain.Holiday) (*dto.GetHolidayCalendarResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.contextTimeout)
+	defer cancel()
+
+	tx := h.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			h.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	calendar := domain.HolidayCalendar{Name: name}
+	if err := h.calendarRepo.SaveCalendar(ctx, &calendar, tx); err != nil {
+		h.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := h.calendarRepo.ReplaceHolidays(ctx, calendar.ID, holidays, tx); err != nil {
+		h.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := h.transactionManager.Commit(tx); err != nil {
+		h.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	calendar.Holidays = holidays
+	return assembleHolidayCalendarResponse(&calendar), nil
+}
+
+func (h *holidayCalendarUsecase) GetCalendar(ctx context.Context, name string) (*dto.GetHolidayCalendarResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.contextTimeout)
+	defer cancel()
+
+	calendar, err := h.calendarRepo.FindCalendarByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleHolidayCalendarResponse(calendar), nil
+}
+
+func (h *holidayCalendarUsecase) AddHoliday(ctx context.Context, name string, holiday domain.Holiday) (*dto.GetHolidayCalendarResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.contextTimeout)
+	defer cancel()
+
+	calendar, err := h.calendarRepo.FindCalendarByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday.CalendarID = calendar.ID
+	if err := h.calendarRepo.CreateHoliday(ctx, &holiday, nil); err != nil {
+		return nil, err
+	}
+
+	calendar.Holidays = append(calendar.Holidays, holiday)
+	return assembleHolidayCalendarResponse(calendar), nil
+}
+
+// LoadCalendarsFromFile imports calendars from a JSON file holding a list of
+// calendars in the same shape as the admin API request body. Existing calendars
+// with the same name are replaced.
+func (h *holidayCalendarUsecase) LoadCalendarsFromFile(ctx context.Context, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var calendars []dto.SaveHolidayCalendarRequest
+	if err := json.Unmarshal(content, &calendars); err != nil {
+		return err
+	}
+
+	for _, calendar := range calendars {
+		holidays, err := parseHolidays(calendar.Holidays)
+		if err != nil {
+			return err
+		}
+
+		if _, err := h.SaveCalendar(ctx, calendar.Name, holidays); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseHolidays(requests []dto.HolidayRequest) ([]domain.Holiday, error) {
+	holidays := make([]domain.Holiday, len(requests))
+	for i, request := range requests {
+		date, err := time.Parse("2006-01-02", request.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		holidays[i] = domain.Holiday{
+			Date:        date,
+			Description: request.Description,
+		}
+	}
+
+	return holidays, nil
+}
+
+func assembleHolidayCalendarResponse(calendar *domain.HolidayCalendar) *dto.GetHolidayCalendarResponse {
+	holidayResponses := make([]dto.GetHolidayResponse, len(calendar.Holidays))
+	for i, holiday := range calendar.Holidays {
+		holidayResponses[i] = dto.GetHolidayResponse{
+			Date:        holiday.Date.Format("2006-01-02"),
+			Description: holiday.Description,
+		}
+	}
+
+	return &dto.GetHolidayCalendarResponse{
+		ID:       calendar.ID,
+		Name:     calendar.Name,
+		Holidays: holidayResponses,
+	}
+}
@@ -0,0 +1,164 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	calendarUsecase "github.com/greekrode/loan-engine-amartha/calendar/usecase"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type HolidayCalendarUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *HolidayCalendarUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *HolidayCalendarUsecaseSuite) TestSaveCalendar() {
+	nyepi := domain.Holiday{Date: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), Description: "Nyepi"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.HolidayCalendarRepository, *mocks.TransactionManager)
+		expected      *dto.GetHolidayCalendarResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Save",
+			setupMocks: func(mcr *mocks.HolidayCalendarRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mcr.On("SaveCalendar", mock.Anything, mock.AnythingOfType("*domain.HolidayCalendar"), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.HolidayCalendar).ID = 1
+				}).Return(nil)
+				mcr.On("ReplaceHolidays", mock.Anything, uint(1), []domain.Holiday{nyepi}, mock.Anything).Return(nil)
+			},
+			expected: &dto.GetHolidayCalendarResponse{
+				ID:   1,
+				Name: "ID",
+				Holidays: []dto.GetHolidayResponse{
+					{Date: "2024-03-11", Description: "Nyepi"},
+				},
+			},
+		},
+		{
+			name: "Error Replacing Holidays",
+			setupMocks: func(mcr *mocks.HolidayCalendarRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mcr.On("SaveCalendar", mock.Anything, mock.AnythingOfType("*domain.HolidayCalendar"), mock.Anything).Return(nil)
+				mcr.On("ReplaceHolidays", mock.Anything, uint(0), []domain.Holiday{nyepi}, mock.Anything).Return(errors.New("error replacing holidays"))
+			},
+			expectedError: errors.New("error replacing holidays"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := calendarUsecase.NewHolidayCalendarUsecase(mockCalendarRepo, mockTransactionManager, s.timeout)
+
+			tt.setupMocks(mockCalendarRepo, mockTransactionManager)
+			result, err := uc.SaveCalendar(context.TODO(), "ID", []domain.Holiday{nyepi})
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *HolidayCalendarUsecaseSuite) TestAddHoliday() {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.HolidayCalendarRepository)
+		expected      *dto.GetHolidayCalendarResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Add",
+			setupMocks: func(mcr *mocks.HolidayCalendarRepository) {
+				mcr.On("FindCalendarByName", mock.Anything, "ID").Return(&domain.HolidayCalendar{Model: gorm.Model{ID: 1}, Name: "ID"}, nil)
+				mcr.On("CreateHoliday", mock.Anything, mock.MatchedBy(func(h *domain.Holiday) bool {
+					return h.CalendarID == 1
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.GetHolidayCalendarResponse{
+				ID:   1,
+				Name: "ID",
+				Holidays: []dto.GetHolidayResponse{
+					{Date: "2024-04-10", Description: "Idul Fitri"},
+				},
+			},
+		},
+		{
+			name: "Calendar Not Found",
+			setupMocks: func(mcr *mocks.HolidayCalendarRepository) {
+				mcr.On("FindCalendarByName", mock.Anything, "ID").Return(nil, errors.New("Holiday calendar not found"))
+			},
+			expectedError: errors.New("Holiday calendar not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := calendarUsecase.NewHolidayCalendarUsecase(mockCalendarRepo, mockTransactionManager, s.timeout)
+
+			tt.setupMocks(mockCalendarRepo)
+			result, err := uc.AddHoliday(context.TODO(), "ID", domain.Holiday{
+				Date:        time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC),
+				Description: "Idul Fitri",
+			})
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *HolidayCalendarUsecaseSuite) TestLoadCalendarsFromFile() {
+	mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+	mockTransactionManager := new(mocks.TransactionManager)
+
+	mockTransactionManager.On("Begin").Return(&gorm.DB{})
+	mockTransactionManager.On("Commit", mock.Anything).Return(nil)
+	mockCalendarRepo.On("SaveCalendar", mock.Anything, mock.MatchedBy(func(c *domain.HolidayCalendar) bool {
+		return c.Name == "ID"
+	}), mock.Anything).Return(nil)
+	mockCalendarRepo.On("ReplaceHolidays", mock.Anything, uint(0), mock.MatchedBy(func(h []domain.Holiday) bool {
+		return len(h) == 3 && h[0].Description == "Nyepi" && h[0].Date.Equal(time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC))
+	}), mock.Anything).Return(nil)
+
+	uc := calendarUsecase.NewHolidayCalendarUsecase(mockCalendarRepo, mockTransactionManager, s.timeout)
+
+	assert.NoError(s.T(), uc.LoadCalendarsFromFile(context.TODO(), "testdata/holidays.json"))
+	assert.Error(s.T(), uc.LoadCalendarsFromFile(context.TODO(), "testdata/missing.json"))
+	mockCalendarRepo.AssertExpectations(s.T())
+}
+
+func TestHolidayCalendarUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(HolidayCalendarUsecaseSuite))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/greekrode/loan-engine-amartha/domain"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var DB *gorm.DB
@@ -18,7 +19,12 @@ func InitDB() {
 		log.Fatalf("failed to connect database: %v", err)
 	}
 
-	DB.AutoMigrate(&domain.Borrower{}, &domain.Loan{}, &domain.PaymentSchedule{}, &domain.Payment{})
+	if err := MigrateMoneyToMinorUnits(DB); err != nil {
+		log.Fatalf("failed to migrate money amounts to minor units: %v", err)
+	}
+
+	DB.AutoMigrate(&domain.Borrower{}, &domain.Loan{}, &domain.PaymentSchedule{}, &domain.Payment{}, &domain.HolidayCalendar{}, &domain.Holiday{}, &domain.LoanProduct{}, &domain.LoanProductFee{}, &domain.LoanFee{}, &domain.LoanStatusChange{}, &domain.LoanApproval{}, &domain.Investor{}, &domain.LoanInvestment{}, &domain.InvestorReturn{}, &domain.PaymentAllocation{}, &domain.PenaltyCharge{}, &domain.LoanRestructure{}, &domain.LoanDeferral{}, &domain.LoanWriteOff{}, &domain.LoanRefinance{}, &domain.BorrowerGroup{}, &domain.GroupSettlement{}, &domain.MemberDebt{}, &domain.Account{}, &domain.JournalEntry{}, &domain.Posting{}, &domain.InterestAccrual{}, &domain.PaymentReversal{}, &domain.Refund{}, &domain.IdempotencyKey{})
+	DB.Clauses(clause.OnConflict{DoNothing: true}).Create(domain.ChartOfAccounts())
 
 	TrxManager = NewGormTransactionManager(DB)
 }
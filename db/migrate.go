@@ -0,0 +1,60 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+// legacyMoneyColumns are the amounts that were stored as float currency units
+// before money was kept in int64 minor units.
+var legacyMoneyColumns = []struct {
+	model   interface{}
+	table   string
+	columns []string
+}{
+	{&domain.Loan{}, "loans", []string{"principal", "outstanding_amount"}},
+	{&domain.PaymentSchedule{}, "payment_schedules", []string{"due_amount"}},
+	{&domain.Payment{}, "payments", []string{"amount"}},
+}
+
+// MigrateMoneyToMinorUnits converts the amounts of a database created before
+// money was kept in minor units: each is multiplied by 100, rounded and its
+// column turned into an integer one. Columns already holding integers are left
+// alone, so it is safe to run on every start, and it must run before
+// AutoMigrate, which would otherwise read the old amounts as minor units.
+func MigrateMoneyToMinorUnits(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, legacy := range legacyMoneyColumns {
+			if !tx.Migrator().HasTable(legacy.table) {
+				continue
+			}
+
+			columnTypes, err := tx.Migrator().ColumnTypes(legacy.table)
+			if err != nil {
+				return err
+			}
+
+			for _, columnType := range columnTypes {
+				if !slices.Contains(legacy.columns, columnType.Name()) || !strings.EqualFold(columnType.DatabaseTypeName(), "real") {
+					continue
+				}
+
+				err = tx.Exec(fmt.Sprintf("UPDATE `%s` SET `%s` = ROUND(`%s` * 100)", legacy.table, columnType.Name(), columnType.Name())).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Migrator().AlterColumn(legacy.model, columnType.Name())
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MigrateSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (s *MigrateSuite) SetupTest() {
+	var err error
+	s.db, err = gorm.Open(sqlite.Open(filepath.Join(s.T().TempDir(), "data.db")), &gorm.Config{})
+	s.Require().NoError(err)
+}
+
+// legacySchema is how loans, their installments and payments were stored
+// while amounts were float currency units.
+var legacySchema = []string{
+	"CREATE TABLE `loans` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`borrower_id` integer NOT NULL,`principal` real NOT NULL,`interest_rate` real NOT NULL,`duration_weeks` integer NOT NULL,`amortization_method` text NOT NULL DEFAULT \"flat\",`outstanding_amount` real NOT NULL,`start_date` datetime NOT NULL)",
+	"CREATE TABLE `payment_schedules` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`due_amount` real NOT NULL,`due_date` datetime NOT NULL,`paid` numeric NOT NULL DEFAULT false,`loan_id` integer NOT NULL)",
+	"CREATE TABLE `payments` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`loan_id` integer NOT NULL,`amount` real NOT NULL)",
+	"INSERT INTO `loans` (`id`,`borrower_id`,`principal`,`interest_rate`,`duration_weeks`,`outstanding_amount`,`start_date`) VALUES (1,1,5000000.0,10,50,5370768.99,'2024-01-01')",
+	"INSERT INTO `payment_schedules` (`id`,`due_amount`,`due_date`,`paid`,`loan_id`) VALUES (1,109615.38,'2024-01-08',true,1),(2,109615.38,'2024-01-15',false,1)",
+	"INSERT INTO `payments` (`id`,`loan_id`,`amount`) VALUES (1,1,109615.38)",
+}
+
+func (s *MigrateSuite) TestMigrateLegacyDatabase() {
+	for _, statement := range legacySchema {
+		s.Require().NoError(s.db.Exec(statement).Error)
+	}
+
+	// Running it again finds nothing left to convert.
+	s.Require().NoError(db.MigrateMoneyToMinorUnits(s.db))
+	s.Require().NoError(db.MigrateMoneyToMinorUnits(s.db))
+
+	var loan domain.Loan
+	s.Require().NoError(s.db.Table("loans").Select("principal, outstanding_amount").First(&loan).Error)
+	s.Equal(money.FromFloat(5000000), loan.Principal)
+	s.Equal(money.FromFloat(5370768.99), loan.OutstandingAmount)
+
+	var schedules []domain.PaymentSchedule
+	s.Require().NoError(s.db.Table("payment_schedules").Select("due_amount").Order("id").Find(&schedules).Error)
+	s.Require().Len(schedules, 2)
+	s.Equal(money.FromFloat(109615.38), schedules[0].DueAmount)
+	s.Equal(money.FromFloat(109615.38), schedules[1].DueAmount)
+
+	var payment domain.Payment
+	s.Require().NoError(s.db.Table("payments").Select("amount").First(&payment).Error)
+	s.Equal(money.FromFloat(109615.38), payment.Amount)
+}
+
+func (s *MigrateSuite) TestMigrateCurrentDatabase() {
+	s.Require().NoError(s.db.AutoMigrate(&domain.Loan{}, &domain.PaymentSchedule{}, &domain.Payment{}))
+	s.Require().NoError(s.db.Create(&domain.Payment{LoanID: 1, Amount: money.FromFloat(250.5)}).Error)
+
+	s.Require().NoError(db.MigrateMoneyToMinorUnits(s.db))
+
+	var payment domain.Payment
+	s.Require().NoError(s.db.First(&payment).Error)
+	s.Equal(money.FromFloat(250.5), payment.Amount)
+}
+
+func (s *MigrateSuite) TestMigrateEmptyDatabase() {
+	s.NoError(db.MigrateMoneyToMinorUnits(s.db))
+}
+
+func TestMigrateSuite(t *testing.T) {
+	suite.Run(t, new(MigrateSuite))
+}
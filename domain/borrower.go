@@ -9,9 +9,11 @@ import (
 
 type Borrower struct {
 	gorm.Model
-	FirstName string `gorm:"not null" faker:"first_name"`
-	LastName  string `gorm:"not null" faker:"last_name"`
-	Email     string `gorm:"not null" faker:"email"`
+	FirstName string         `gorm:"not null" faker:"first_name"`
+	LastName  string         `gorm:"not null" faker:"last_name"`
+	Email     string         `gorm:"not null" faker:"email"`
+	GroupID   *uint          `gorm:"index" faker:"-"`
+	Group     *BorrowerGroup `faker:"-"`
 }
 
 type BorrowerUsecase interface {
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// BorrowerGroup is a majelis: borrowers from one village who meet weekly
+// with their leader, on the same weekday, to repay together. Installments
+// of loans made to members fall due on the meeting day.
+type BorrowerGroup struct {
+	gorm.Model
+	Name       string       `gorm:"not null" json:"name"`
+	Village    string       `gorm:"not null;index" json:"village"`
+	MeetingDay time.Weekday `gorm:"not null" json:"meeting_day"`
+	LeaderID   uint         `gorm:"not null" json:"leader_id"`
+	Members    []Borrower   `gorm:"foreignKey:GroupID"`
+}
+
+func (g BorrowerGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return fmt.Errorf("group name is required")
+	}
+	if strings.TrimSpace(g.Village) == "" {
+		return fmt.Errorf("village is required")
+	}
+	if g.MeetingDay < time.Sunday || g.MeetingDay > time.Saturday {
+		return fmt.Errorf("invalid meeting day")
+	}
+	if g.LeaderID == 0 {
+		return fmt.Errorf("leader ID is required")
+	}
+	return nil
+}
+
+// NextMeeting returns the first meeting on or after the given date.
+func (g BorrowerGroup) NextMeeting(date time.Time) time.Time {
+	return NextWeekday(date, g.MeetingDay)
+}
+
+// NextWeekday returns the first date on or after date that falls on day.
+func NextWeekday(date time.Time, day time.Weekday) time.Time {
+	days := (int(day) - int(date.Weekday()) + 7) % 7
+	return date.AddDate(0, 0, days)
+}
+
+// ParseWeekday reads a weekday name such as "tuesday", ignoring case.
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid meeting day")
+}
+
+type BorrowerGroupUsecase interface {
+	CreateGroup(ctx context.Context, group BorrowerGroup, memberIDs []uint) (*dto.GetBorrowerGroupResponse, error)
+	GetGroup(ctx context.Context, groupID uint) (*dto.GetBorrowerGroupResponse, error)
+	AddMember(ctx context.Context, groupID uint, borrowerID uint) (*dto.GetBorrowerGroupResponse, error)
+	GetNextMeeting(ctx context.Context, groupID uint, date time.Time) (*dto.GroupMeetingResponse, error)
+
+	GetMemberDebts(ctx context.Context, groupID uint) (*dto.GroupDebtsResponse, error)
+	RepayMemberDebt(ctx context.Context, groupID uint, debtID uint, amount money.Money) (*dto.MemberDebtResponse, error)
+}
+
+type BorrowerGroupRepository interface {
+	CreateGroup(ctx context.Context, group *BorrowerGroup, tx *gorm.DB) error
+	FindGroupByID(ctx context.Context, groupID uint) (*BorrowerGroup, error)
+	AssignMembers(ctx context.Context, groupID uint, borrowerIDs []uint, tx *gorm.DB) error
+
+	CreateMemberDebts(ctx context.Context, debts []MemberDebt, tx *gorm.DB) error
+	FindMemberDebtByID(ctx context.Context, debtID uint) (*MemberDebt, error)
+	GetMemberDebtsByGroupID(ctx context.Context, groupID uint) ([]MemberDebt, error)
+	UpdateMemberDebt(ctx context.Context, debt *MemberDebt, tx *gorm.DB) error
+}
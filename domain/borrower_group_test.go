@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type BorrowerGroupSuite struct {
+	suite.Suite
+}
+
+func (s *BorrowerGroupSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		modify        func(*domain.BorrowerGroup)
+		expectedError string
+	}{
+		{
+			name:   "Valid Group",
+			modify: func(g *domain.BorrowerGroup) {},
+		},
+		{
+			name:          "Missing Name",
+			modify:        func(g *domain.BorrowerGroup) { g.Name = " " },
+			expectedError: "group name is required",
+		},
+		{
+			name:          "Missing Village",
+			modify:        func(g *domain.BorrowerGroup) { g.Village = "" },
+			expectedError: "village is required",
+		},
+		{
+			name:          "Invalid Meeting Day",
+			modify:        func(g *domain.BorrowerGroup) { g.MeetingDay = 7 },
+			expectedError: "invalid meeting day",
+		},
+		{
+			name:          "Missing Leader",
+			modify:        func(g *domain.BorrowerGroup) { g.LeaderID = 0 },
+			expectedError: "leader ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			group := domain.BorrowerGroup{
+				Name:       "Mawar",
+				Village:    "Ciseeng",
+				MeetingDay: time.Tuesday,
+				LeaderID:   1,
+			}
+			tt.modify(&group)
+
+			err := group.Validate()
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *BorrowerGroupSuite) TestNextMeeting() {
+	group := domain.BorrowerGroup{MeetingDay: time.Tuesday}
+
+	tests := []struct {
+		date     time.Time
+		expected time.Time
+	}{
+		{time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, time.January, 4, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.date.Format("2006-01-02"), func() {
+			s.Equal(tt.expected, group.NextMeeting(tt.date))
+		})
+	}
+}
+
+func (s *BorrowerGroupSuite) TestParseWeekday() {
+	day, err := domain.ParseWeekday("Friday")
+	s.NoError(err)
+	s.Equal(time.Friday, day)
+
+	day, err = domain.ParseWeekday("sunday")
+	s.NoError(err)
+	s.Equal(time.Sunday, day)
+
+	_, err = domain.ParseWeekday("market day")
+	s.EqualError(err, "invalid meeting day")
+}
+
+func TestBorrowerGroupSuite(t *testing.T) {
+	suite.Run(t, new(BorrowerGroupSuite))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// DayCount is the convention that measures how much of an interest period has
+// elapsed when interest is accrued.
+type DayCount string
+
+const (
+	DayCountActual365 DayCount = "act/365"
+	DayCount30360     DayCount = "30/360"
+)
+
+func (d DayCount) IsValid() bool {
+	switch d {
+	case DayCountActual365, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// YearFraction returns the part of a year between two dates. ACT/365 counts
+// the calendar days over a 365-day year. 30/360 counts every month as 30 days
+// over a 360-day year, treating the 31st as the 30th the way US bond markets
+// do.
+func (d DayCount) YearFraction(start, end time.Time) float64 {
+	if d == DayCount30360 {
+		y1, m1, d1 := start.Date()
+		y2, m2, d2 := end.Date()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && d1 == 30 {
+			d2 = 30
+		}
+		days := 360*(y2-y1) + 30*int(m2-m1) + (d2 - d1)
+		return float64(days) / 360
+	}
+
+	return end.Sub(start).Hours() / 24 / 365
+}
+
+// Accrue returns the part of an installment's interest earned by the given
+// date. The interest is earned evenly over the period from start to the due
+// date as the convention measures it, so the whole of it has been earned once
+// the installment falls due.
+func (d DayCount) Accrue(interest money.Money, start, due, date time.Time) money.Money {
+	if !date.After(start) {
+		return 0
+	}
+	if !date.Before(due) {
+		return interest
+	}
+
+	period := d.YearFraction(start, due)
+	if period <= 0 {
+		return interest
+	}
+
+	elapsed := d.YearFraction(start, date) / period
+	switch {
+	case elapsed <= 0:
+		return 0
+	case elapsed >= 1:
+		return interest
+	}
+	return interest.MulRate(elapsed)
+}
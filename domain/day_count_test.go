@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type DayCountSuite struct {
+	suite.Suite
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *DayCountSuite) TestYearFraction() {
+	tests := []struct {
+		name     string
+		dayCount domain.DayCount
+		start    time.Time
+		end      time.Time
+		expected float64
+	}{
+		{"Actual Days Over 365", domain.DayCountActual365, date(2024, time.January, 1), date(2024, time.July, 1), 182.0 / 365},
+		{"Thirty Day Months", domain.DayCount30360, date(2024, time.January, 1), date(2024, time.July, 1), 180.0 / 360},
+		{"Thirty First Starts On The Thirtieth", domain.DayCount30360, date(2024, time.January, 31), date(2024, time.March, 1), 31.0 / 360},
+		{"Thirty First Ends On The Thirtieth", domain.DayCount30360, date(2024, time.January, 30), date(2024, time.March, 31), 60.0 / 360},
+		{"Thirty First Kept After Short Month", domain.DayCount30360, date(2023, time.February, 28), date(2023, time.March, 31), 33.0 / 360},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.InDelta(tt.expected, tt.dayCount.YearFraction(tt.start, tt.end), 1e-9)
+		})
+	}
+}
+
+func (s *DayCountSuite) TestAccrue() {
+	interest := money.FromFloat(31)
+	start := date(2024, time.January, 1)
+	due := date(2024, time.February, 1)
+
+	tests := []struct {
+		name     string
+		dayCount domain.DayCount
+		date     time.Time
+		expected money.Money
+	}{
+		{"Before The Period", domain.DayCountActual365, date(2023, time.December, 31), 0},
+		{"On The Period Start", domain.DayCountActual365, start, 0},
+		{"Actual Days Elapsed", domain.DayCountActual365, date(2024, time.January, 16), money.FromFloat(15)},
+		{"Thirty Day Months Elapsed", domain.DayCount30360, date(2024, time.January, 16), money.FromFloat(15.50)},
+		{"On The Due Date", domain.DayCount30360, due, interest},
+		{"After The Due Date", domain.DayCountActual365, date(2024, time.March, 1), interest},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, tt.dayCount.Accrue(interest, start, due, tt.date))
+		})
+	}
+}
+
+func TestDayCountSuite(t *testing.T) {
+	suite.Run(t, new(DayCountSuite))
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type CreateBorrowerGroupRequest struct {
+	Name       string `json:"name"`
+	Village    string `json:"village"`
+	MeetingDay string `json:"meeting_day"`
+	LeaderID   uint   `json:"leader_id"`
+	MemberIDs  []uint `json:"member_ids"`
+}
+
+type AddGroupMemberRequest struct {
+	BorrowerID uint `json:"borrower_id"`
+}
+
+type GetBorrowerGroupResponse struct {
+	ID         uint                  `json:"id"`
+	Name       string                `json:"name"`
+	Village    string                `json:"village"`
+	MeetingDay string                `json:"meeting_day"`
+	LeaderID   uint                  `json:"leader_id"`
+	Members    []GetBorrowerResponse `json:"members"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+type GroupInstallmentResponse struct {
+	BorrowerID        uint        `json:"borrower_id"`
+	LoanID            uint        `json:"loan_id"`
+	PaymentScheduleID uint        `json:"payment_schedule_id"`
+	DueDate           time.Time   `json:"due_date"`
+	DueAmount         money.Money `json:"due_amount"`
+	PaidAmount        money.Money `json:"paid_amount"`
+	OutstandingAmount money.Money `json:"outstanding_amount"`
+	Overdue           bool        `json:"overdue"`
+}
+
+type GroupMeetingResponse struct {
+	GroupID      uint                       `json:"group_id"`
+	MeetingDate  time.Time                  `json:"meeting_date"`
+	TotalDue     money.Money                `json:"total_due"`
+	Installments []GroupInstallmentResponse `json:"installments"`
+}
+
+type SettleGroupArrearsRequest struct {
+	PayerID uint        `json:"payer_id"`
+	Amount  money.Money `json:"amount"`
+}
+
+type SettlementPaymentResponse struct {
+	PaymentID         uint                        `json:"payment_id"`
+	LoanID            uint                        `json:"loan_id"`
+	BorrowerID        uint                        `json:"borrower_id"`
+	Amount            money.Money                 `json:"amount"`
+	OutstandingAmount money.Money                 `json:"outstanding_amount"`
+	LoanStatus        string                      `json:"loan_status"`
+	Allocations       []PaymentAllocationResponse `json:"allocations"`
+}
+
+type GroupSettlementResponse struct {
+	SettlementID uint                        `json:"settlement_id"`
+	GroupID      uint                        `json:"group_id"`
+	PayerID      uint                        `json:"payer_id"`
+	Amount       money.Money                 `json:"amount"`
+	Payments     []SettlementPaymentResponse `json:"payments"`
+	Debts        []MemberDebtResponse        `json:"debts"`
+}
+
+type MemberDebtResponse struct {
+	ID                uint        `json:"id"`
+	SettlementID      uint        `json:"settlement_id"`
+	CreditorID        uint        `json:"creditor_id"`
+	DebtorID          uint        `json:"debtor_id"`
+	Amount            money.Money `json:"amount"`
+	RepaidAmount      money.Money `json:"repaid_amount"`
+	OutstandingAmount money.Money `json:"outstanding_amount"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+type GroupDebtsResponse struct {
+	GroupID          uint                 `json:"group_id"`
+	TotalOutstanding money.Money          `json:"total_outstanding"`
+	Debts            []MemberDebtResponse `json:"debts"`
+}
+
+type RepayMemberDebtRequest struct {
+	Amount money.Money `json:"amount"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type HolidayRequest struct {
+	Date        string `json:"date"`
+	Description string `json:"description"`
+}
+
+type SaveHolidayCalendarRequest struct {
+	Name     string           `json:"name"`
+	Holidays []HolidayRequest `json:"holidays"`
+}
+
+type GetHolidayResponse struct {
+	Date        string `json:"date"`
+	Description string `json:"description"`
+}
+
+type GetHolidayCalendarResponse struct {
+	ID       uint                 `json:"id"`
+	Name     string               `json:"name"`
+	Holidays []GetHolidayResponse `json:"holidays"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type InterestAccrualResponse struct {
+	LoanID            uint        `json:"loan_id"`
+	PaymentScheduleID uint        `json:"payment_schedule_id"`
+	DayCount          string      `json:"day_count"`
+	Amount            money.Money `json:"amount"`
+}
+
+type AccrueInterestResponse struct {
+	AccruedOn     time.Time                 `json:"accrued_on"`
+	LoansAccrued  int                       `json:"loans_accrued"`
+	TotalInterest money.Money               `json:"total_interest"`
+	Accruals      []InterestAccrualResponse `json:"accruals"`
+}
+
+type LoanAccruedInterestResponse struct {
+	LoanID          uint        `json:"loan_id"`
+	DayCount        string      `json:"day_count"`
+	AccruedInterest money.Money `json:"accrued_interest"`
+}
+
+type AccruedInterestReportResponse struct {
+	Date                 time.Time                     `json:"date"`
+	TotalAccruedInterest money.Money                   `json:"total_accrued_interest"`
+	Loans                []LoanAccruedInterestResponse `json:"loans"`
+}
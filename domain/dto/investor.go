@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type CreateInvestorRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type GetInvestorResponse struct {
+	ID          uint                        `json:"id"`
+	Name        string                      `json:"name"`
+	Email       string                      `json:"email"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	Investments []GetLoanInvestmentResponse `json:"investments"`
+	TotalReturn money.Money                 `json:"total_return"`
+	Returns     []GetInvestorReturnResponse `json:"returns"`
+}
+
+type GetInvestorReturnResponse struct {
+	PaymentID  uint        `json:"payment_id"`
+	LoanID     uint        `json:"loan_id"`
+	Principal  money.Money `json:"principal"`
+	Interest   money.Money `json:"interest"`
+	ServiceFee money.Money `json:"service_fee"`
+	NetAmount  money.Money `json:"net_amount"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type InvestInLoanRequest struct {
+	InvestorID uint        `json:"investor_id"`
+	Amount     money.Money `json:"amount"`
+}
+
+type GetLoanInvestmentResponse struct {
+	ID         uint        `json:"id"`
+	LoanID     uint        `json:"loan_id"`
+	InvestorID uint        `json:"investor_id"`
+	Amount     money.Money `json:"amount"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type InvestInLoanResponse struct {
+	Investment    GetLoanInvestmentResponse `json:"investment"`
+	TotalInvested money.Money               `json:"total_invested"`
+	Remaining     money.Money               `json:"remaining"`
+	LoanStatus    string                    `json:"loan_status"`
+}
+
+type GetLoanInvestmentsResponse struct {
+	LoanID        uint                        `json:"loan_id"`
+	Principal     money.Money                 `json:"principal"`
+	TotalInvested money.Money                 `json:"total_invested"`
+	Remaining     money.Money                 `json:"remaining"`
+	Investments   []GetLoanInvestmentResponse `json:"investments"`
+}
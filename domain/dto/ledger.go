@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type TrialBalanceAccountResponse struct {
+	Code    string      `json:"code"`
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Debit   money.Money `json:"debit"`
+	Credit  money.Money `json:"credit"`
+	Balance money.Money `json:"balance"`
+}
+
+type TrialBalanceResponse struct {
+	Date        time.Time                     `json:"date"`
+	TotalDebit  money.Money                   `json:"total_debit"`
+	TotalCredit money.Money                   `json:"total_credit"`
+	Balanced    bool                          `json:"balanced"`
+	Accounts    []TrialBalanceAccountResponse `json:"accounts"`
+}
@@ -1,36 +1,181 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
 
 type CreateLoanRequest struct {
-	BorrowerID   uint    `json:"borrower_id"`
-	Principal    float64 `json:"principal"`
-	InterestRate float64 `json:"interest_rate"`
-	Duration     int     `json:"duration"`
-	StartDate    string  `json:"start_date"`
+	BorrowerID     uint        `json:"borrower_id"`
+	ProductID      uint        `json:"product_id"`
+	Principal      money.Money `json:"principal"`
+	Duration       int         `json:"duration"`
+	Frequency      string      `json:"frequency"`
+	StartDate      string      `json:"start_date"`
+	Calendar       string      `json:"calendar"`
+	RollConvention string      `json:"roll_convention"`
+}
+
+type GetLoanFeeResponse struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Charge string      `json:"charge"`
+	Amount money.Money `json:"amount"`
 }
 
 type CreateLoanResponse struct {
-	ID                uint                         `json:"id"`
-	Principal         float64                      `json:"principal"`
-	InterestRate      float64                      `json:"interest_rate"`
-	Duration          int                          `json:"duration"`
-	StartDate         time.Time                    `json:"start_date"`
-	OutstandingAmount float64                      `json:"outstanding_amount"`
-	PaymentSchedules  []GetPaymentScheduleResponse `json:"payment_schedules"`
+	ID                 uint                         `json:"id"`
+	ProductID          uint                         `json:"product_id"`
+	Principal          money.Money                  `json:"principal"`
+	TotalFees          money.Money                  `json:"total_fees"`
+	NetDisbursed       money.Money                  `json:"net_disbursed"`
+	Fees               []GetLoanFeeResponse         `json:"fees"`
+	InterestRate       float64                      `json:"interest_rate"`
+	Duration           int                          `json:"duration"`
+	Frequency          string                       `json:"frequency"`
+	AmortizationMethod string                       `json:"amortization_method"`
+	StartDate          time.Time                    `json:"start_date"`
+	Calendar           string                       `json:"calendar,omitempty"`
+	RollConvention     string                       `json:"roll_convention,omitempty"`
+	Status             string                       `json:"status"`
+	OutstandingAmount  money.Money                  `json:"outstanding_amount"`
+	RefinancedLoanID   *uint                        `json:"refinanced_loan_id,omitempty"`
+	RefinancePayoff    money.Money                  `json:"refinance_payoff,omitempty"`
+	PaymentSchedules   []GetPaymentScheduleResponse `json:"payment_schedules"`
+}
+
+type QuoteLoanResponse struct {
+	ProductID          uint                         `json:"product_id"`
+	Principal          money.Money                  `json:"principal"`
+	TotalFees          money.Money                  `json:"total_fees"`
+	NetDisbursed       money.Money                  `json:"net_disbursed"`
+	Fees               []GetLoanFeeResponse         `json:"fees"`
+	InterestRate       float64                      `json:"interest_rate"`
+	Duration           int                          `json:"duration"`
+	Frequency          string                       `json:"frequency"`
+	AmortizationMethod string                       `json:"amortization_method"`
+	StartDate          time.Time                    `json:"start_date"`
+	TotalInterest      money.Money                  `json:"total_interest"`
+	TotalRepayable     money.Money                  `json:"total_repayable"`
+	EffectiveRate      float64                      `json:"effective_rate"`
+	PaymentSchedules   []GetPaymentScheduleResponse `json:"payment_schedules"`
 }
 
 type GetLoanDetailsResponse struct {
-	Principal         float64                      `json:"principal"`
-	InterestRate      float64                      `json:"interest_rate"`
-	OutstandingAmount float64                      `json:"outstanding_amount"`
-	Duration          int                          `json:"duration"`
-	StartDate         time.Time                    `json:"start_date"`
-	CreatedAt         time.Time                    `json:"created_at"`
-	Borrower          GetBorrowerResponse          `json:"borrower"`
-	PaymentSchedule   []GetPaymentScheduleResponse `json:"payment_schedules"`
+	ProductID          uint                         `json:"product_id"`
+	Principal          money.Money                  `json:"principal"`
+	TotalFees          money.Money                  `json:"total_fees"`
+	NetDisbursed       money.Money                  `json:"net_disbursed"`
+	Fees               []GetLoanFeeResponse         `json:"fees"`
+	InterestRate       float64                      `json:"interest_rate"`
+	OutstandingAmount  money.Money                  `json:"outstanding_amount"`
+	CreditBalance      money.Money                  `json:"credit_balance"`
+	TotalPenalties     money.Money                  `json:"total_penalties"`
+	WrittenOffAmount   money.Money                  `json:"written_off_amount"`
+	RecoveredAmount    money.Money                  `json:"recovered_amount"`
+	Duration           int                          `json:"duration"`
+	Frequency          string                       `json:"frequency"`
+	AmortizationMethod string                       `json:"amortization_method"`
+	StartDate          time.Time                    `json:"start_date"`
+	Calendar           string                       `json:"calendar,omitempty"`
+	RollConvention     string                       `json:"roll_convention,omitempty"`
+	Status             string                       `json:"status"`
+	RefinancedLoanID   *uint                        `json:"refinanced_loan_id,omitempty"`
+	RefinancedByLoanID *uint                        `json:"refinanced_by_loan_id,omitempty"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	Borrower           GetBorrowerResponse          `json:"borrower"`
+	PaymentSchedule    []GetPaymentScheduleResponse `json:"payment_schedules"`
+	StatusHistory      []LoanStatusChangeResponse   `json:"status_history"`
+	Approval           *GetLoanApprovalResponse     `json:"approval,omitempty"`
+}
+
+type LoanStatusChangeResponse struct {
+	LoanID     uint      `json:"loan_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+type ApproveLoanRequest struct {
+	ValidatorID       uint   `json:"validator_id"`
+	ApprovalDate      string `json:"approval_date"`
+	ProofOfVisitImage string `json:"proof_of_visit_image"`
+}
+
+type GetLoanApprovalResponse struct {
+	LoanID            uint      `json:"loan_id"`
+	ValidatorID       uint      `json:"validator_id"`
+	ApprovalDate      time.Time `json:"approval_date"`
+	ProofOfVisitImage string    `json:"proof_of_visit_image"`
+	ApprovedAt        time.Time `json:"approved_at"`
 }
 
 type GetOutstandingResponse struct {
-	OutstandingAmount float64 `json:"outstanding_amount"`
+	OutstandingAmount money.Money `json:"outstanding_amount"`
+}
+
+type RestructureLoanRequest struct {
+	Tenor             int      `json:"tenor"`
+	InterestRate      *float64 `json:"interest_rate"`
+	CapitalizeArrears bool     `json:"capitalize_arrears"`
+	Reason            string   `json:"reason"`
+}
+
+type RestructureLoanResponse struct {
+	LoanID              uint                         `json:"loan_id"`
+	RestructureID       uint                         `json:"restructure_id"`
+	PreviousTenor       int                          `json:"previous_tenor"`
+	Tenor               int                          `json:"tenor"`
+	PreviousRate        float64                      `json:"previous_rate"`
+	InterestRate        float64                      `json:"interest_rate"`
+	CapitalizedArrears  money.Money                  `json:"capitalized_arrears"`
+	PreviousOutstanding money.Money                  `json:"previous_outstanding"`
+	OutstandingAmount   money.Money                  `json:"outstanding_amount"`
+	SupersededSchedules int                          `json:"superseded_schedules"`
+	PaymentSchedules    []GetPaymentScheduleResponse `json:"payment_schedules"`
+}
+
+type DeferInstallmentsRequest struct {
+	Installments int    `json:"installments"`
+	Policy       string `json:"policy"`
+	Reason       string `json:"reason"`
+	ApproverID   uint   `json:"approver_id"`
+}
+
+type DeferInstallmentsResponse struct {
+	LoanID            uint                         `json:"loan_id"`
+	DeferralID        uint                         `json:"deferral_id"`
+	Installments      int                          `json:"installments"`
+	Policy            string                       `json:"policy"`
+	Reason            string                       `json:"reason"`
+	ApproverID        uint                         `json:"approver_id"`
+	CapitalizedAmount money.Money                  `json:"capitalized_amount"`
+	HolidayInterest   money.Money                  `json:"holiday_interest"`
+	Tenor             int                          `json:"tenor"`
+	OutstandingAmount money.Money                  `json:"outstanding_amount"`
+	PaymentSchedules  []GetPaymentScheduleResponse `json:"payment_schedules"`
+}
+
+type WriteOffLoanRequest struct {
+	Reason string `json:"reason"`
+}
+
+type WriteOffLoanResponse struct {
+	LoanID        uint        `json:"loan_id"`
+	WriteOffID    uint        `json:"write_off_id"`
+	LossAmount    money.Money `json:"loss_amount"`
+	ForgoneIncome money.Money `json:"forgone_income"`
+	Reason        string      `json:"reason"`
+	Status        string      `json:"status"`
+	WrittenOffAt  time.Time   `json:"written_off_at"`
+}
+
+type PortfolioResponse struct {
+	ActiveLoans       int64       `json:"active_loans"`
+	ActiveOutstanding money.Money `json:"active_outstanding"`
+	WrittenOffLoans   int64       `json:"written_off_loans"`
+	WrittenOffAmount  money.Money `json:"written_off_amount"`
+	RecoveredAmount   money.Money `json:"recovered_amount"`
+	NetChargeOff      money.Money `json:"net_charge_off"`
 }
@@ -0,0 +1,64 @@
+package dto
+
+import "github.com/greekrode/loan-engine-amartha/domain/money"
+
+type LoanProductFeeRequest struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Charge string      `json:"charge"`
+	Amount money.Money `json:"amount"`
+	Rate   float64     `json:"rate"`
+}
+
+type PenaltyPolicyRequest struct {
+	Type   string      `json:"type"`
+	Amount money.Money `json:"amount"`
+	Rate   float64     `json:"rate"`
+	Cap    money.Money `json:"cap"`
+}
+
+type SaveLoanProductRequest struct {
+	Name               string                  `json:"name"`
+	MinPrincipal       money.Money             `json:"min_principal"`
+	MaxPrincipal       money.Money             `json:"max_principal"`
+	Tenors             []int                   `json:"tenors"`
+	InterestRate       float64                 `json:"interest_rate"`
+	ServiceFeeRate     float64                 `json:"service_fee_rate"`
+	AmortizationMethod string                  `json:"amortization_method"`
+	RebatePolicy       string                  `json:"rebate_policy"`
+	DayCount           string                  `json:"day_count"`
+	AllocationOrder    []string                `json:"allocation_order"`
+	PenaltyPolicy      PenaltyPolicyRequest    `json:"penalty_policy"`
+	Fees               []LoanProductFeeRequest `json:"fees"`
+}
+
+type GetLoanProductFeeResponse struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Charge string      `json:"charge"`
+	Amount money.Money `json:"amount"`
+	Rate   float64     `json:"rate"`
+}
+
+type GetPenaltyPolicyResponse struct {
+	Type   string      `json:"type"`
+	Amount money.Money `json:"amount"`
+	Rate   float64     `json:"rate"`
+	Cap    money.Money `json:"cap"`
+}
+
+type GetLoanProductResponse struct {
+	ID                 uint                        `json:"id"`
+	Name               string                      `json:"name"`
+	MinPrincipal       money.Money                 `json:"min_principal"`
+	MaxPrincipal       money.Money                 `json:"max_principal"`
+	Tenors             []int                       `json:"tenors"`
+	InterestRate       float64                     `json:"interest_rate"`
+	ServiceFeeRate     float64                     `json:"service_fee_rate"`
+	AmortizationMethod string                      `json:"amortization_method"`
+	RebatePolicy       string                      `json:"rebate_policy"`
+	DayCount           string                      `json:"day_count"`
+	AllocationOrder    []string                    `json:"allocation_order"`
+	PenaltyPolicy      GetPenaltyPolicyResponse    `json:"penalty_policy"`
+	Fees               []GetLoanProductFeeResponse `json:"fees"`
+}
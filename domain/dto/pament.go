@@ -1,6 +1,63 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
 type RequestPaymentResponse struct {
-	TotalDue         float64                      `json:"total_due"`
+	TotalDue         money.Money                  `json:"total_due"`
+	TotalPenalty     money.Money                  `json:"total_penalty"`
+	PaymentSchedules []GetPaymentScheduleResponse `json:"payment_schedules"`
+}
+
+// PayOffLoanRequest leaves Amount nil when it is missing, since zero is a
+// valid payoff amount for a loan its credit balance pays off.
+type PayOffLoanRequest struct {
+	Amount *money.Money `json:"amount"`
+}
+
+type PayoffQuoteResponse struct {
+	LoanID           uint                         `json:"loan_id"`
+	PayoffDate       time.Time                    `json:"payoff_date"`
+	RebatePolicy     string                       `json:"rebate_policy"`
+	Principal        money.Money                  `json:"principal"`
+	Interest         money.Money                  `json:"interest"`
+	Fees             money.Money                  `json:"fees"`
+	Penalties        money.Money                  `json:"penalties"`
+	Rebate           money.Money                  `json:"rebate"`
+	CreditApplied    money.Money                  `json:"credit_applied"`
+	PayoffAmount     money.Money                  `json:"payoff_amount"`
 	PaymentSchedules []GetPaymentScheduleResponse `json:"payment_schedules"`
 }
+
+type ReversePaymentRequest struct {
+	Reason string `json:"reason"`
+}
+
+type PaymentReversalResponse struct {
+	ReversalID        uint                         `json:"reversal_id"`
+	PaymentID         uint                         `json:"payment_id"`
+	LoanID            uint                         `json:"loan_id"`
+	Amount            money.Money                  `json:"amount"`
+	Reason            string                       `json:"reason"`
+	OutstandingAmount money.Money                  `json:"outstanding_amount"`
+	CreditBalance     money.Money                  `json:"credit_balance"`
+	RecoveredAmount   money.Money                  `json:"recovered_amount,omitempty"`
+	LoanStatus        string                       `json:"loan_status"`
+	PaymentSchedules  []GetPaymentScheduleResponse `json:"payment_schedules"`
+}
+
+type RefundCreditRequest struct {
+	Amount money.Money `json:"amount"`
+	Reason string      `json:"reason"`
+}
+
+type RefundResponse struct {
+	RefundID      uint        `json:"refund_id"`
+	LoanID        uint        `json:"loan_id"`
+	Amount        money.Money `json:"amount"`
+	Reason        string      `json:"reason"`
+	CreditBalance money.Money `json:"credit_balance"`
+}
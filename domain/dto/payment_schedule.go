@@ -1,15 +1,44 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
 
 type GetPaymentScheduleResponse struct {
-	ID        uint      `json:"id,omitempty"`
-	DueAmount float64   `json:"due_amount"`
-	DueDate   time.Time `json:"due_date"`
-	Paid      bool      `json:"paid"`
+	ID              uint        `json:"id,omitempty"`
+	DueAmount       money.Money `json:"due_amount"`
+	PrincipalAmount money.Money `json:"principal_amount"`
+	InterestAmount  money.Money `json:"interest_amount"`
+	FeeAmount       money.Money `json:"fee_amount"`
+	PenaltyAmount   money.Money `json:"penalty_amount"`
+	PaidAmount      money.Money `json:"paid_amount"`
+	DueDate         time.Time   `json:"due_date"`
+	Paid            bool        `json:"paid"`
+	Status          string      `json:"status"`
 }
 
 type MakePaymentRequest struct {
-	PaymentSchedulesID []uint  `json:"payment_schedules_id"`
-	Amount             float64 `json:"amount"`
+	Amount money.Money `json:"amount"`
+}
+
+type MakePaymentResponse struct {
+	PaymentID         uint                         `json:"payment_id"`
+	Amount            money.Money                  `json:"amount"`
+	AppliedAmount     money.Money                  `json:"applied_amount"`
+	CreditAmount      money.Money                  `json:"credit_amount"`
+	OutstandingAmount money.Money                  `json:"outstanding_amount"`
+	CreditBalance     money.Money                  `json:"credit_balance"`
+	LoanStatus        string                       `json:"loan_status"`
+	Recovery          bool                         `json:"recovery,omitempty"`
+	RecoveredAmount   money.Money                  `json:"recovered_amount,omitempty"`
+	PaymentSchedules  []GetPaymentScheduleResponse `json:"payment_schedules"`
+	Allocations       []PaymentAllocationResponse  `json:"allocations"`
+}
+
+type PaymentAllocationResponse struct {
+	PaymentScheduleID uint        `json:"payment_schedule_id"`
+	Component         string      `json:"component"`
+	Amount            money.Money `json:"amount"`
 }
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type PenaltyChargeResponse struct {
+	LoanID            uint        `json:"loan_id"`
+	PaymentScheduleID uint        `json:"payment_schedule_id"`
+	Amount            money.Money `json:"amount"`
+}
+
+type AccruePenaltiesResponse struct {
+	AccruedOn    time.Time               `json:"accrued_on"`
+	LoansCharged int                     `json:"loans_charged"`
+	TotalPenalty money.Money             `json:"total_penalty"`
+	Charges      []PenaltyChargeResponse `json:"charges"`
+}
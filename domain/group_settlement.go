@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// GroupSettlement is one payment made at a group meeting under joint
+// liability (tanggung renteng): the payer covers the overdue installments of
+// several members at once. Each loan it settles gets its own Payment, and
+// what the payer covered for other members is owed back to her as
+// MemberDebt.
+type GroupSettlement struct {
+	gorm.Model
+	GroupID  uint         `gorm:"not null;index" json:"group_id"`
+	PayerID  uint         `gorm:"not null;index" json:"payer_id"`
+	Amount   money.Money  `gorm:"not null" json:"amount"`
+	Payments []Payment    `gorm:"foreignKey:SettlementID"`
+	Debts    []MemberDebt `gorm:"foreignKey:SettlementID"`
+}
+
+// MemberDebt is what a member owes the member who covered her installments
+// in a group settlement. It is settled between the members and does not
+// touch the loan.
+type MemberDebt struct {
+	gorm.Model
+	GroupID      uint        `gorm:"not null;index" json:"group_id"`
+	SettlementID uint        `gorm:"not null;index" json:"settlement_id"`
+	CreditorID   uint        `gorm:"not null;index" json:"creditor_id"`
+	DebtorID     uint        `gorm:"not null;index" json:"debtor_id"`
+	Amount       money.Money `gorm:"not null" json:"amount"`
+	RepaidAmount money.Money `gorm:"not null;default:0" json:"repaid_amount"`
+}
+
+func (d MemberDebt) Outstanding() money.Money {
+	return d.Amount - d.RepaidAmount
+}
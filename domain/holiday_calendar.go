@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"gorm.io/gorm"
+)
+
+type RollConvention string
+
+const (
+	RollUnadjusted        RollConvention = "unadjusted"
+	RollFollowing         RollConvention = "following"
+	RollModifiedFollowing RollConvention = "modified_following"
+	RollPreceding         RollConvention = "preceding"
+)
+
+func (r RollConvention) IsValid() bool {
+	switch r {
+	case RollUnadjusted, RollFollowing, RollModifiedFollowing, RollPreceding:
+		return true
+	}
+	return false
+}
+
+type HolidayCalendar struct {
+	gorm.Model
+	Name     string    `gorm:"not null;uniqueIndex" json:"name"`
+	Holidays []Holiday `gorm:"foreignKey:CalendarID" json:"holidays"`
+}
+
+type Holiday struct {
+	gorm.Model
+	CalendarID  uint      `gorm:"not null;index" json:"calendar_id"`
+	Date        time.Time `gorm:"not null" json:"date"`
+	Description string    `gorm:"not null" json:"description"`
+}
+
+// IsBusinessDay reports whether collections can happen on the given date:
+// weekends and the calendar's holidays are not business days.
+func (c *HolidayCalendar) IsBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+
+	for _, holiday := range c.Holidays {
+		if sameDay(holiday.Date, date) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Adjust moves a date that is not a business day according to the roll
+// convention. Modified following rolls forward unless that would cross into the
+// next month, in which case it rolls back instead.
+func (c *HolidayCalendar) Adjust(date time.Time, convention RollConvention) time.Time {
+	switch convention {
+	case RollFollowing:
+		return c.roll(date, 1)
+	case RollPreceding:
+		return c.roll(date, -1)
+	case RollModifiedFollowing:
+		adjusted := c.roll(date, 1)
+		if adjusted.Month() != date.Month() {
+			return c.roll(date, -1)
+		}
+		return adjusted
+	}
+	return date
+}
+
+func (c *HolidayCalendar) roll(date time.Time, step int) time.Time {
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+type HolidayCalendarUsecase interface {
+	SaveCalendar(ctx context.Context, name string, holidays []Holiday) (*dto.GetHolidayCalendarResponse, error)
+	GetCalendar(ctx context.Context, name string) (*dto.GetHolidayCalendarResponse, error)
+	AddHoliday(ctx context.Context, name string, holiday Holiday) (*dto.GetHolidayCalendarResponse, error)
+	LoadCalendarsFromFile(ctx context.Context, path string) error
+}
+
+type HolidayCalendarRepository interface {
+	SaveCalendar(ctx context.Context, calendar *HolidayCalendar, tx *gorm.DB) error
+	CreateHoliday(ctx context.Context, holiday *Holiday, tx *gorm.DB) error
+	ReplaceHolidays(ctx context.Context, calendarID uint, holidays []Holiday, tx *gorm.DB) error
+
+	FindCalendarByName(ctx context.Context, name string) (*HolidayCalendar, error)
+}
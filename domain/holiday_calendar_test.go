@@ -0,0 +1,86 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type HolidayCalendarSuite struct {
+	suite.Suite
+	calendar *domain.HolidayCalendar
+}
+
+func (s *HolidayCalendarSuite) SetupSuite() {
+	s.calendar = &domain.HolidayCalendar{
+		Name: "ID",
+		Holidays: []domain.Holiday{
+			{Date: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), Description: "Nyepi"},
+			{Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC), Description: "Month End Holiday"},
+		},
+	}
+}
+
+func (s *HolidayCalendarSuite) TestIsBusinessDay() {
+	s.True(s.calendar.IsBusinessDay(time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)))
+	s.False(s.calendar.IsBusinessDay(time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)))
+	s.False(s.calendar.IsBusinessDay(time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)))
+}
+
+func (s *HolidayCalendarSuite) TestAdjust() {
+	tests := []struct {
+		name       string
+		date       time.Time
+		convention domain.RollConvention
+		expected   time.Time
+	}{
+		{
+			name:       "Unadjusted",
+			date:       time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollUnadjusted,
+			expected:   time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Following Over Holiday",
+			date:       time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollFollowing,
+			expected:   time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Following Over Weekend And Holiday",
+			date:       time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollFollowing,
+			expected:   time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Preceding Over Weekend",
+			date:       time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollPreceding,
+			expected:   time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Modified Following Within Month",
+			date:       time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollModifiedFollowing,
+			expected:   time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Modified Following At Month End",
+			date:       time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC),
+			convention: domain.RollModifiedFollowing,
+			expected:   time.Date(2024, time.May, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, s.calendar.Adjust(tt.date, tt.convention))
+		})
+	}
+}
+
+func TestHolidayCalendarSuite(t *testing.T) {
+	suite.Run(t, new(HolidayCalendarSuite))
+}
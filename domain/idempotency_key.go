@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key has not been answered yet")
+)
+
+// IdempotencyKey remembers the response to the first request a client sent
+// with a key, so a retry of that request is answered with the same response
+// instead of being processed again. Fingerprint identifies the request the key
+// was first used for. StatusCode stays zero until that request has been
+// answered. A key left unanswered by a request that was lost, e.g. with the
+// server it ran on, stays claimed: whether its changes were committed is not
+// known, so it is reconciled by hand rather than processed again.
+type IdempotencyKey struct {
+	gorm.Model
+	Key          string `gorm:"not null;uniqueIndex" json:"key"`
+	Fingerprint  string `gorm:"not null" json:"fingerprint"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody []byte `json:"-"`
+}
+
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
+
+type IdempotencyUsecase interface {
+	BeginRequest(ctx context.Context, key, fingerprint string) (*IdempotencyKey, error)
+	CompleteRequest(ctx context.Context, key string, statusCode int, responseBody []byte) error
+	ReleaseKey(ctx context.Context, key string) error
+}
+
+type IdempotencyRepository interface {
+	CreateIdempotencyKey(ctx context.Context, key *IdempotencyKey, tx *gorm.DB) (bool, error)
+	FindIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error)
+	UpdateIdempotencyKey(ctx context.Context, key *IdempotencyKey, tx *gorm.DB) error
+	DeleteIdempotencyKey(ctx context.Context, key string, tx *gorm.DB) error
+}
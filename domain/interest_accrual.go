@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// InterestAccrual is the interest an installment earned up to AccruedOn that
+// earlier accruals had not recognized yet.
+type InterestAccrual struct {
+	gorm.Model
+	LoanID            uint        `gorm:"not null;index" json:"loan_id"`
+	PaymentScheduleID uint        `gorm:"not null;index" json:"payment_schedule_id"`
+	Amount            money.Money `gorm:"not null" json:"amount"`
+	DayCount          DayCount    `gorm:"not null" json:"day_count"`
+	AccruedOn         time.Time   `gorm:"not null;index" json:"accrued_on"`
+}
+
+// AccruedInterest totals the interest accrued on a loan, or on one of its
+// installments when PaymentScheduleID is set.
+type AccruedInterest struct {
+	LoanID            uint
+	PaymentScheduleID uint
+	DayCount          DayCount
+	Amount            money.Money
+}
+
+type InterestAccrualUsecase interface {
+	AccrueInterest(ctx context.Context, date time.Time) (*dto.AccrueInterestResponse, error)
+	GetAccruedInterest(ctx context.Context, date time.Time) (*dto.AccruedInterestReportResponse, error)
+}
+
+type InterestAccrualRepository interface {
+	CreateInterestAccruals(ctx context.Context, accruals []InterestAccrual, tx *gorm.DB) error
+	GetAccruedInterestBySchedule(ctx context.Context, loanIDs []uint) ([]AccruedInterest, error)
+	GetAccruedInterestNotDue(ctx context.Context, date time.Time) ([]AccruedInterest, error)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+type Investor struct {
+	gorm.Model
+	Name        string           `gorm:"not null" json:"name"`
+	Email       string           `gorm:"not null;uniqueIndex" json:"email"`
+	Investments []LoanInvestment `gorm:"foreignKey:InvestorID"`
+	Returns     []InvestorReturn `gorm:"foreignKey:InvestorID"`
+}
+
+// LoanInvestment is the part of a loan's principal funded by one investor.
+type LoanInvestment struct {
+	gorm.Model
+	LoanID     uint        `gorm:"not null;index" json:"loan_id"`
+	InvestorID uint        `gorm:"not null;index" json:"investor_id"`
+	Amount     money.Money `gorm:"not null" json:"amount"`
+}
+
+// InvestorReturn is an investor's share of one repayment. The service fee is
+// the platform's cut of the interest; NetAmount is what the investor keeps.
+// Returns from a reversed payment are cancelled by returns of negated amounts.
+type InvestorReturn struct {
+	gorm.Model
+	PaymentID    uint        `gorm:"not null;index" json:"payment_id"`
+	LoanID       uint        `gorm:"not null;index" json:"loan_id"`
+	InvestorID   uint        `gorm:"not null;index" json:"investor_id"`
+	InvestmentID uint        `gorm:"not null" json:"investment_id"`
+	Principal    money.Money `gorm:"not null" json:"principal"`
+	Interest     money.Money `gorm:"not null" json:"interest"`
+	ServiceFee   money.Money `gorm:"not null" json:"service_fee"`
+	NetAmount    money.Money `gorm:"not null" json:"net_amount"`
+}
+
+// DistributeRepayment splits the principal and interest of a payment across
+// the loan's investors in proportion to the amount each one funded. The
+// platform keeps its service fee out of every investor's interest.
+func DistributeRepayment(payment *Payment, investments []LoanInvestment, serviceFeeRate float64) []InvestorReturn {
+	shares := make([]money.Money, len(investments))
+	for i, investment := range investments {
+		shares[i] = investment.Amount
+	}
+
+	principalParts := payment.Principal.Allocate(shares)
+	interestParts := payment.Interest.Allocate(shares)
+
+	returns := make([]InvestorReturn, len(investments))
+	for i, investment := range investments {
+		serviceFee := interestParts[i].MulRate(serviceFeeRate / 100)
+		returns[i] = InvestorReturn{
+			PaymentID:    payment.ID,
+			LoanID:       payment.LoanID,
+			InvestorID:   investment.InvestorID,
+			InvestmentID: investment.ID,
+			Principal:    principalParts[i],
+			Interest:     interestParts[i],
+			ServiceFee:   serviceFee,
+			NetAmount:    principalParts[i] + interestParts[i] - serviceFee,
+		}
+	}
+
+	return returns
+}
+
+// ClawBack returns the investor returns that cancel the given ones when the
+// payment they were paid from is reversed.
+func ClawBack(returns []InvestorReturn) []InvestorReturn {
+	clawbacks := make([]InvestorReturn, len(returns))
+	for i, r := range returns {
+		clawbacks[i] = InvestorReturn{
+			PaymentID:    r.PaymentID,
+			LoanID:       r.LoanID,
+			InvestorID:   r.InvestorID,
+			InvestmentID: r.InvestmentID,
+			Principal:    -r.Principal,
+			Interest:     -r.Interest,
+			ServiceFee:   -r.ServiceFee,
+			NetAmount:    -r.NetAmount,
+		}
+	}
+	return clawbacks
+}
+
+type InvestorUsecase interface {
+	CreateInvestor(ctx context.Context, investor Investor) (*dto.GetInvestorResponse, error)
+	GetInvestor(ctx context.Context, investorID uint) (*dto.GetInvestorResponse, error)
+
+	InvestInLoan(ctx context.Context, loanID uint, investorID uint, amount money.Money) (*dto.InvestInLoanResponse, error)
+	GetLoanInvestments(ctx context.Context, loanID uint) (*dto.GetLoanInvestmentsResponse, error)
+}
+
+type InvestorRepository interface {
+	CreateInvestor(ctx context.Context, investor *Investor, tx *gorm.DB) error
+	FindInvestorByID(ctx context.Context, investorID uint) (*Investor, error)
+
+	CreateInvestment(ctx context.Context, investment *LoanInvestment, tx *gorm.DB) error
+	GetInvestmentsByLoanID(ctx context.Context, loanID uint) ([]LoanInvestment, error)
+	SumInvestmentsByLoanID(ctx context.Context, loanID uint, tx *gorm.DB) (money.Money, error)
+
+	CreateInvestorReturns(ctx context.Context, returns []InvestorReturn, tx *gorm.DB) error
+	GetReturnsByPaymentID(ctx context.Context, paymentID uint) ([]InvestorReturn, error)
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+type AccountType string
+
+const (
+	AccountAsset     AccountType = "asset"
+	AccountLiability AccountType = "liability"
+	AccountEquity    AccountType = "equity"
+	AccountIncome    AccountType = "income"
+	AccountExpense   AccountType = "expense"
+)
+
+// DebitNormal reports whether debits increase the balance of accounts of this
+// type.
+func (t AccountType) DebitNormal() bool {
+	return t == AccountAsset || t == AccountExpense
+}
+
+// Codes of the accounts in the chart of accounts.
+const (
+	AccountCash              = "1000"
+	AccountLoansReceivable   = "1100"
+	AccountRefinanceClearing = "1900"
+	AccountBorrowerCredit    = "2100"
+	AccountInterestIncome    = "4000"
+	AccountFeeIncome         = "4100"
+	AccountPenaltyIncome     = "4200"
+	AccountRecoveryIncome    = "4300"
+	AccountLoanLoss          = "5000"
+)
+
+// Account is an account in the general ledger's chart of accounts.
+type Account struct {
+	gorm.Model
+	Code string      `gorm:"not null;uniqueIndex" json:"code"`
+	Name string      `gorm:"not null" json:"name"`
+	Type AccountType `gorm:"not null" json:"type"`
+}
+
+// ChartOfAccounts returns the accounts the loan engine posts to. Loans
+// receivable carries outstanding principal only: interest, fees and penalties
+// are recognized as income when they are collected.
+func ChartOfAccounts() []Account {
+	return []Account{
+		{Code: AccountCash, Name: "Cash", Type: AccountAsset},
+		{Code: AccountLoansReceivable, Name: "Loans receivable", Type: AccountAsset},
+		{Code: AccountRefinanceClearing, Name: "Refinance clearing", Type: AccountAsset},
+		{Code: AccountBorrowerCredit, Name: "Borrower credit balances", Type: AccountLiability},
+		{Code: AccountInterestIncome, Name: "Interest income", Type: AccountIncome},
+		{Code: AccountFeeIncome, Name: "Fee income", Type: AccountIncome},
+		{Code: AccountPenaltyIncome, Name: "Penalty income", Type: AccountIncome},
+		{Code: AccountRecoveryIncome, Name: "Recoveries of written-off loans", Type: AccountIncome},
+		{Code: AccountLoanLoss, Name: "Loan losses", Type: AccountExpense},
+	}
+}
+
+// JournalEntry is one balanced movement in the general ledger. Entries are
+// posted in the same transaction as the loan or payment change they record.
+type JournalEntry struct {
+	gorm.Model
+	Date        time.Time `gorm:"not null;index" json:"date"`
+	Description string    `gorm:"not null" json:"description"`
+	LoanID      *uint     `gorm:"index" json:"loan_id,omitempty"`
+	PaymentID   *uint     `gorm:"index" json:"payment_id,omitempty"`
+	Postings    []Posting `gorm:"foreignKey:JournalEntryID" json:"postings"`
+}
+
+// Posting debits or credits one account as part of a journal entry.
+type Posting struct {
+	gorm.Model
+	JournalEntryID uint        `gorm:"not null;index" json:"journal_entry_id"`
+	AccountCode    string      `gorm:"not null;index" json:"account_code"`
+	Debit          money.Money `gorm:"not null;default:0" json:"debit"`
+	Credit         money.Money `gorm:"not null;default:0" json:"credit"`
+}
+
+// Debit adds a debit posting to the entry. Zero amounts are left out.
+func (e *JournalEntry) Debit(accountCode string, amount money.Money) {
+	if amount != 0 {
+		e.Postings = append(e.Postings, Posting{AccountCode: accountCode, Debit: amount})
+	}
+}
+
+// Credit adds a credit posting to the entry. Zero amounts are left out.
+func (e *JournalEntry) Credit(accountCode string, amount money.Money) {
+	if amount != 0 {
+		e.Postings = append(e.Postings, Posting{AccountCode: accountCode, Credit: amount})
+	}
+}
+
+// Validate checks that the entry moves money and that its debits equal its
+// credits.
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) == 0 {
+		return errors.New("journal entry has no postings")
+	}
+
+	var debits, credits money.Money
+	for _, posting := range e.Postings {
+		if posting.Debit < 0 || posting.Credit < 0 {
+			return fmt.Errorf("posting to account %s must not be negative", posting.AccountCode)
+		}
+		debits += posting.Debit
+		credits += posting.Credit
+	}
+
+	if debits != credits {
+		return fmt.Errorf("journal entry is not balanced: debits %s, credits %s", debits, credits)
+	}
+
+	return nil
+}
+
+func newLoanEntry(loan *Loan, description string) *JournalEntry {
+	return &JournalEntry{
+		Date:        time.Now().UTC(),
+		Description: description,
+		LoanID:      &loan.ID,
+	}
+}
+
+// NewDisbursementEntry books the principal of a disbursed loan as receivable
+// against the cash paid out and the upfront fees kept from it. For a
+// refinancing loan, the part that paid off the refinanced loan is released
+// from refinance clearing.
+func NewDisbursementEntry(loan *Loan) *JournalEntry {
+	upfront := loan.UpfrontFees()
+
+	entry := newLoanEntry(loan, fmt.Sprintf("Disbursement of loan %d", loan.ID))
+	entry.Debit(AccountLoansReceivable, loan.Principal)
+	entry.Credit(AccountCash, loan.NetDisbursed)
+	entry.Credit(AccountFeeIncome, upfront)
+	entry.Credit(AccountRefinanceClearing, loan.Principal-upfront-loan.NetDisbursed)
+	return entry
+}
+
+// NewRepaymentEntry books a payment against the loan's receivable and income
+// accounts by the components it settled. Whatever it did not settle is owed
+// back to the borrower as a credit balance, and whatever it settled beyond
+// the amount paid was drawn from that balance.
+func NewRepaymentEntry(loan *Loan, payment *Payment) *JournalEntry {
+	applied := payment.Principal + payment.Interest + payment.FeeAmount + payment.Penalty
+
+	entry := newLoanEntry(loan, fmt.Sprintf("Repayment on loan %d", loan.ID))
+	entry.PaymentID = &payment.ID
+	entry.Debit(AccountCash, payment.Amount)
+	entry.Debit(AccountBorrowerCredit, max(applied-payment.Amount, 0))
+	entry.Credit(AccountLoansReceivable, payment.Principal)
+	entry.Credit(AccountInterestIncome, payment.Interest)
+	entry.Credit(AccountFeeIncome, payment.FeeAmount)
+	entry.Credit(AccountPenaltyIncome, payment.Penalty)
+	entry.Credit(AccountBorrowerCredit, max(payment.Amount-applied, 0))
+	return entry
+}
+
+// NewRecoveryEntry books a payment on a written-off loan as a recovery.
+func NewRecoveryEntry(loan *Loan, payment *Payment) *JournalEntry {
+	entry := newLoanEntry(loan, fmt.Sprintf("Recovery on written-off loan %d", loan.ID))
+	entry.PaymentID = &payment.ID
+	entry.Debit(AccountCash, payment.Amount)
+	entry.Credit(AccountRecoveryIncome, payment.Amount)
+	return entry
+}
+
+// NewReversalEntry takes back the repayment or recovery entry of a reversed
+// payment by posting it again with debits and credits swapped.
+func NewReversalEntry(loan *Loan, payment *Payment) *JournalEntry {
+	original := NewRepaymentEntry(loan, payment)
+	if payment.Recovery {
+		original = NewRecoveryEntry(loan, payment)
+	}
+
+	entry := newLoanEntry(loan, fmt.Sprintf("Reversal of payment %d on loan %d", payment.ID, loan.ID))
+	entry.PaymentID = &payment.ID
+	for _, posting := range original.Postings {
+		entry.Postings = append(entry.Postings, Posting{
+			AccountCode: posting.AccountCode,
+			Debit:       posting.Credit,
+			Credit:      posting.Debit,
+		})
+	}
+	return entry
+}
+
+// NewRefundEntry pays a borrower's credit balance back out in cash.
+func NewRefundEntry(loan *Loan, refund *Refund) *JournalEntry {
+	entry := newLoanEntry(loan, fmt.Sprintf("Refund of credit balance on loan %d", loan.ID))
+	entry.Debit(AccountBorrowerCredit, refund.Amount)
+	entry.Credit(AccountCash, refund.Amount)
+	return entry
+}
+
+// NewWriteOffEntry charges the principal still receivable on a loan to loan
+// losses. Interest and fees not yet collected were never booked as income and
+// are not part of the loss.
+func NewWriteOffEntry(loan *Loan, principal money.Money) *JournalEntry {
+	entry := newLoanEntry(loan, fmt.Sprintf("Write-off of loan %d", loan.ID))
+	entry.Debit(AccountLoanLoss, principal)
+	entry.Credit(AccountLoansReceivable, principal)
+	return entry
+}
+
+// NewCapitalizationEntry books interest, fees and penalties capitalized into
+// the principal of a restructured or deferred loan as income, since the
+// borrower now owes them as principal.
+func NewCapitalizationEntry(loan *Loan, arrears ScheduleAllocation) *JournalEntry {
+	entry := newLoanEntry(loan, fmt.Sprintf("Arrears capitalized on loan %d", loan.ID))
+	entry.Debit(AccountLoansReceivable, arrears.Interest+arrears.Fee+arrears.Penalty)
+	entry.Credit(AccountInterestIncome, arrears.Interest)
+	entry.Credit(AccountFeeIncome, arrears.Fee)
+	entry.Credit(AccountPenaltyIncome, arrears.Penalty)
+	return entry
+}
+
+// NewRefinancePayoffEntry settles what a refinanced loan still owed when the
+// loan refinancing it is disbursed. The payoff is drawn from refinance
+// clearing, where that disbursement puts it, and any credit balance on the
+// refinanced loan goes towards it first.
+func NewRefinancePayoffEntry(loan *Loan, settled ScheduleAllocation, payoff money.Money) *JournalEntry {
+	entry := newLoanEntry(loan, fmt.Sprintf("Refinance payoff of loan %d", loan.ID))
+	entry.Debit(AccountRefinanceClearing, payoff)
+	entry.Debit(AccountBorrowerCredit, settled.Total()-payoff)
+	entry.Credit(AccountLoansReceivable, settled.Principal)
+	entry.Credit(AccountInterestIncome, settled.Interest)
+	entry.Credit(AccountFeeIncome, settled.Fee)
+	entry.Credit(AccountPenaltyIncome, settled.Penalty)
+	return entry
+}
+
+// AccountBalance totals the debits and credits posted to an account.
+type AccountBalance struct {
+	AccountCode string
+	Debit       money.Money
+	Credit      money.Money
+}
+
+type LedgerUsecase interface {
+	GetTrialBalance(ctx context.Context, date time.Time) (*dto.TrialBalanceResponse, error)
+}
+
+type LedgerRepository interface {
+	CreateJournalEntry(ctx context.Context, entry *JournalEntry, tx *gorm.DB) error
+	GetAccounts(ctx context.Context) ([]Account, error)
+	GetAccountBalances(ctx context.Context, date time.Time) ([]AccountBalance, error)
+}
@@ -0,0 +1,253 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LedgerSuite struct {
+	suite.Suite
+}
+
+func (s *LedgerSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		postings      []domain.Posting
+		expectedError string
+	}{
+		{
+			name: "Balanced Entry",
+			postings: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(100)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(90)},
+				{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10)},
+			},
+		},
+		{
+			name:          "No Postings",
+			expectedError: "journal entry has no postings",
+		},
+		{
+			name: "Unbalanced Entry",
+			postings: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(100)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(90)},
+			},
+			expectedError: "journal entry is not balanced: debits 100.00, credits 90.00",
+		},
+		{
+			name: "Negative Posting",
+			postings: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(-10)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(-10)},
+			},
+			expectedError: "posting to account 1000 must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := domain.JournalEntry{Postings: tt.postings}.Validate()
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+				return
+			}
+			s.NoError(err)
+		})
+	}
+}
+
+func (s *LedgerSuite) TestEntries() {
+	tests := []struct {
+		name     string
+		entry    *domain.JournalEntry
+		expected []domain.Posting
+	}{
+		{
+			name: "Disbursement With Upfront Fee",
+			entry: domain.NewDisbursementEntry(&domain.Loan{
+				Model:        gorm.Model{ID: 1},
+				Principal:    money.FromFloat(1000),
+				NetDisbursed: money.FromFloat(950),
+				Fees: []domain.LoanFee{
+					{Charge: domain.FeeUpfront, Amount: money.FromFloat(50)},
+					{Charge: domain.FeeAmortized, Amount: money.FromFloat(20)},
+				},
+			}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(1000)},
+				{AccountCode: domain.AccountCash, Credit: money.FromFloat(950)},
+				{AccountCode: domain.AccountFeeIncome, Credit: money.FromFloat(50)},
+			},
+		},
+		{
+			name: "Disbursement Of Refinancing Loan",
+			entry: domain.NewDisbursementEntry(&domain.Loan{
+				Model:        gorm.Model{ID: 2},
+				Principal:    money.FromFloat(1000),
+				NetDisbursed: money.FromFloat(400),
+			}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(1000)},
+				{AccountCode: domain.AccountCash, Credit: money.FromFloat(400)},
+				{AccountCode: domain.AccountRefinanceClearing, Credit: money.FromFloat(600)},
+			},
+		},
+		{
+			name: "Repayment With Overpayment",
+			entry: domain.NewRepaymentEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Payment{
+				Amount:    money.FromFloat(130),
+				Principal: money.FromFloat(100),
+				Interest:  money.FromFloat(10),
+				FeeAmount: money.FromFloat(5),
+				Penalty:   money.FromFloat(5),
+			}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(130)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(100)},
+				{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10)},
+				{AccountCode: domain.AccountFeeIncome, Credit: money.FromFloat(5)},
+				{AccountCode: domain.AccountPenaltyIncome, Credit: money.FromFloat(5)},
+				{AccountCode: domain.AccountBorrowerCredit, Credit: money.FromFloat(10)},
+			},
+		},
+		{
+			name: "Repayment Drawing On Credit Balance",
+			entry: domain.NewRepaymentEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Payment{
+				Amount:    money.FromFloat(80),
+				Principal: money.FromFloat(100),
+				Interest:  money.FromFloat(10),
+			}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(80)},
+				{AccountCode: domain.AccountBorrowerCredit, Debit: money.FromFloat(30)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(100)},
+				{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10)},
+			},
+		},
+		{
+			name:  "Recovery",
+			entry: domain.NewRecoveryEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Payment{Amount: money.FromFloat(40), Principal: money.FromFloat(40), Recovery: true}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountCash, Debit: money.FromFloat(40)},
+				{AccountCode: domain.AccountRecoveryIncome, Credit: money.FromFloat(40)},
+			},
+		},
+		{
+			name: "Reversal Of Repayment",
+			entry: domain.NewReversalEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Payment{
+				Amount:    money.FromFloat(120),
+				Principal: money.FromFloat(100),
+				Interest:  money.FromFloat(10),
+			}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountCash, Credit: money.FromFloat(120)},
+				{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(100)},
+				{AccountCode: domain.AccountInterestIncome, Debit: money.FromFloat(10)},
+				{AccountCode: domain.AccountBorrowerCredit, Debit: money.FromFloat(10)},
+			},
+		},
+		{
+			name:  "Reversal Of Recovery",
+			entry: domain.NewReversalEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Payment{Amount: money.FromFloat(40), Principal: money.FromFloat(40), Recovery: true}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountCash, Credit: money.FromFloat(40)},
+				{AccountCode: domain.AccountRecoveryIncome, Debit: money.FromFloat(40)},
+			},
+		},
+		{
+			name:  "Refund Of Credit Balance",
+			entry: domain.NewRefundEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, &domain.Refund{Amount: money.FromFloat(15)}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountBorrowerCredit, Debit: money.FromFloat(15)},
+				{AccountCode: domain.AccountCash, Credit: money.FromFloat(15)},
+			},
+		},
+		{
+			name:  "Capitalized Arrears",
+			entry: domain.NewCapitalizationEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, domain.ScheduleAllocation{Interest: money.FromFloat(10), Penalty: money.FromFloat(2)}),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(12)},
+				{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10)},
+				{AccountCode: domain.AccountPenaltyIncome, Credit: money.FromFloat(2)},
+			},
+		},
+		{
+			name:  "Refinance Payoff Using Credit Balance",
+			entry: domain.NewRefinancePayoffEntry(&domain.Loan{Model: gorm.Model{ID: 1}}, domain.ScheduleAllocation{Principal: money.FromFloat(200), Interest: money.FromFloat(20)}, money.FromFloat(200)),
+			expected: []domain.Posting{
+				{AccountCode: domain.AccountRefinanceClearing, Debit: money.FromFloat(200)},
+				{AccountCode: domain.AccountBorrowerCredit, Debit: money.FromFloat(20)},
+				{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(200)},
+				{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(20)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, tt.entry.Postings)
+			s.NoError(tt.entry.Validate())
+			s.NotNil(tt.entry.LoanID)
+		})
+	}
+}
+
+func (s *LedgerSuite) TestCapitalizedDeferral() {
+	schedule := func() domain.PaymentSchedule {
+		return domain.PaymentSchedule{DueAmount: money.FromFloat(110), PrincipalAmount: money.FromFloat(100), InterestAmount: money.FromFloat(10)}
+	}
+	loan := &domain.Loan{
+		Model:            gorm.Model{ID: 1},
+		Principal:        money.FromFloat(300),
+		NetDisbursed:     money.FromFloat(300),
+		PaymentSchedules: []domain.PaymentSchedule{schedule(), schedule(), schedule()},
+	}
+	entries := []*domain.JournalEntry{domain.NewDisbursementEntry(loan)}
+
+	deferred := loan.PaymentSchedules[0].Defer()
+	principalParts := deferred.Principal.Split(2)
+	interestParts := deferred.Interest.Split(2)
+	for n := range principalParts {
+		loan.PaymentSchedules[n+1].Capitalize(domain.ScheduleAllocation{Principal: principalParts[n], Interest: interestParts[n]})
+	}
+	entries = append(entries, domain.NewCapitalizationEntry(loan, domain.ScheduleAllocation{Interest: deferred.Interest, Penalty: deferred.Penalty}))
+
+	applied := loan.PaymentSchedules[1].Pay(money.FromFloat(165), nil)
+	entries = append(entries, domain.NewRepaymentEntry(loan, &domain.Payment{
+		Amount:    applied.Total(),
+		Principal: applied.Principal,
+		Interest:  applied.Interest,
+	}))
+
+	var receivable money.Money
+	for _, entry := range entries {
+		s.Require().NoError(entry.Validate())
+		for _, posting := range entry.Postings {
+			if posting.AccountCode == domain.AccountLoansReceivable {
+				receivable += posting.Debit - posting.Credit
+			}
+		}
+	}
+
+	// What is still receivable is exactly the principal of the last
+	// installment, which now carries half of the deferred interest.
+	s.True(loan.PaymentSchedules[1].Paid)
+	s.Equal(loan.PaymentSchedules[2].PrincipalAmount, receivable)
+	s.Equal(money.FromFloat(155), receivable)
+}
+
+func (s *LedgerSuite) TestDebitNormal() {
+	s.True(domain.AccountAsset.DebitNormal())
+	s.True(domain.AccountExpense.DebitNormal())
+	s.False(domain.AccountLiability.DebitNormal())
+	s.False(domain.AccountIncome.DebitNormal())
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
@@ -5,31 +5,125 @@ import (
 	"time"
 
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	"gorm.io/gorm"
 )
 
 type Loan struct {
 	gorm.Model
-	BorrowerID        uint              `gorm:"not null" json:"borrower_id"`
-	Principal         float64           `gorm:"not null" json:"principal"`
-	InterestRate      float64           `gorm:"not null" json:"interest_rate"`
-	DurationWeeks     int               `gorm:"not null" json:"duration_weeks"`
-	OutstandingAmount float64           `gorm:"not null" json:"outstanding_amount"`
-	StartDate         time.Time         `gorm:"not null" json:"start_date"`
-	PaymentSchedules  []PaymentSchedule `gorm:"foreignKey:LoanID"`
+	BorrowerID         uint               `gorm:"not null" json:"borrower_id"`
+	ProductID          uint               `gorm:"index" json:"product_id"`
+	Principal          money.Money        `gorm:"not null" json:"principal"`
+	TotalFees          money.Money        `gorm:"not null;default:0" json:"total_fees"`
+	NetDisbursed       money.Money        `gorm:"not null;default:0" json:"net_disbursed"`
+	InterestRate       float64            `gorm:"not null" json:"interest_rate"`
+	ServiceFeeRate     float64            `gorm:"not null;default:0" json:"service_fee_rate"`
+	Tenor              int                `gorm:"not null" json:"tenor"`
+	Frequency          RepaymentFrequency `gorm:"not null;default:weekly" json:"frequency"`
+	AmortizationMethod AmortizationMethod `gorm:"not null;default:flat" json:"amortization_method"`
+	RebatePolicy       RebatePolicy       `gorm:"not null;default:none" json:"rebate_policy"`
+	DayCount           DayCount           `gorm:"not null;default:act/365" json:"day_count"`
+	AllocationOrder    AllocationOrder    `gorm:"serializer:json" json:"allocation_order"`
+	PenaltyPolicy      PenaltyPolicy      `gorm:"embedded;embeddedPrefix:penalty_" json:"penalty_policy"`
+	OutstandingAmount  money.Money        `gorm:"not null" json:"outstanding_amount"`
+	CreditBalance      money.Money        `gorm:"not null;default:0" json:"credit_balance"`
+	WrittenOffAmount   money.Money        `gorm:"not null;default:0" json:"written_off_amount"`
+	RecoveredAmount    money.Money        `gorm:"not null;default:0" json:"recovered_amount"`
+	StartDate          time.Time          `gorm:"not null" json:"start_date"`
+	CalendarName       string             `json:"calendar_name"`
+	RollConvention     RollConvention     `gorm:"not null;default:unadjusted" json:"roll_convention"`
+	Status             LoanStatus         `gorm:"not null;default:proposed;index" json:"status"`
+	RefinancedLoanID   *uint              `gorm:"index" json:"refinanced_loan_id,omitempty"`
+	RefinancedByLoanID *uint              `gorm:"index" json:"refinanced_by_loan_id,omitempty"`
+	PaymentSchedules   []PaymentSchedule  `gorm:"foreignKey:LoanID"`
+	Fees               []LoanFee          `gorm:"foreignKey:LoanID"`
+	StatusHistory      []LoanStatusChange `gorm:"foreignKey:LoanID"`
+	Approval           *LoanApproval      `gorm:"foreignKey:LoanID"`
+}
+
+// UpfrontFees totals the fees kept from the principal when the loan is
+// disbursed.
+func (l *Loan) UpfrontFees() money.Money {
+	var upfront money.Money
+	for _, fee := range l.Fees {
+		if fee.Charge == FeeUpfront {
+			upfront += fee.Amount
+		}
+	}
+	return upfront
+}
+
+// LoanFee is a product fee as it was assessed when the loan was created.
+type LoanFee struct {
+	gorm.Model
+	LoanID uint        `gorm:"not null;index" json:"loan_id"`
+	Name   string      `gorm:"not null" json:"name"`
+	Type   FeeType     `gorm:"not null" json:"type"`
+	Charge FeeCharge   `gorm:"not null" json:"charge"`
+	Amount money.Money `gorm:"not null" json:"amount"`
+}
+
+// LoanTerms describes how a loan is priced and repaid. The interest rate,
+// service fee rate, amortization method, rebate policy, day count, allocation
+// order, penalty policy and fees are filled in from the loan product. Loans to
+// members of a borrower group fall due on the group's MeetingDay.
+type LoanTerms struct {
+	ProductID          uint
+	Principal          money.Money
+	InterestRate       float64
+	ServiceFeeRate     float64
+	Tenor              int
+	Frequency          RepaymentFrequency
+	AmortizationMethod AmortizationMethod
+	RebatePolicy       RebatePolicy
+	DayCount           DayCount
+	AllocationOrder    AllocationOrder
+	PenaltyPolicy      PenaltyPolicy
+	StartDate          time.Time
+	CalendarName       string
+	RollConvention     RollConvention
+	MeetingDay         *time.Weekday
+	Fees               []LoanProductFee
 }
 
 type LoanUsecase interface {
-	CreateLoan(ctx context.Context, borrowerID uint, principal, interestRate float64, durationWeeks int32, startDate time.Time) (*dto.CreateLoanResponse, error)
+	CreateLoan(ctx context.Context, borrowerID uint, terms LoanTerms) (*dto.CreateLoanResponse, error)
+	QuoteLoan(ctx context.Context, terms LoanTerms) (*dto.QuoteLoanResponse, error)
+	UpdateLoan(ctx context.Context, loanID uint, terms LoanTerms) (*dto.CreateLoanResponse, error)
 	GetLoanDetails(ctx context.Context, loanID uint) (*dto.GetLoanDetailsResponse, error)
-	GetOutstandingAmount(ctx context.Context, loanID uint) (float64, error)
+	GetOutstandingAmount(ctx context.Context, loanID uint) (money.Money, error)
+
+	ApproveLoan(ctx context.Context, loanID uint, approval LoanApproval) (*dto.GetLoanApprovalResponse, error)
+	GetLoanApproval(ctx context.Context, loanID uint) (*dto.GetLoanApprovalResponse, error)
+	DisburseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error)
+	CloseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error)
+	CancelLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error)
+	WriteOffLoan(ctx context.Context, loanID uint, reason string) (*dto.WriteOffLoanResponse, error)
+	GetPortfolio(ctx context.Context) (*dto.PortfolioResponse, error)
+
+	RestructureLoan(ctx context.Context, loanID uint, terms RestructureTerms) (*dto.RestructureLoanResponse, error)
+	DeferInstallments(ctx context.Context, loanID uint, deferral LoanDeferral) (*dto.DeferInstallmentsResponse, error)
+	RefinanceLoan(ctx context.Context, loanID uint, terms LoanTerms) (*dto.CreateLoanResponse, error)
 }
 
 type LoanRepository interface {
 	CreateLoan(ctx context.Context, loan *Loan, tx *gorm.DB) error
+	CreateLoanFees(ctx context.Context, fees []LoanFee, tx *gorm.DB) error
+	CreateLoanApproval(ctx context.Context, approval *LoanApproval, tx *gorm.DB) error
 
 	FindLoanByID(ctx context.Context, loanID uint) (*Loan, error)
 	GetLoansByBorrowerID(ctx context.Context, borrowerID uint) ([]Loan, error)
+	GetOverdueLoans(ctx context.Context, date time.Time) ([]Loan, error)
+	GetActiveLoansByBorrowerIDs(ctx context.Context, borrowerIDs []uint) ([]Loan, error)
+	GetDisbursedLoans(ctx context.Context) ([]Loan, error)
+	GetPortfolioSummary(ctx context.Context) (*PortfolioSummary, error)
 
 	UpdateLoan(ctx context.Context, loan *Loan, tx *gorm.DB) error
+	CreateStatusChange(ctx context.Context, change *LoanStatusChange, tx *gorm.DB) error
+	CreateRestructure(ctx context.Context, restructure *LoanRestructure, tx *gorm.DB) error
+	CreateDeferral(ctx context.Context, deferral *LoanDeferral, tx *gorm.DB) error
+	CreateWriteOff(ctx context.Context, writeOff *LoanWriteOff, tx *gorm.DB) error
+	CreateRefinance(ctx context.Context, refinance *LoanRefinance, tx *gorm.DB) error
+
+	DeleteLoanFees(ctx context.Context, loanID uint, tx *gorm.DB) error
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoanApproval is the evidence a field validator gives when approving a
+// proposed loan: who visited the borrower, when, and a photo of the visit.
+type LoanApproval struct {
+	gorm.Model
+	LoanID            uint      `gorm:"not null;uniqueIndex" json:"loan_id"`
+	ValidatorID       uint      `gorm:"not null;index" json:"validator_id"`
+	ApprovalDate      time.Time `gorm:"not null" json:"approval_date"`
+	ProofOfVisitImage string    `gorm:"not null" json:"proof_of_visit_image"`
+}
+
+func (a LoanApproval) Validate() error {
+	if a.ValidatorID == 0 {
+		return fmt.Errorf("validator ID is required")
+	}
+	if a.ApprovalDate.IsZero() {
+		return fmt.Errorf("approval date is required")
+	}
+	if a.ApprovalDate.After(time.Now()) {
+		return fmt.Errorf("approval date must not be in the future")
+	}
+	if a.ProofOfVisitImage == "" {
+		return fmt.Errorf("proof of visit image is required")
+	}
+	return nil
+}
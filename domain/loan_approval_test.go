@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanApprovalSuite struct {
+	suite.Suite
+}
+
+func (s *LoanApprovalSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		modify        func(*domain.LoanApproval)
+		expectedError string
+	}{
+		{
+			name:   "Valid Approval",
+			modify: func(a *domain.LoanApproval) {},
+		},
+		{
+			name:          "Missing Validator",
+			modify:        func(a *domain.LoanApproval) { a.ValidatorID = 0 },
+			expectedError: "validator ID is required",
+		},
+		{
+			name:          "Missing Approval Date",
+			modify:        func(a *domain.LoanApproval) { a.ApprovalDate = time.Time{} },
+			expectedError: "approval date is required",
+		},
+		{
+			name:          "Future Approval Date",
+			modify:        func(a *domain.LoanApproval) { a.ApprovalDate = time.Now().AddDate(0, 0, 1) },
+			expectedError: "approval date must not be in the future",
+		},
+		{
+			name:          "Missing Proof Of Visit",
+			modify:        func(a *domain.LoanApproval) { a.ProofOfVisitImage = "" },
+			expectedError: "proof of visit image is required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			approval := domain.LoanApproval{
+				ValidatorID:       7,
+				ApprovalDate:      time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+				ProofOfVisitImage: "visits/loan-1.jpg",
+			}
+			tt.modify(&approval)
+
+			err := approval.Validate()
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func TestLoanApprovalSuite(t *testing.T) {
+	suite.Run(t, new(LoanApprovalSuite))
+}
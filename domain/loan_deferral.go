@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// DeferralPolicy says what happens to installments deferred by a payment
+// holiday: moved past the last installment, or dropped with what they owed
+// capitalized into the installments that remain.
+type DeferralPolicy string
+
+const (
+	DeferralExtendTenor        DeferralPolicy = "extend_tenor"
+	DeferralCapitalizeInterest DeferralPolicy = "capitalize_interest"
+)
+
+func (p DeferralPolicy) IsValid() bool {
+	switch p {
+	case DeferralExtendTenor, DeferralCapitalizeInterest:
+		return true
+	}
+	return false
+}
+
+// LoanDeferral is a payment holiday granted by an officer on the next
+// Installments upcoming installments of a disbursed loan. HolidayInterest is
+// the interest charged on the deferred principal when it is capitalized, and
+// is part of CapitalizedAmount.
+type LoanDeferral struct {
+	gorm.Model
+	LoanID            uint           `gorm:"not null;index" json:"loan_id"`
+	Installments      int            `gorm:"not null" json:"installments"`
+	Policy            DeferralPolicy `gorm:"not null" json:"policy"`
+	Reason            string         `gorm:"not null" json:"reason"`
+	ApproverID        uint           `gorm:"not null;index" json:"approver_id"`
+	CapitalizedAmount money.Money    `gorm:"not null;default:0" json:"capitalized_amount"`
+	HolidayInterest   money.Money    `gorm:"not null;default:0" json:"holiday_interest"`
+}
+
+func (d LoanDeferral) Validate() error {
+	if d.Installments <= 0 {
+		return fmt.Errorf("at least one installment must be deferred")
+	}
+	if !d.Policy.IsValid() {
+		return fmt.Errorf("invalid deferral policy")
+	}
+	if d.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if d.ApproverID == 0 {
+		return fmt.Errorf("approver ID is required")
+	}
+	return nil
+}
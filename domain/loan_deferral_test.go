@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanDeferralSuite struct {
+	suite.Suite
+}
+
+func (s *LoanDeferralSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		modify        func(*domain.LoanDeferral)
+		expectedError string
+	}{
+		{
+			name:   "Valid Deferral",
+			modify: func(d *domain.LoanDeferral) {},
+		},
+		{
+			name:          "No Installments",
+			modify:        func(d *domain.LoanDeferral) { d.Installments = 0 },
+			expectedError: "at least one installment must be deferred",
+		},
+		{
+			name:          "Invalid Policy",
+			modify:        func(d *domain.LoanDeferral) { d.Policy = "forgive" },
+			expectedError: "invalid deferral policy",
+		},
+		{
+			name:          "Missing Reason",
+			modify:        func(d *domain.LoanDeferral) { d.Reason = "" },
+			expectedError: "reason is required",
+		},
+		{
+			name:          "Missing Approver",
+			modify:        func(d *domain.LoanDeferral) { d.ApproverID = 0 },
+			expectedError: "approver ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			deferral := domain.LoanDeferral{
+				Installments: 2,
+				Policy:       domain.DeferralExtendTenor,
+				Reason:       "Ramadan",
+				ApproverID:   7,
+			}
+			tt.modify(&deferral)
+
+			err := deferral.Validate()
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func TestLoanDeferralSuite(t *testing.T) {
+	suite.Run(t, new(LoanDeferralSuite))
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+type FeeType string
+
+const (
+	FeeFlat       FeeType = "flat"
+	FeePercentage FeeType = "percentage"
+)
+
+func (f FeeType) IsValid() bool {
+	switch f {
+	case FeeFlat, FeePercentage:
+		return true
+	}
+	return false
+}
+
+// FeeCharge says how a fee is collected: deducted from the disbursement or
+// spread over the installments.
+type FeeCharge string
+
+const (
+	FeeUpfront   FeeCharge = "upfront"
+	FeeAmortized FeeCharge = "amortized"
+)
+
+func (f FeeCharge) IsValid() bool {
+	switch f {
+	case FeeUpfront, FeeAmortized:
+		return true
+	}
+	return false
+}
+
+// LoanProduct is a loan offering. ServiceFeeRate is the percentage of the
+// interest repaid that the platform keeps before paying investors, and
+// AllocationOrder decides which installment components repayments settle
+// first. PenaltyPolicy prices late payment on the product's loans, and
+// DayCount decides how their interest accrues between due dates.
+type LoanProduct struct {
+	gorm.Model
+	Name               string             `gorm:"not null;uniqueIndex" json:"name"`
+	MinPrincipal       money.Money        `gorm:"not null" json:"min_principal"`
+	MaxPrincipal       money.Money        `gorm:"not null" json:"max_principal"`
+	Tenors             []int              `gorm:"not null;serializer:json" json:"tenors"`
+	InterestRate       float64            `gorm:"not null" json:"interest_rate"`
+	ServiceFeeRate     float64            `gorm:"not null;default:0" json:"service_fee_rate"`
+	AmortizationMethod AmortizationMethod `gorm:"not null;default:flat" json:"amortization_method"`
+	RebatePolicy       RebatePolicy       `gorm:"not null;default:none" json:"rebate_policy"`
+	DayCount           DayCount           `gorm:"not null;default:act/365" json:"day_count"`
+	AllocationOrder    AllocationOrder    `gorm:"serializer:json" json:"allocation_order"`
+	PenaltyPolicy      PenaltyPolicy      `gorm:"embedded;embeddedPrefix:penalty_" json:"penalty_policy"`
+	Fees               []LoanProductFee   `gorm:"foreignKey:ProductID" json:"fees"`
+}
+
+// LoanProductFee is a fee charged on every loan of a product. Flat fees use
+// Amount, percentage fees use Rate as a percentage of the principal.
+type LoanProductFee struct {
+	gorm.Model
+	ProductID uint        `gorm:"not null;index" json:"product_id"`
+	Name      string      `gorm:"not null" json:"name"`
+	Type      FeeType     `gorm:"not null" json:"type"`
+	Charge    FeeCharge   `gorm:"not null;default:upfront" json:"charge"`
+	Amount    money.Money `json:"amount"`
+	Rate      float64     `json:"rate"`
+}
+
+// Validate checks that the product can be offered: the bounds must be
+// consistent and every tenor must be positive.
+func (p *LoanProduct) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("product name is required")
+	}
+	if p.MinPrincipal <= 0 || p.MaxPrincipal < p.MinPrincipal {
+		return fmt.Errorf("invalid principal range")
+	}
+	if p.InterestRate < 0 {
+		return fmt.Errorf("interest rate must not be negative")
+	}
+	if p.ServiceFeeRate < 0 || p.ServiceFeeRate > 100 {
+		return fmt.Errorf("service fee rate must be between 0 and 100")
+	}
+	if len(p.Tenors) == 0 {
+		return fmt.Errorf("at least one tenor is required")
+	}
+	for _, tenor := range p.Tenors {
+		if tenor <= 0 {
+			return fmt.Errorf("tenor must be positive")
+		}
+	}
+	if !p.AmortizationMethod.IsValid() {
+		return fmt.Errorf("invalid amortization method")
+	}
+	if !p.RebatePolicy.IsValid() {
+		return fmt.Errorf("invalid rebate policy")
+	}
+	if !p.DayCount.IsValid() {
+		return fmt.Errorf("invalid day count")
+	}
+	if err := p.AllocationOrder.Validate(); err != nil {
+		return err
+	}
+	if err := p.PenaltyPolicy.Validate(); err != nil {
+		return err
+	}
+	for _, fee := range p.Fees {
+		if !fee.Type.IsValid() {
+			return fmt.Errorf("invalid fee type")
+		}
+		if !fee.Charge.IsValid() {
+			return fmt.Errorf("invalid fee charge")
+		}
+		if fee.Amount < 0 || fee.Rate < 0 {
+			return fmt.Errorf("fee must not be negative")
+		}
+	}
+	return nil
+}
+
+// AmountFor returns the fee charged on a loan with the given principal.
+func (f LoanProductFee) AmountFor(principal money.Money) money.Money {
+	if f.Type == FeePercentage {
+		return principal.MulRate(f.Rate / 100)
+	}
+	return f.Amount
+}
+
+// CheckTerms reports whether a loan with the given principal and tenor can be
+// booked under the product.
+func (p *LoanProduct) CheckTerms(principal money.Money, tenor int) error {
+	if principal < p.MinPrincipal || principal > p.MaxPrincipal {
+		return fmt.Errorf("principal must be between %s and %s", p.MinPrincipal, p.MaxPrincipal)
+	}
+
+	for _, allowed := range p.Tenors {
+		if allowed == tenor {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("tenor %d is not offered by product %s", tenor, p.Name)
+}
+
+type LoanProductUsecase interface {
+	CreateProduct(ctx context.Context, product LoanProduct) (*dto.GetLoanProductResponse, error)
+	GetProduct(ctx context.Context, productID uint) (*dto.GetLoanProductResponse, error)
+	GetProducts(ctx context.Context) ([]dto.GetLoanProductResponse, error)
+	UpdateProduct(ctx context.Context, productID uint, product LoanProduct) (*dto.GetLoanProductResponse, error)
+	DeleteProduct(ctx context.Context, productID uint) error
+}
+
+type LoanProductRepository interface {
+	CreateProduct(ctx context.Context, product *LoanProduct, tx *gorm.DB) error
+
+	FindProductByID(ctx context.Context, productID uint) (*LoanProduct, error)
+	GetProducts(ctx context.Context) ([]LoanProduct, error)
+
+	UpdateProduct(ctx context.Context, product *LoanProduct, tx *gorm.DB) error
+	ReplaceFees(ctx context.Context, productID uint, fees []LoanProductFee, tx *gorm.DB) error
+	DeleteProduct(ctx context.Context, productID uint, tx *gorm.DB) error
+}
@@ -0,0 +1,149 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanProductSuite struct {
+	suite.Suite
+}
+
+func weeklyMicroProduct() domain.LoanProduct {
+	return domain.LoanProduct{
+		Name:               "Weekly Micro",
+		MinPrincipal:       money.FromFloat(500),
+		MaxPrincipal:       money.FromFloat(10000),
+		Tenors:             []int{25, 50},
+		InterestRate:       12,
+		AmortizationMethod: domain.AmortizationFlat,
+		RebatePolicy:       domain.RebateNone,
+		DayCount:           domain.DayCountActual365,
+		PenaltyPolicy:      domain.PenaltyPolicy{Type: domain.PenaltyNone},
+		Fees: []domain.LoanProductFee{
+			{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Rate: 2},
+		},
+	}
+}
+
+func (s *LoanProductSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		modify        func(*domain.LoanProduct)
+		expectedError string
+	}{
+		{
+			name:   "Valid Product",
+			modify: func(p *domain.LoanProduct) {},
+		},
+		{
+			name:          "Missing Name",
+			modify:        func(p *domain.LoanProduct) { p.Name = "" },
+			expectedError: "product name is required",
+		},
+		{
+			name:          "Max Below Min",
+			modify:        func(p *domain.LoanProduct) { p.MaxPrincipal = money.FromFloat(100) },
+			expectedError: "invalid principal range",
+		},
+		{
+			name:          "Negative Rate",
+			modify:        func(p *domain.LoanProduct) { p.InterestRate = -1 },
+			expectedError: "interest rate must not be negative",
+		},
+		{
+			name:          "Service Fee Rate Above 100",
+			modify:        func(p *domain.LoanProduct) { p.ServiceFeeRate = 101 },
+			expectedError: "service fee rate must be between 0 and 100",
+		},
+		{
+			name:          "No Tenors",
+			modify:        func(p *domain.LoanProduct) { p.Tenors = nil },
+			expectedError: "at least one tenor is required",
+		},
+		{
+			name:          "Zero Tenor",
+			modify:        func(p *domain.LoanProduct) { p.Tenors = []int{0, 25} },
+			expectedError: "tenor must be positive",
+		},
+		{
+			name:          "Invalid Amortization Method",
+			modify:        func(p *domain.LoanProduct) { p.AmortizationMethod = "balloon" },
+			expectedError: "invalid amortization method",
+		},
+		{
+			name:          "Invalid Rebate Policy",
+			modify:        func(p *domain.LoanProduct) { p.RebatePolicy = "half" },
+			expectedError: "invalid rebate policy",
+		},
+		{
+			name:          "Invalid Day Count",
+			modify:        func(p *domain.LoanProduct) { p.DayCount = "act/360" },
+			expectedError: "invalid day count",
+		},
+		{
+			name:          "Invalid Allocation Order",
+			modify:        func(p *domain.LoanProduct) { p.AllocationOrder = domain.AllocationOrder{domain.ComponentFee} },
+			expectedError: "allocation order must list each of fee, penalty, interest and principal once",
+		},
+		{
+			name:          "Invalid Penalty Type",
+			modify:        func(p *domain.LoanProduct) { p.PenaltyPolicy.Type = "weekly" },
+			expectedError: "invalid penalty type",
+		},
+		{
+			name:          "Negative Penalty Cap",
+			modify:        func(p *domain.LoanProduct) { p.PenaltyPolicy.Cap = money.FromFloat(-1) },
+			expectedError: "penalty must not be negative",
+		},
+		{
+			name:          "Invalid Fee Type",
+			modify:        func(p *domain.LoanProduct) { p.Fees[0].Type = "tiered" },
+			expectedError: "invalid fee type",
+		},
+		{
+			name:          "Invalid Fee Charge",
+			modify:        func(p *domain.LoanProduct) { p.Fees[0].Charge = "monthly" },
+			expectedError: "invalid fee charge",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			product := weeklyMicroProduct()
+			tt.modify(&product)
+
+			err := product.Validate()
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *LoanProductSuite) TestCheckTerms() {
+	product := weeklyMicroProduct()
+
+	s.NoError(product.CheckTerms(money.FromFloat(500), 25))
+	s.NoError(product.CheckTerms(money.FromFloat(10000), 50))
+	s.EqualError(product.CheckTerms(money.FromFloat(499.99), 25), "principal must be between 500.00 and 10000.00")
+	s.EqualError(product.CheckTerms(money.FromFloat(1000), 30), "tenor 30 is not offered by product Weekly Micro")
+}
+
+func (s *LoanProductSuite) TestFeeAmountFor() {
+	percentage := domain.LoanProductFee{Type: domain.FeePercentage, Rate: 2.5}
+	flat := domain.LoanProductFee{Type: domain.FeeFlat, Amount: money.FromFloat(15)}
+
+	s.Equal(money.FromFloat(25), percentage.AmountFor(money.FromFloat(1000)))
+	s.Equal(money.FromFloat(0.03), percentage.AmountFor(money.FromFloat(1.15)))
+	s.Equal(money.FromFloat(15), flat.AmountFor(money.FromFloat(1000)))
+}
+
+func TestLoanProductSuite(t *testing.T) {
+	suite.Run(t, new(LoanProductSuite))
+}
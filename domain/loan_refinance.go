@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// LoanRefinance links a new loan to the active loan whose outstanding
+// balance was paid off from its principal.
+type LoanRefinance struct {
+	gorm.Model
+	LoanID           uint        `gorm:"not null;uniqueIndex" json:"loan_id"`
+	RefinancedLoanID uint        `gorm:"not null;uniqueIndex" json:"refinanced_loan_id"`
+	PayoffAmount     money.Money `gorm:"not null" json:"payoff_amount"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// LoanRestructure records a new repayment schedule replacing the unpaid
+// installments of a disbursed loan. The installments it replaced are kept as
+// superseded and point back to it.
+type LoanRestructure struct {
+	gorm.Model
+	LoanID              uint        `gorm:"not null;index" json:"loan_id"`
+	PreviousTenor       int         `gorm:"not null" json:"previous_tenor"`
+	NewTenor            int         `gorm:"not null" json:"new_tenor"`
+	PreviousRate        float64     `gorm:"not null" json:"previous_rate"`
+	NewRate             float64     `gorm:"not null" json:"new_rate"`
+	CapitalizedArrears  money.Money `gorm:"not null;default:0" json:"capitalized_arrears"`
+	PreviousOutstanding money.Money `gorm:"not null" json:"previous_outstanding"`
+	NewOutstanding      money.Money `gorm:"not null" json:"new_outstanding"`
+	Reason              string      `json:"reason"`
+}
+
+// RestructureTerms describes the new schedule. Tenor is the number of new
+// installments and defaults to the number being replaced; a nil InterestRate
+// keeps the loan's rate. Capitalized arrears fold what is overdue into the
+// principal of the new schedule, otherwise overdue installments stay due as
+// they are.
+type RestructureTerms struct {
+	Tenor             int
+	InterestRate      *float64
+	CapitalizeArrears bool
+	Reason            string
+}
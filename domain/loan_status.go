@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type LoanStatus string
+
+const (
+	LoanProposed   LoanStatus = "proposed"
+	LoanApproved   LoanStatus = "approved"
+	LoanInvested   LoanStatus = "invested"
+	LoanDisbursed  LoanStatus = "disbursed"
+	LoanClosed     LoanStatus = "closed"
+	LoanCancelled  LoanStatus = "cancelled"
+	LoanWrittenOff LoanStatus = "written_off"
+)
+
+// loanTransitions lists the statuses a loan may move to from each status.
+// Closed, cancelled and written-off loans are final.
+var loanTransitions = map[LoanStatus][]LoanStatus{
+	LoanProposed:  {LoanApproved, LoanCancelled},
+	LoanApproved:  {LoanInvested, LoanCancelled},
+	LoanInvested:  {LoanDisbursed, LoanCancelled},
+	LoanDisbursed: {LoanClosed, LoanWrittenOff},
+}
+
+func (s LoanStatus) CanTransitionTo(next LoanStatus) bool {
+	for _, allowed := range loanTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEditable reports whether the loan terms may still change. Terms are
+// fixed once a loan is approved.
+func (s LoanStatus) IsEditable() bool {
+	return s == LoanProposed
+}
+
+// LoanStatusChange records a single transition in a loan's lifecycle.
+type LoanStatusChange struct {
+	gorm.Model
+	LoanID     uint       `gorm:"not null;index" json:"loan_id"`
+	FromStatus LoanStatus `gorm:"not null" json:"from_status"`
+	ToStatus   LoanStatus `gorm:"not null" json:"to_status"`
+}
+
+// TransitionTo moves the loan to the next status and returns the change to
+// be persisted alongside it.
+func (l *Loan) TransitionTo(next LoanStatus) (*LoanStatusChange, error) {
+	if !l.Status.CanTransitionTo(next) {
+		return nil, fmt.Errorf("cannot move loan from %s to %s", l.Status, next)
+	}
+
+	change := &LoanStatusChange{
+		LoanID:     l.ID,
+		FromStatus: l.Status,
+		ToStatus:   next,
+	}
+	l.Status = next
+	return change, nil
+}
+
+// Reopen moves a closed loan back to disbursed once the payment that closed
+// it has been reversed. It is the only way out of a final status.
+func (l *Loan) Reopen() (*LoanStatusChange, error) {
+	if l.Status != LoanClosed {
+		return nil, fmt.Errorf("cannot reopen a %s loan", l.Status)
+	}
+
+	change := &LoanStatusChange{
+		LoanID:     l.ID,
+		FromStatus: l.Status,
+		ToStatus:   LoanDisbursed,
+	}
+	l.Status = LoanDisbursed
+	return change, nil
+}
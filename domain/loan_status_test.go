@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LoanStatusSuite struct {
+	suite.Suite
+}
+
+func (s *LoanStatusSuite) TestCanTransitionTo() {
+	tests := []struct {
+		from     domain.LoanStatus
+		to       domain.LoanStatus
+		expected bool
+	}{
+		{domain.LoanProposed, domain.LoanApproved, true},
+		{domain.LoanApproved, domain.LoanInvested, true},
+		{domain.LoanInvested, domain.LoanDisbursed, true},
+		{domain.LoanDisbursed, domain.LoanClosed, true},
+		{domain.LoanProposed, domain.LoanCancelled, true},
+		{domain.LoanInvested, domain.LoanCancelled, true},
+		{domain.LoanDisbursed, domain.LoanWrittenOff, true},
+		{domain.LoanProposed, domain.LoanDisbursed, false},
+		{domain.LoanApproved, domain.LoanProposed, false},
+		{domain.LoanDisbursed, domain.LoanCancelled, false},
+		{domain.LoanApproved, domain.LoanWrittenOff, false},
+		{domain.LoanClosed, domain.LoanDisbursed, false},
+		{domain.LoanWrittenOff, domain.LoanClosed, false},
+	}
+
+	for _, tt := range tests {
+		s.Run(string(tt.from)+" to "+string(tt.to), func() {
+			s.Equal(tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func (s *LoanStatusSuite) TestTransitionTo() {
+	loan := domain.Loan{Model: gorm.Model{ID: 7}, Status: domain.LoanProposed}
+
+	change, err := loan.TransitionTo(domain.LoanApproved)
+	s.NoError(err)
+	s.Equal(&domain.LoanStatusChange{LoanID: 7, FromStatus: domain.LoanProposed, ToStatus: domain.LoanApproved}, change)
+	s.Equal(domain.LoanApproved, loan.Status)
+
+	_, err = loan.TransitionTo(domain.LoanClosed)
+	s.EqualError(err, "cannot move loan from approved to closed")
+	s.Equal(domain.LoanApproved, loan.Status)
+}
+
+func (s *LoanStatusSuite) TestReopen() {
+	loan := domain.Loan{Model: gorm.Model{ID: 7}, Status: domain.LoanClosed}
+
+	change, err := loan.Reopen()
+	s.NoError(err)
+	s.Equal(&domain.LoanStatusChange{LoanID: 7, FromStatus: domain.LoanClosed, ToStatus: domain.LoanDisbursed}, change)
+	s.Equal(domain.LoanDisbursed, loan.Status)
+
+	_, err = loan.Reopen()
+	s.EqualError(err, "cannot reopen a disbursed loan")
+}
+
+func TestLoanStatusSuite(t *testing.T) {
+	suite.Run(t, new(LoanStatusSuite))
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// LoanWriteOff records the balance charged off when a disbursed loan is
+// written off. Later payments on the loan are recoveries against it.
+// LossAmount is the principal charged to loan loss; ForgoneIncome is the
+// interest, fees and penalties still owed, which were never booked as income
+// and so are dropped without a loss.
+type LoanWriteOff struct {
+	gorm.Model
+	LoanID        uint        `gorm:"not null;uniqueIndex" json:"loan_id"`
+	LossAmount    money.Money `gorm:"not null" json:"loss_amount"`
+	ForgoneIncome money.Money `gorm:"not null;default:0" json:"forgone_income"`
+	Reason        string      `gorm:"not null" json:"reason"`
+}
+
+// PortfolioSummary splits the loan book into the balance still being
+// collected on disbursed loans and the balance charged off, less what has
+// since been recovered.
+type PortfolioSummary struct {
+	ActiveLoans       int64
+	ActiveOutstanding money.Money
+	WrittenOffLoans   int64
+	WrittenOffAmount  money.Money
+	RecoveredAmount   money.Money
+}
+
+// NetChargeOff is the part of the written-off balance not recovered.
+func (s PortfolioSummary) NetChargeOff() money.Money {
+	return s.WrittenOffAmount - s.RecoveredAmount
+}
@@ -0,0 +1,115 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/greekrode/loan-engine-amartha/domain"
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HolidayCalendarRepository is an autogenerated mock type for the HolidayCalendarRepository type
+type HolidayCalendarRepository struct {
+	mock.Mock
+}
+
+// CreateHoliday provides a mock function with given fields: ctx, holiday, tx
+func (_m *HolidayCalendarRepository) CreateHoliday(ctx context.Context, holiday *domain.Holiday, tx *gorm.DB) error {
+	ret := _m.Called(ctx, holiday, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHoliday")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Holiday, *gorm.DB) error); ok {
+		r0 = rf(ctx, holiday, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindCalendarByName provides a mock function with given fields: ctx, name
+func (_m *HolidayCalendarRepository) FindCalendarByName(ctx context.Context, name string) (*domain.HolidayCalendar, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCalendarByName")
+	}
+
+	var r0 *domain.HolidayCalendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.HolidayCalendar, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.HolidayCalendar); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.HolidayCalendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceHolidays provides a mock function with given fields: ctx, calendarID, holidays, tx
+func (_m *HolidayCalendarRepository) ReplaceHolidays(ctx context.Context, calendarID uint, holidays []domain.Holiday, tx *gorm.DB) error {
+	ret := _m.Called(ctx, calendarID, holidays, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceHolidays")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []domain.Holiday, *gorm.DB) error); ok {
+		r0 = rf(ctx, calendarID, holidays, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCalendar provides a mock function with given fields: ctx, calendar, tx
+func (_m *HolidayCalendarRepository) SaveCalendar(ctx context.Context, calendar *domain.HolidayCalendar, tx *gorm.DB) error {
+	ret := _m.Called(ctx, calendar, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCalendar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.HolidayCalendar, *gorm.DB) error); ok {
+		r0 = rf(ctx, calendar, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHolidayCalendarRepository creates a new instance of HolidayCalendarRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHolidayCalendarRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HolidayCalendarRepository {
+	mock := &HolidayCalendarRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
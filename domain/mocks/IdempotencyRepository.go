@@ -0,0 +1,125 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/greekrode/loan-engine-amartha/domain"
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// CreateIdempotencyKey provides a mock function with given fields: ctx, key, tx
+func (_m *IdempotencyRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, tx *gorm.DB) (bool, error) {
+	ret := _m.Called(ctx, key, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotencyKey")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyKey, *gorm.DB) (bool, error)); ok {
+		return rf(ctx, key, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyKey, *gorm.DB) bool); ok {
+		r0 = rf(ctx, key, tx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.IdempotencyKey, *gorm.DB) error); ok {
+		r1 = rf(ctx, key, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, key, tx
+func (_m *IdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, key string, tx *gorm.DB) error {
+	ret := _m.Called(ctx, key, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *gorm.DB) error); ok {
+		r0 = rf(ctx, key, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepository) FindIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdempotencyKey")
+	}
+
+	var r0 *domain.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateIdempotencyKey provides a mock function with given fields: ctx, key, tx
+func (_m *IdempotencyRepository) UpdateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, tx *gorm.DB) error {
+	ret := _m.Called(ctx, key, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyKey, *gorm.DB) error); ok {
+		r0 = rf(ctx, key, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
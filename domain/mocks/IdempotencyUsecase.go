@@ -0,0 +1,95 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/greekrode/loan-engine-amartha/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyUsecase is an autogenerated mock type for the IdempotencyUsecase type
+type IdempotencyUsecase struct {
+	mock.Mock
+}
+
+// BeginRequest provides a mock function with given fields: ctx, key, fingerprint
+func (_m *IdempotencyUsecase) BeginRequest(ctx context.Context, key string, fingerprint string) (*domain.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginRequest")
+	}
+
+	var r0 *domain.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.IdempotencyKey, error)); ok {
+		return rf(ctx, key, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.IdempotencyKey); ok {
+		r0 = rf(ctx, key, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteRequest provides a mock function with given fields: ctx, key, statusCode, responseBody
+func (_m *IdempotencyUsecase) CompleteRequest(ctx context.Context, key string, statusCode int, responseBody []byte) error {
+	ret := _m.Called(ctx, key, statusCode, responseBody)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, responseBody)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseKey provides a mock function with given fields: ctx, key
+func (_m *IdempotencyUsecase) ReleaseKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotencyUsecase creates a new instance of IdempotencyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyUsecase {
+	mock := &IdempotencyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -9,6 +9,8 @@ import (
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // LoanRepository is an autogenerated mock type for the LoanRepository type
@@ -16,6 +18,24 @@ type LoanRepository struct {
 	mock.Mock
 }
 
+// CreateDeferral provides a mock function with given fields: ctx, deferral, tx
+func (_m *LoanRepository) CreateDeferral(ctx context.Context, deferral *domain.LoanDeferral, tx *gorm.DB) error {
+	ret := _m.Called(ctx, deferral, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeferral")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanDeferral, *gorm.DB) error); ok {
+		r0 = rf(ctx, deferral, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateLoan provides a mock function with given fields: ctx, loan, tx
 func (_m *LoanRepository) CreateLoan(ctx context.Context, loan *domain.Loan, tx *gorm.DB) error {
 	ret := _m.Called(ctx, loan, tx)
@@ -34,6 +54,132 @@ func (_m *LoanRepository) CreateLoan(ctx context.Context, loan *domain.Loan, tx
 	return r0
 }
 
+// CreateLoanApproval provides a mock function with given fields: ctx, approval, tx
+func (_m *LoanRepository) CreateLoanApproval(ctx context.Context, approval *domain.LoanApproval, tx *gorm.DB) error {
+	ret := _m.Called(ctx, approval, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoanApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanApproval, *gorm.DB) error); ok {
+		r0 = rf(ctx, approval, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLoanFees provides a mock function with given fields: ctx, fees, tx
+func (_m *LoanRepository) CreateLoanFees(ctx context.Context, fees []domain.LoanFee, tx *gorm.DB) error {
+	ret := _m.Called(ctx, fees, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoanFees")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.LoanFee, *gorm.DB) error); ok {
+		r0 = rf(ctx, fees, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefinance provides a mock function with given fields: ctx, refinance, tx
+func (_m *LoanRepository) CreateRefinance(ctx context.Context, refinance *domain.LoanRefinance, tx *gorm.DB) error {
+	ret := _m.Called(ctx, refinance, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefinance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanRefinance, *gorm.DB) error); ok {
+		r0 = rf(ctx, refinance, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRestructure provides a mock function with given fields: ctx, restructure, tx
+func (_m *LoanRepository) CreateRestructure(ctx context.Context, restructure *domain.LoanRestructure, tx *gorm.DB) error {
+	ret := _m.Called(ctx, restructure, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRestructure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanRestructure, *gorm.DB) error); ok {
+		r0 = rf(ctx, restructure, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateStatusChange provides a mock function with given fields: ctx, change, tx
+func (_m *LoanRepository) CreateStatusChange(ctx context.Context, change *domain.LoanStatusChange, tx *gorm.DB) error {
+	ret := _m.Called(ctx, change, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStatusChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanStatusChange, *gorm.DB) error); ok {
+		r0 = rf(ctx, change, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWriteOff provides a mock function with given fields: ctx, writeOff, tx
+func (_m *LoanRepository) CreateWriteOff(ctx context.Context, writeOff *domain.LoanWriteOff, tx *gorm.DB) error {
+	ret := _m.Called(ctx, writeOff, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWriteOff")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanWriteOff, *gorm.DB) error); ok {
+		r0 = rf(ctx, writeOff, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLoanFees provides a mock function with given fields: ctx, loanID, tx
+func (_m *LoanRepository) DeleteLoanFees(ctx context.Context, loanID uint, tx *gorm.DB) error {
+	ret := _m.Called(ctx, loanID, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLoanFees")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *gorm.DB) error); ok {
+		r0 = rf(ctx, loanID, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindLoanByID provides a mock function with given fields: ctx, loanID
 func (_m *LoanRepository) FindLoanByID(ctx context.Context, loanID uint) (*domain.Loan, error) {
 	ret := _m.Called(ctx, loanID)
@@ -64,6 +210,66 @@ func (_m *LoanRepository) FindLoanByID(ctx context.Context, loanID uint) (*domai
 	return r0, r1
 }
 
+// GetActiveLoansByBorrowerIDs provides a mock function with given fields: ctx, borrowerIDs
+func (_m *LoanRepository) GetActiveLoansByBorrowerIDs(ctx context.Context, borrowerIDs []uint) ([]domain.Loan, error) {
+	ret := _m.Called(ctx, borrowerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveLoansByBorrowerIDs")
+	}
+
+	var r0 []domain.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]domain.Loan, error)); ok {
+		return rf(ctx, borrowerIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []domain.Loan); ok {
+		r0 = rf(ctx, borrowerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, borrowerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDisbursedLoans provides a mock function with given fields: ctx
+func (_m *LoanRepository) GetDisbursedLoans(ctx context.Context) ([]domain.Loan, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisbursedLoans")
+	}
+
+	var r0 []domain.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Loan, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Loan); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoansByBorrowerID provides a mock function with given fields: ctx, borrowerID
 func (_m *LoanRepository) GetLoansByBorrowerID(ctx context.Context, borrowerID uint) ([]domain.Loan, error) {
 	ret := _m.Called(ctx, borrowerID)
@@ -94,6 +300,66 @@ func (_m *LoanRepository) GetLoansByBorrowerID(ctx context.Context, borrowerID u
 	return r0, r1
 }
 
+// GetOverdueLoans provides a mock function with given fields: ctx, date
+func (_m *LoanRepository) GetOverdueLoans(ctx context.Context, date time.Time) ([]domain.Loan, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueLoans")
+	}
+
+	var r0 []domain.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Loan, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Loan); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPortfolioSummary provides a mock function with given fields: ctx
+func (_m *LoanRepository) GetPortfolioSummary(ctx context.Context) (*domain.PortfolioSummary, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPortfolioSummary")
+	}
+
+	var r0 *domain.PortfolioSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.PortfolioSummary, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.PortfolioSummary); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PortfolioSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLoan provides a mock function with given fields: ctx, loan, tx
 func (_m *LoanRepository) UpdateLoan(ctx context.Context, loan *domain.Loan, tx *gorm.DB) error {
 	ret := _m.Called(ctx, loan, tx)
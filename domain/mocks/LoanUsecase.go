@@ -5,11 +5,12 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/greekrode/loan-engine-amartha/domain"
 	dto "github.com/greekrode/loan-engine-amartha/domain/dto"
 
 	mock "github.com/stretchr/testify/mock"
 
-	time "time"
+	money "github.com/greekrode/loan-engine-amartha/domain/money"
 )
 
 // LoanUsecase is an autogenerated mock type for the LoanUsecase type
@@ -17,9 +18,99 @@ type LoanUsecase struct {
 	mock.Mock
 }
 
-// CreateLoan provides a mock function with given fields: ctx, borrowerID, principal, interestRate, durationWeeks, startDate
-func (_m *LoanUsecase) CreateLoan(ctx context.Context, borrowerID uint, principal float64, interestRate float64, durationWeeks int32, startDate time.Time) (*dto.CreateLoanResponse, error) {
-	ret := _m.Called(ctx, borrowerID, principal, interestRate, durationWeeks, startDate)
+// ApproveLoan provides a mock function with given fields: ctx, loanID, approval
+func (_m *LoanUsecase) ApproveLoan(ctx context.Context, loanID uint, approval domain.LoanApproval) (*dto.GetLoanApprovalResponse, error) {
+	ret := _m.Called(ctx, loanID, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveLoan")
+	}
+
+	var r0 *dto.GetLoanApprovalResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanApproval) (*dto.GetLoanApprovalResponse, error)); ok {
+		return rf(ctx, loanID, approval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanApproval) *dto.GetLoanApprovalResponse); ok {
+		r0 = rf(ctx, loanID, approval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.GetLoanApprovalResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.LoanApproval) error); ok {
+		r1 = rf(ctx, loanID, approval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelLoan provides a mock function with given fields: ctx, loanID
+func (_m *LoanUsecase) CancelLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelLoan")
+	}
+
+	var r0 *dto.LoanStatusChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dto.LoanStatusChangeResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dto.LoanStatusChangeResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.LoanStatusChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseLoan provides a mock function with given fields: ctx, loanID
+func (_m *LoanUsecase) CloseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseLoan")
+	}
+
+	var r0 *dto.LoanStatusChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dto.LoanStatusChangeResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dto.LoanStatusChangeResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.LoanStatusChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoan provides a mock function with given fields: ctx, borrowerID, terms
+func (_m *LoanUsecase) CreateLoan(ctx context.Context, borrowerID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
+	ret := _m.Called(ctx, borrowerID, terms)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateLoan")
@@ -27,19 +118,109 @@ func (_m *LoanUsecase) CreateLoan(ctx context.Context, borrowerID uint, principa
 
 	var r0 *dto.CreateLoanResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, float64, float64, int32, time.Time) (*dto.CreateLoanResponse, error)); ok {
-		return rf(ctx, borrowerID, principal, interestRate, durationWeeks, startDate)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) (*dto.CreateLoanResponse, error)); ok {
+		return rf(ctx, borrowerID, terms)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, float64, float64, int32, time.Time) *dto.CreateLoanResponse); ok {
-		r0 = rf(ctx, borrowerID, principal, interestRate, durationWeeks, startDate)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) *dto.CreateLoanResponse); ok {
+		r0 = rf(ctx, borrowerID, terms)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dto.CreateLoanResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, float64, float64, int32, time.Time) error); ok {
-		r1 = rf(ctx, borrowerID, principal, interestRate, durationWeeks, startDate)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.LoanTerms) error); ok {
+		r1 = rf(ctx, borrowerID, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeferInstallments provides a mock function with given fields: ctx, loanID, deferral
+func (_m *LoanUsecase) DeferInstallments(ctx context.Context, loanID uint, deferral domain.LoanDeferral) (*dto.DeferInstallmentsResponse, error) {
+	ret := _m.Called(ctx, loanID, deferral)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeferInstallments")
+	}
+
+	var r0 *dto.DeferInstallmentsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanDeferral) (*dto.DeferInstallmentsResponse, error)); ok {
+		return rf(ctx, loanID, deferral)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanDeferral) *dto.DeferInstallmentsResponse); ok {
+		r0 = rf(ctx, loanID, deferral)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.DeferInstallmentsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.LoanDeferral) error); ok {
+		r1 = rf(ctx, loanID, deferral)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisburseLoan provides a mock function with given fields: ctx, loanID
+func (_m *LoanUsecase) DisburseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisburseLoan")
+	}
+
+	var r0 *dto.LoanStatusChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dto.LoanStatusChangeResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dto.LoanStatusChangeResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.LoanStatusChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanApproval provides a mock function with given fields: ctx, loanID
+func (_m *LoanUsecase) GetLoanApproval(ctx context.Context, loanID uint) (*dto.GetLoanApprovalResponse, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanApproval")
+	}
+
+	var r0 *dto.GetLoanApprovalResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dto.GetLoanApprovalResponse, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dto.GetLoanApprovalResponse); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.GetLoanApprovalResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, loanID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,22 +259,22 @@ func (_m *LoanUsecase) GetLoanDetails(ctx context.Context, loanID uint) (*dto.Ge
 }
 
 // GetOutstandingAmount provides a mock function with given fields: ctx, loanID
-func (_m *LoanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (float64, error) {
+func (_m *LoanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (money.Money, error) {
 	ret := _m.Called(ctx, loanID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOutstandingAmount")
 	}
 
-	var r0 float64
+	var r0 money.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) (float64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (money.Money, error)); ok {
 		return rf(ctx, loanID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) float64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uint) money.Money); ok {
 		r0 = rf(ctx, loanID)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(money.Money)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
@@ -105,6 +286,186 @@ func (_m *LoanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (f
 	return r0, r1
 }
 
+// GetPortfolio provides a mock function with given fields: ctx
+func (_m *LoanUsecase) GetPortfolio(ctx context.Context) (*dto.PortfolioResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPortfolio")
+	}
+
+	var r0 *dto.PortfolioResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*dto.PortfolioResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *dto.PortfolioResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.PortfolioResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuoteLoan provides a mock function with given fields: ctx, terms
+func (_m *LoanUsecase) QuoteLoan(ctx context.Context, terms domain.LoanTerms) (*dto.QuoteLoanResponse, error) {
+	ret := _m.Called(ctx, terms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuoteLoan")
+	}
+
+	var r0 *dto.QuoteLoanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoanTerms) (*dto.QuoteLoanResponse, error)); ok {
+		return rf(ctx, terms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoanTerms) *dto.QuoteLoanResponse); ok {
+		r0 = rf(ctx, terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.QuoteLoanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LoanTerms) error); ok {
+		r1 = rf(ctx, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefinanceLoan provides a mock function with given fields: ctx, loanID, terms
+func (_m *LoanUsecase) RefinanceLoan(ctx context.Context, loanID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
+	ret := _m.Called(ctx, loanID, terms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefinanceLoan")
+	}
+
+	var r0 *dto.CreateLoanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) (*dto.CreateLoanResponse, error)); ok {
+		return rf(ctx, loanID, terms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) *dto.CreateLoanResponse); ok {
+		r0 = rf(ctx, loanID, terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CreateLoanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.LoanTerms) error); ok {
+		r1 = rf(ctx, loanID, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestructureLoan provides a mock function with given fields: ctx, loanID, terms
+func (_m *LoanUsecase) RestructureLoan(ctx context.Context, loanID uint, terms domain.RestructureTerms) (*dto.RestructureLoanResponse, error) {
+	ret := _m.Called(ctx, loanID, terms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestructureLoan")
+	}
+
+	var r0 *dto.RestructureLoanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.RestructureTerms) (*dto.RestructureLoanResponse, error)); ok {
+		return rf(ctx, loanID, terms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.RestructureTerms) *dto.RestructureLoanResponse); ok {
+		r0 = rf(ctx, loanID, terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.RestructureLoanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.RestructureTerms) error); ok {
+		r1 = rf(ctx, loanID, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLoan provides a mock function with given fields: ctx, loanID, terms
+func (_m *LoanUsecase) UpdateLoan(ctx context.Context, loanID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
+	ret := _m.Called(ctx, loanID, terms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoan")
+	}
+
+	var r0 *dto.CreateLoanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) (*dto.CreateLoanResponse, error)); ok {
+		return rf(ctx, loanID, terms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.LoanTerms) *dto.CreateLoanResponse); ok {
+		r0 = rf(ctx, loanID, terms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CreateLoanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.LoanTerms) error); ok {
+		r1 = rf(ctx, loanID, terms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WriteOffLoan provides a mock function with given fields: ctx, loanID, reason
+func (_m *LoanUsecase) WriteOffLoan(ctx context.Context, loanID uint, reason string) (*dto.WriteOffLoanResponse, error) {
+	ret := _m.Called(ctx, loanID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteOffLoan")
+	}
+
+	var r0 *dto.WriteOffLoanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*dto.WriteOffLoanResponse, error)); ok {
+		return rf(ctx, loanID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *dto.WriteOffLoanResponse); ok {
+		r0 = rf(ctx, loanID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WriteOffLoanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, loanID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLoanUsecase creates a new instance of LoanUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLoanUsecase(t interface {
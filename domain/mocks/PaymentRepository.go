@@ -34,6 +34,108 @@ func (_m *PaymentRepository) CreatePayment(ctx context.Context, payment *domain.
 	return r0
 }
 
+// CreatePaymentAllocations provides a mock function with given fields: ctx, allocations, tx
+func (_m *PaymentRepository) CreatePaymentAllocations(ctx context.Context, allocations []domain.PaymentAllocation, tx *gorm.DB) error {
+	ret := _m.Called(ctx, allocations, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentAllocations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.PaymentAllocation, *gorm.DB) error); ok {
+		r0 = rf(ctx, allocations, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefund provides a mock function with given fields: ctx, refund, tx
+func (_m *PaymentRepository) CreateRefund(ctx context.Context, refund *domain.Refund, tx *gorm.DB) error {
+	ret := _m.Called(ctx, refund, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Refund, *gorm.DB) error); ok {
+		r0 = rf(ctx, refund, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateReversal provides a mock function with given fields: ctx, reversal, tx
+func (_m *PaymentRepository) CreateReversal(ctx context.Context, reversal *domain.PaymentReversal, tx *gorm.DB) error {
+	ret := _m.Called(ctx, reversal, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReversal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PaymentReversal, *gorm.DB) error); ok {
+		r0 = rf(ctx, reversal, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSettlement provides a mock function with given fields: ctx, settlement, tx
+func (_m *PaymentRepository) CreateSettlement(ctx context.Context, settlement *domain.GroupSettlement, tx *gorm.DB) error {
+	ret := _m.Called(ctx, settlement, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSettlement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GroupSettlement, *gorm.DB) error); ok {
+		r0 = rf(ctx, settlement, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindPaymentByID provides a mock function with given fields: ctx, paymentID
+func (_m *PaymentRepository) FindPaymentByID(ctx context.Context, paymentID uint) (*domain.Payment, error) {
+	ret := _m.Called(ctx, paymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPaymentByID")
+	}
+
+	var r0 *domain.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*domain.Payment, error)); ok {
+		return rf(ctx, paymentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *domain.Payment); ok {
+		r0 = rf(ctx, paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPaymentRepository(t interface {
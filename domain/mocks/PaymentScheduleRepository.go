@@ -72,6 +72,24 @@ func (_m *PaymentScheduleRepository) CreatePaymentSchedule(ctx context.Context,
 	return r0
 }
 
+// DeletePaymentSchedulesByLoanID provides a mock function with given fields: ctx, loanID, tx
+func (_m *PaymentScheduleRepository) DeletePaymentSchedulesByLoanID(ctx context.Context, loanID uint, tx *gorm.DB) error {
+	ret := _m.Called(ctx, loanID, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentSchedulesByLoanID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *gorm.DB) error); ok {
+		r0 = rf(ctx, loanID, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPaymentSchedulesByLoanID provides a mock function with given fields: ctx, loanID
 func (_m *PaymentScheduleRepository) GetPaymentSchedulesByLoanID(ctx context.Context, loanID uint) ([]domain.PaymentSchedule, error) {
 	ret := _m.Called(ctx, loanID)
@@ -132,6 +150,24 @@ func (_m *PaymentScheduleRepository) GetUnpaidPaymentSchedulesByLoanID(ctx conte
 	return r0, r1
 }
 
+// SupersedePaymentSchedules provides a mock function with given fields: ctx, paymentSchedulesID, restructureID, tx
+func (_m *PaymentScheduleRepository) SupersedePaymentSchedules(ctx context.Context, paymentSchedulesID []uint, restructureID uint, tx *gorm.DB) error {
+	ret := _m.Called(ctx, paymentSchedulesID, restructureID, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupersedePaymentSchedules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint, uint, *gorm.DB) error); ok {
+		r0 = rf(ctx, paymentSchedulesID, restructureID, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePaymentSchedule provides a mock function with given fields: ctx, bs, tx
 func (_m *PaymentScheduleRepository) UpdatePaymentSchedule(ctx context.Context, bs *domain.PaymentSchedule, tx *gorm.DB) error {
 	ret := _m.Called(ctx, bs, tx)
@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/greekrode/loan-engine-amartha/domain/money"
 )
 
 // PaymentScheduleUsecase is an autogenerated mock type for the PaymentScheduleUsecase type
@@ -14,7 +16,7 @@ type PaymentScheduleUsecase struct {
 }
 
 // MakePayment provides a mock function with given fields: ctx, loanID, amount
-func (_m *PaymentScheduleUsecase) MakePayment(ctx context.Context, loanID uint, amount float64) error {
+func (_m *PaymentScheduleUsecase) MakePayment(ctx context.Context, loanID uint, amount money.Money) error {
 	ret := _m.Called(ctx, loanID, amount)
 
 	if len(ret) == 0 {
@@ -22,7 +24,7 @@ func (_m *PaymentScheduleUsecase) MakePayment(ctx context.Context, loanID uint,
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, float64) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money) error); ok {
 		r0 = rf(ctx, loanID, amount)
 	} else {
 		r0 = ret.Error(0)
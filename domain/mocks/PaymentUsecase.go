@@ -8,6 +8,10 @@ import (
 	dto "github.com/greekrode/loan-engine-amartha/domain/dto"
 
 	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/greekrode/loan-engine-amartha/domain/money"
+
+	time "time"
 )
 
 // PaymentUsecase is an autogenerated mock type for the PaymentUsecase type
@@ -15,22 +19,124 @@ type PaymentUsecase struct {
 	mock.Mock
 }
 
-// MakePayment provides a mock function with given fields: ctx, loanID, paymentSchedulesID, amount
-func (_m *PaymentUsecase) MakePayment(ctx context.Context, loanID uint, paymentSchedulesID []uint, amount float64) error {
-	ret := _m.Called(ctx, loanID, paymentSchedulesID, amount)
+// MakePayment provides a mock function with given fields: ctx, loanID, amount
+func (_m *PaymentUsecase) MakePayment(ctx context.Context, loanID uint, amount money.Money) (*dto.MakePaymentResponse, error) {
+	ret := _m.Called(ctx, loanID, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MakePayment")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []uint, float64) error); ok {
-		r0 = rf(ctx, loanID, paymentSchedulesID, amount)
+	var r0 *dto.MakePaymentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money) (*dto.MakePaymentResponse, error)); ok {
+		return rf(ctx, loanID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money) *dto.MakePaymentResponse); ok {
+		r0 = rf(ctx, loanID, amount)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.MakePaymentResponse)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uint, money.Money) error); ok {
+		r1 = rf(ctx, loanID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PayOffLoan provides a mock function with given fields: ctx, loanID, amount
+func (_m *PaymentUsecase) PayOffLoan(ctx context.Context, loanID uint, amount money.Money) (*dto.PayoffQuoteResponse, error) {
+	ret := _m.Called(ctx, loanID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PayOffLoan")
+	}
+
+	var r0 *dto.PayoffQuoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money) (*dto.PayoffQuoteResponse, error)); ok {
+		return rf(ctx, loanID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money) *dto.PayoffQuoteResponse); ok {
+		r0 = rf(ctx, loanID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.PayoffQuoteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, money.Money) error); ok {
+		r1 = rf(ctx, loanID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuotePayoff provides a mock function with given fields: ctx, loanID, date
+func (_m *PaymentUsecase) QuotePayoff(ctx context.Context, loanID uint, date time.Time) (*dto.PayoffQuoteResponse, error) {
+	ret := _m.Called(ctx, loanID, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuotePayoff")
+	}
+
+	var r0 *dto.PayoffQuoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) (*dto.PayoffQuoteResponse, error)); ok {
+		return rf(ctx, loanID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) *dto.PayoffQuoteResponse); ok {
+		r0 = rf(ctx, loanID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.PayoffQuoteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time) error); ok {
+		r1 = rf(ctx, loanID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundCredit provides a mock function with given fields: ctx, loanID, amount, reason
+func (_m *PaymentUsecase) RefundCredit(ctx context.Context, loanID uint, amount money.Money, reason string) (*dto.RefundResponse, error) {
+	ret := _m.Called(ctx, loanID, amount, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundCredit")
+	}
+
+	var r0 *dto.RefundResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money, string) (*dto.RefundResponse, error)); ok {
+		return rf(ctx, loanID, amount, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, money.Money, string) *dto.RefundResponse); ok {
+		r0 = rf(ctx, loanID, amount, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.RefundResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, money.Money, string) error); ok {
+		r1 = rf(ctx, loanID, amount, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RequestPayment provides a mock function with given fields: ctx, loanID
@@ -63,6 +169,66 @@ func (_m *PaymentUsecase) RequestPayment(ctx context.Context, loanID uint) (*dto
 	return r0, r1
 }
 
+// ReversePayment provides a mock function with given fields: ctx, paymentID, reason
+func (_m *PaymentUsecase) ReversePayment(ctx context.Context, paymentID uint, reason string) (*dto.PaymentReversalResponse, error) {
+	ret := _m.Called(ctx, paymentID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReversePayment")
+	}
+
+	var r0 *dto.PaymentReversalResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*dto.PaymentReversalResponse, error)); ok {
+		return rf(ctx, paymentID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *dto.PaymentReversalResponse); ok {
+		r0 = rf(ctx, paymentID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.PaymentReversalResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, paymentID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettleGroupArrears provides a mock function with given fields: ctx, groupID, payerID, amount
+func (_m *PaymentUsecase) SettleGroupArrears(ctx context.Context, groupID uint, payerID uint, amount money.Money) (*dto.GroupSettlementResponse, error) {
+	ret := _m.Called(ctx, groupID, payerID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleGroupArrears")
+	}
+
+	var r0 *dto.GroupSettlementResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, money.Money) (*dto.GroupSettlementResponse, error)); ok {
+		return rf(ctx, groupID, payerID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, money.Money) *dto.GroupSettlementResponse); ok {
+		r0 = rf(ctx, groupID, payerID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.GroupSettlementResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, money.Money) error); ok {
+		r1 = rf(ctx, groupID, payerID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPaymentUsecase creates a new instance of PaymentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPaymentUsecase(t interface {
@@ -0,0 +1,62 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/greekrode/loan-engine-amartha/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PenaltyUsecase is an autogenerated mock type for the PenaltyUsecase type
+type PenaltyUsecase struct {
+	mock.Mock
+}
+
+// AccruePenalties provides a mock function with given fields: ctx, date
+func (_m *PenaltyUsecase) AccruePenalties(ctx context.Context, date time.Time) (*dto.AccruePenaltiesResponse, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccruePenalties")
+	}
+
+	var r0 *dto.AccruePenaltiesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*dto.AccruePenaltiesResponse, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *dto.AccruePenaltiesResponse); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AccruePenaltiesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPenaltyUsecase creates a new instance of PenaltyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPenaltyUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PenaltyUsecase {
+	mock := &PenaltyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
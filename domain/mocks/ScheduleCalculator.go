@@ -0,0 +1,49 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/greekrode/loan-engine-amartha/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// ScheduleCalculator is an autogenerated mock type for the ScheduleCalculator type
+type ScheduleCalculator struct {
+	mock.Mock
+}
+
+// Calculate provides a mock function with given fields: principal, periodicRate, periods
+func (_m *ScheduleCalculator) Calculate(principal money.Money, periodicRate float64, periods int) []domain.Installment {
+	ret := _m.Called(principal, periodicRate, periods)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Calculate")
+	}
+
+	var r0 []domain.Installment
+	if rf, ok := ret.Get(0).(func(money.Money, float64, int) []domain.Installment); ok {
+		r0 = rf(principal, periodicRate, periods)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Installment)
+		}
+	}
+
+	return r0
+}
+
+// NewScheduleCalculator creates a new instance of ScheduleCalculator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleCalculator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleCalculator {
+	mock := &ScheduleCalculator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
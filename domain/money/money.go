@@ -0,0 +1,152 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of currency held in minor units (1/100 of the unit), so
+// sums and comparisons are exact.
+type Money int64
+
+const (
+	minorDigits = 2
+	minorUnits  = 100
+)
+
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+// FromFloat converts a unit amount to Money, rounding half away from zero.
+func FromFloat(f float64) Money {
+	return Money(math.Round(f * minorUnits))
+}
+
+// Parse reads a decimal string such as "1250", "1250.5" or "-0.25". Amounts with
+// more precision than a minor unit are rejected instead of being rounded.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if whole == "" || len(fraction) > minorDigits {
+		return 0, ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", minorDigits-len(fraction))
+
+	units, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	minor, err := strconv.ParseUint(fraction, 10, 63)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	if units > math.MaxInt64/minorUnits {
+		return 0, ErrInvalidAmount
+	}
+
+	m := Money(units*minorUnits + minor)
+	if negative {
+		m = -m
+	}
+
+	return m, nil
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / minorUnits
+}
+
+func (m Money) String() string {
+	sign := ""
+	abs := int64(m)
+	if abs < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, abs/minorUnits, abs%minorUnits)
+}
+
+// MulRate multiplies the amount by a rate such as a periodic interest rate,
+// rounding the result half away from zero to the nearest minor unit.
+func (m Money) MulRate(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+// Split divides the amount into n parts that add back up to the original
+// amount exactly. Whatever cannot be divided evenly goes to the last part.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parts := make([]Money, n)
+	share := m / Money(n)
+	for i := range parts {
+		parts[i] = share
+	}
+	parts[n-1] += m - share*Money(n)
+
+	return parts
+}
+
+// Allocate divides the amount in proportion to the given weights so that the
+// parts add back up to the original amount exactly. Whatever is lost to
+// rounding down goes to the last part.
+func (m Money) Allocate(weights []Money) []Money {
+	if len(weights) == 0 {
+		return nil
+	}
+
+	parts := make([]Money, len(weights))
+	total := Sum(weights...)
+	if total == 0 {
+		return parts
+	}
+
+	var allocated Money
+	for i, weight := range weights[:len(weights)-1] {
+		share := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(weight)))
+		share.Quo(share, big.NewInt(int64(total)))
+		parts[i] = Money(share.Int64())
+		allocated += parts[i]
+	}
+	parts[len(parts)-1] = m - allocated
+
+	return parts
+}
+
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and quoted decimal strings.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
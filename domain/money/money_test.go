@@ -0,0 +1,91 @@
+package money_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type MoneySuite struct {
+	suite.Suite
+}
+
+func (s *MoneySuite) TestParse() {
+	tests := []struct {
+		name     string
+		input    string
+		expected money.Money
+		wantErr  bool
+	}{
+		{name: "Whole Amount", input: "1250", expected: money.Money(125000)},
+		{name: "Single Decimal", input: "1250.5", expected: money.Money(125050)},
+		{name: "Two Decimals", input: "0.07", expected: money.Money(7)},
+		{name: "Trailing Zeros", input: "10.500", expected: money.Money(1050)},
+		{name: "Negative", input: "-0.25", expected: money.Money(-25)},
+		{name: "Too Precise", input: "0.001", wantErr: true},
+		{name: "Exponent", input: "1e3", wantErr: true},
+		{name: "Empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			result, err := money.Parse(tt.input)
+			if tt.wantErr {
+				s.ErrorIs(err, money.ErrInvalidAmount)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *MoneySuite) TestString() {
+	s.Equal("1001.92", money.Money(100192).String())
+	s.Equal("0.05", money.Money(5).String())
+	s.Equal("-3.10", money.Money(-310).String())
+}
+
+func (s *MoneySuite) TestMulRate() {
+	s.Equal(money.Money(192), money.FromFloat(1000).MulRate(0.05/52*2))
+	s.Equal(money.Money(1), money.Money(1).MulRate(0.5))
+}
+
+func (s *MoneySuite) TestSplit() {
+	parts := money.FromFloat(100).Split(3)
+	s.Equal([]money.Money{3333, 3333, 3334}, parts)
+	s.Equal(money.FromFloat(100), money.Sum(parts...))
+	s.Nil(money.FromFloat(100).Split(0))
+}
+
+func (s *MoneySuite) TestAllocate() {
+	parts := money.FromFloat(100).Allocate([]money.Money{money.FromFloat(600), money.FromFloat(400)})
+	s.Equal([]money.Money{money.FromFloat(60), money.FromFloat(40)}, parts)
+	s.Equal([]money.Money{33, 33, 34}, money.Money(100).Allocate([]money.Money{1, 1, 1}))
+	s.Equal([]money.Money{0, 0}, money.Money(100).Allocate([]money.Money{0, 0}))
+	s.Nil(money.Money(100).Allocate(nil))
+}
+
+func (s *MoneySuite) TestJSON() {
+	var payload struct {
+		Amount money.Money `json:"amount"`
+	}
+
+	s.Require().NoError(json.Unmarshal([]byte(`{"amount": 0.3}`), &payload))
+	s.Equal(money.Money(30), payload.Amount)
+
+	s.Require().NoError(json.Unmarshal([]byte(`{"amount": "12.34"}`), &payload))
+	s.Equal(money.Money(1234), payload.Amount)
+
+	s.Error(json.Unmarshal([]byte(`{"amount": 0.333}`), &payload))
+
+	encoded, err := json.Marshal(payload)
+	s.Require().NoError(err)
+	s.JSONEq(`{"amount": 12.34}`, string(encoded))
+}
+
+func TestMoneySuite(t *testing.T) {
+	suite.Run(t, new(MoneySuite))
+}
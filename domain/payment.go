@@ -2,22 +2,53 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	"gorm.io/gorm"
 )
 
+// Payment is a repayment on a loan. The part of Amount applied to installments
+// is split into the principal and interest owed to investors and the loan fees
+// and penalties kept by the platform. Anything left over is credited to the
+// loan. Its PaymentAllocation rows record which installment components it
+// settled. Payments on a written-off loan are recoveries and settle no
+// installments. Payments made as part of a group settlement carry its ID. A
+// payment taken back by mistake has a Reversal.
 type Payment struct {
 	gorm.Model
-	LoanID uint    `gorm:"not null" json:"loan_id"`
-	Amount float64 `gorm:"not null" json:"amount"`
+	LoanID       uint                `gorm:"not null" json:"loan_id"`
+	Amount       money.Money         `gorm:"not null" json:"amount"`
+	Principal    money.Money         `gorm:"not null;default:0" json:"principal"`
+	Interest     money.Money         `gorm:"not null;default:0" json:"interest"`
+	FeeAmount    money.Money         `gorm:"not null;default:0" json:"fee_amount"`
+	Penalty      money.Money         `gorm:"not null;default:0" json:"penalty"`
+	Recovery     bool                `gorm:"not null;default:false" json:"recovery"`
+	SettlementID *uint               `gorm:"index" json:"settlement_id,omitempty"`
+	Allocations  []PaymentAllocation `gorm:"foreignKey:PaymentID" json:"allocations,omitempty"`
+	Reversal     *PaymentReversal    `gorm:"foreignKey:PaymentID" json:"reversal,omitempty"`
 }
 
 type PaymentUsecase interface {
 	RequestPayment(ctx context.Context, loanID uint) (*dto.RequestPaymentResponse, error)
-	MakePayment(ctx context.Context, loanID uint, paymentSchedulesID []uint, amount float64) error
+	MakePayment(ctx context.Context, loanID uint, amount money.Money) (*dto.MakePaymentResponse, error)
+
+	QuotePayoff(ctx context.Context, loanID uint, date time.Time) (*dto.PayoffQuoteResponse, error)
+	PayOffLoan(ctx context.Context, loanID uint, amount money.Money) (*dto.PayoffQuoteResponse, error)
+
+	SettleGroupArrears(ctx context.Context, groupID uint, payerID uint, amount money.Money) (*dto.GroupSettlementResponse, error)
+
+	ReversePayment(ctx context.Context, paymentID uint, reason string) (*dto.PaymentReversalResponse, error)
+	RefundCredit(ctx context.Context, loanID uint, amount money.Money, reason string) (*dto.RefundResponse, error)
 }
 
 type PaymentRepository interface {
 	CreatePayment(ctx context.Context, payment *Payment, tx *gorm.DB) error
+	CreatePaymentAllocations(ctx context.Context, allocations []PaymentAllocation, tx *gorm.DB) error
+	CreateSettlement(ctx context.Context, settlement *GroupSettlement, tx *gorm.DB) error
+	CreateReversal(ctx context.Context, reversal *PaymentReversal, tx *gorm.DB) error
+	CreateRefund(ctx context.Context, refund *Refund, tx *gorm.DB) error
+
+	FindPaymentByID(ctx context.Context, paymentID uint) (*Payment, error)
 }
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// AllocationComponent is one of the parts an installment is made of.
+type AllocationComponent string
+
+const (
+	ComponentFee       AllocationComponent = "fee"
+	ComponentPenalty   AllocationComponent = "penalty"
+	ComponentInterest  AllocationComponent = "interest"
+	ComponentPrincipal AllocationComponent = "principal"
+)
+
+func (c AllocationComponent) IsValid() bool {
+	switch c {
+	case ComponentFee, ComponentPenalty, ComponentInterest, ComponentPrincipal:
+		return true
+	}
+	return false
+}
+
+// AllocationOrder is the order in which a payment settles the components of
+// an installment. An empty order falls back to DefaultAllocationOrder.
+type AllocationOrder []AllocationComponent
+
+// DefaultAllocationOrder settles fees, then penalties, then interest and
+// principal last.
+var DefaultAllocationOrder = AllocationOrder{ComponentFee, ComponentPenalty, ComponentInterest, ComponentPrincipal}
+
+func (o AllocationOrder) OrDefault() AllocationOrder {
+	if len(o) == 0 {
+		return DefaultAllocationOrder
+	}
+	return o
+}
+
+// Validate checks that every component appears exactly once.
+func (o AllocationOrder) Validate() error {
+	if len(o) == 0 {
+		return nil
+	}
+	if len(o) != len(DefaultAllocationOrder) {
+		return fmt.Errorf("allocation order must list each of fee, penalty, interest and principal once")
+	}
+
+	seen := make(map[AllocationComponent]bool, len(o))
+	for _, component := range o {
+		if !component.IsValid() {
+			return fmt.Errorf("invalid allocation component %q", component)
+		}
+		if seen[component] {
+			return fmt.Errorf("allocation order must list each of fee, penalty, interest and principal once")
+		}
+		seen[component] = true
+	}
+	return nil
+}
+
+// PaymentAllocation records the part of a payment that settled one component
+// of an installment.
+type PaymentAllocation struct {
+	gorm.Model
+	PaymentID         uint                `gorm:"not null;index" json:"payment_id"`
+	PaymentScheduleID uint                `gorm:"not null;index" json:"payment_schedule_id"`
+	Component         AllocationComponent `gorm:"not null" json:"component"`
+	Amount            money.Money         `gorm:"not null" json:"amount"`
+}
+
+// Allocations lists the non-zero components of the allocation as rows for
+// the given installment, in the order they were settled.
+func (a ScheduleAllocation) Allocations(scheduleID uint, order AllocationOrder) []PaymentAllocation {
+	var allocations []PaymentAllocation
+	for _, component := range order.OrDefault() {
+		if amount := a.Component(component); amount > 0 {
+			allocations = append(allocations, PaymentAllocation{
+				PaymentScheduleID: scheduleID,
+				Component:         component,
+				Amount:            amount,
+			})
+		}
+	}
+	return allocations
+}
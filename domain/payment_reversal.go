@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// PaymentReversal records a payment taken back because it was keyed in by
+// mistake. The payment itself is kept; the reversal undoes what it applied.
+type PaymentReversal struct {
+	gorm.Model
+	PaymentID uint        `gorm:"not null;uniqueIndex" json:"payment_id"`
+	LoanID    uint        `gorm:"not null;index" json:"loan_id"`
+	Amount    money.Money `gorm:"not null" json:"amount"`
+	Reason    string      `gorm:"not null" json:"reason"`
+}
+
+// Refund records credit balance paid back to the borrower of a loan.
+type Refund struct {
+	gorm.Model
+	LoanID uint        `gorm:"not null;index" json:"loan_id"`
+	Amount money.Money `gorm:"not null" json:"amount"`
+	Reason string      `gorm:"not null" json:"reason"`
+}
+
+// SumAllocations totals the allocation rows of a payment per installment.
+func SumAllocations(allocations []PaymentAllocation) map[uint]ScheduleAllocation {
+	sums := make(map[uint]ScheduleAllocation)
+	for _, allocation := range allocations {
+		sum := sums[allocation.PaymentScheduleID]
+		sum.add(allocation.Component, allocation.Amount)
+		sums[allocation.PaymentScheduleID] = sum
+	}
+	return sums
+}
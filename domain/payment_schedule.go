@@ -4,19 +4,229 @@ import (
 	"context"
 	"time"
 
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	"gorm.io/gorm"
 )
 
 type PaymentSchedule struct {
 	gorm.Model
-	DueAmount float64   `gorm:"not null" json:"due_amount"`
-	DueDate   time.Time `gorm:"not null" json:"due_date"`
-	Paid      bool      `gorm:"not null;default:false" json:"paid"`
-	LoanID    uint      `gorm:"not null" json:"loan_id"`
+	DueAmount        money.Money `gorm:"not null" json:"due_amount"`
+	PrincipalAmount  money.Money `gorm:"not null;default:0" json:"principal_amount"`
+	InterestAmount   money.Money `gorm:"not null;default:0" json:"interest_amount"`
+	FeeAmount        money.Money `gorm:"not null;default:0" json:"fee_amount"`
+	PenaltyAmount    money.Money `gorm:"not null;default:0" json:"penalty_amount"`
+	PaidAmount       money.Money `gorm:"not null;default:0" json:"paid_amount"`
+	PrincipalPaid    money.Money `gorm:"not null;default:0" json:"principal_paid"`
+	InterestPaid     money.Money `gorm:"not null;default:0" json:"interest_paid"`
+	FeePaid          money.Money `gorm:"not null;default:0" json:"fee_paid"`
+	PenaltyPaid      money.Money `gorm:"not null;default:0" json:"penalty_paid"`
+	PenaltyAccruedAt *time.Time  `json:"penalty_accrued_at"`
+	DueDate          time.Time   `gorm:"not null" json:"due_date"`
+	Paid             bool        `gorm:"not null;default:false" json:"paid"`
+	PaidAt           *time.Time  `json:"paid_at"`
+	Superseded       bool        `gorm:"not null;default:false" json:"superseded"`
+	RestructureID    *uint       `gorm:"index" json:"restructure_id"`
+	Deferred         bool        `gorm:"not null;default:false" json:"deferred"`
+	DeferralID       *uint       `gorm:"index" json:"deferral_id"`
+	LoanID           uint        `gorm:"not null" json:"loan_id"`
+}
+
+type ScheduleStatus string
+
+const (
+	ScheduleUnpaid        ScheduleStatus = "unpaid"
+	SchedulePartiallyPaid ScheduleStatus = "partially_paid"
+	SchedulePaid          ScheduleStatus = "paid"
+	ScheduleDeferred      ScheduleStatus = "deferred"
+)
+
+func (ps PaymentSchedule) Status() ScheduleStatus {
+	switch {
+	case ps.Deferred:
+		return ScheduleDeferred
+	case ps.Paid:
+		return SchedulePaid
+	case ps.PaidAmount > 0:
+		return SchedulePartiallyPaid
+	}
+	return ScheduleUnpaid
+}
+
+// ScheduleAllocation is an amount split into the components of an
+// installment.
+type ScheduleAllocation struct {
+	Principal money.Money
+	Interest  money.Money
+	Fee       money.Money
+	Penalty   money.Money
+}
+
+func (a ScheduleAllocation) Total() money.Money {
+	return a.Principal + a.Interest + a.Fee + a.Penalty
+}
+
+// Component returns the part of the allocation belonging to c.
+func (a ScheduleAllocation) Component(c AllocationComponent) money.Money {
+	switch c {
+	case ComponentPrincipal:
+		return a.Principal
+	case ComponentInterest:
+		return a.Interest
+	case ComponentFee:
+		return a.Fee
+	case ComponentPenalty:
+		return a.Penalty
+	}
+	return 0
+}
+
+func (a *ScheduleAllocation) add(c AllocationComponent, amount money.Money) {
+	switch c {
+	case ComponentPrincipal:
+		a.Principal += amount
+	case ComponentInterest:
+		a.Interest += amount
+	case ComponentFee:
+		a.Fee += amount
+	case ComponentPenalty:
+		a.Penalty += amount
+	}
+}
+
+// Components returns what the installment is made of. Whatever is due beyond
+// the interest, fee and penalty is principal, which also covers installments
+// created before the components were recorded.
+func (ps PaymentSchedule) Components() ScheduleAllocation {
+	return ScheduleAllocation{
+		Principal: ps.DueAmount - ps.InterestAmount - ps.FeeAmount - ps.PenaltyAmount,
+		Interest:  ps.InterestAmount,
+		Fee:       ps.FeeAmount,
+		Penalty:   ps.PenaltyAmount,
+	}
+}
+
+// Settled returns what has been paid towards each component.
+func (ps PaymentSchedule) Settled() ScheduleAllocation {
+	return ScheduleAllocation{
+		Principal: ps.PrincipalPaid,
+		Interest:  ps.InterestPaid,
+		Fee:       ps.FeePaid,
+		Penalty:   ps.PenaltyPaid,
+	}
+}
+
+// Outstanding returns what is still owed on each component of the
+// installment.
+func (ps PaymentSchedule) Outstanding() ScheduleAllocation {
+	if ps.Paid {
+		return ScheduleAllocation{}
+	}
+
+	components, settled := ps.Components(), ps.Settled()
+	return ScheduleAllocation{
+		Principal: max(components.Principal-settled.Principal, 0),
+		Interest:  max(components.Interest-settled.Interest, 0),
+		Fee:       max(components.Fee-settled.Fee, 0),
+		Penalty:   max(components.Penalty-settled.Penalty, 0),
+	}
+}
+
+// Pay applies up to amount to the installment, settling the components in
+// the given order, and returns the part applied to each component. The
+// installment is paid once nothing is outstanding.
+func (ps *PaymentSchedule) Pay(amount money.Money, order AllocationOrder) ScheduleAllocation {
+	outstanding := ps.Outstanding()
+
+	var applied ScheduleAllocation
+	for _, component := range order.OrDefault() {
+		taken := min(amount, outstanding.Component(component))
+		applied.add(component, taken)
+		amount -= taken
+	}
+
+	ps.record(applied)
+	ps.setPaid(ps.PaidAmount >= ps.DueAmount)
+
+	return applied
+}
+
+// Settle pays off everything outstanding on the installment except the
+// waived interest, and returns the part applied to each component.
+func (ps *PaymentSchedule) Settle(waivedInterest money.Money) ScheduleAllocation {
+	applied := ps.Outstanding()
+	applied.Interest -= min(waivedInterest, applied.Interest)
+
+	ps.record(applied)
+	ps.setPaid(true)
+
+	return applied
+}
+
+// Unpay takes back what a reversed payment applied to the installment. The
+// installment is reopened unless it is still paid in full.
+func (ps *PaymentSchedule) Unpay(applied ScheduleAllocation) {
+	ps.PrincipalPaid -= applied.Principal
+	ps.InterestPaid -= applied.Interest
+	ps.FeePaid -= applied.Fee
+	ps.PenaltyPaid -= applied.Penalty
+	ps.PaidAmount -= applied.Total()
+	ps.setPaid(ps.PaidAmount >= ps.DueAmount)
+}
+
+// setPaid marks the installment paid or unpaid, keeping when it was paid.
+func (ps *PaymentSchedule) setPaid(paid bool) {
+	switch {
+	case !paid:
+		ps.PaidAt = nil
+	case !ps.Paid || ps.PaidAt == nil:
+		paidAt := time.Now().UTC()
+		ps.PaidAt = &paidAt
+	}
+	ps.Paid = paid
+}
+
+func (ps *PaymentSchedule) record(applied ScheduleAllocation) {
+	ps.PrincipalPaid += applied.Principal
+	ps.InterestPaid += applied.Interest
+	ps.FeePaid += applied.Fee
+	ps.PenaltyPaid += applied.Penalty
+	ps.PaidAmount += applied.Total()
+}
+
+// AddPenalty charges a penalty accrued up to the given date to the
+// installment.
+func (ps *PaymentSchedule) AddPenalty(amount money.Money, date time.Time) {
+	ps.PenaltyAmount += amount
+	ps.DueAmount += amount
+	ps.PenaltyAccruedAt = &date
+}
+
+// Defer drops the installment from the repayment plan and returns what it
+// owed. The installment is kept with nothing due as a record of the holiday.
+func (ps *PaymentSchedule) Defer() ScheduleAllocation {
+	deferred := ps.Outstanding()
+
+	ps.DueAmount = ps.PaidAmount
+	ps.PrincipalAmount = ps.PrincipalPaid
+	ps.InterestAmount = ps.InterestPaid
+	ps.FeeAmount = ps.FeePaid
+	ps.PenaltyAmount = ps.PenaltyPaid
+	ps.Deferred = true
+	ps.setPaid(true)
+
+	return deferred
+}
+
+// Capitalize adds a deferred amount to the installment. Deferred interest and
+// penalties become principal; fees stay fees.
+func (ps *PaymentSchedule) Capitalize(amount ScheduleAllocation) {
+	ps.PrincipalAmount += amount.Principal + amount.Interest + amount.Penalty
+	ps.FeeAmount += amount.Fee
+	ps.DueAmount += amount.Total()
 }
 
 type PaymentScheduleUsecase interface {
-	MakePayment(ctx context.Context, loanID uint, amount float64) error
+	MakePayment(ctx context.Context, loanID uint, amount money.Money) error
 }
 
 type PaymentScheduleRepository interface {
@@ -28,4 +238,7 @@ type PaymentScheduleRepository interface {
 
 	UpdatePaymentSchedule(ctx context.Context, bs *PaymentSchedule, tx *gorm.DB) error
 	BulkPayPaymentSchedules(ctx context.Context, paymentSchedulesID []uint, tx *gorm.DB) error
+	SupersedePaymentSchedules(ctx context.Context, paymentSchedulesID []uint, restructureID uint, tx *gorm.DB) error
+
+	DeletePaymentSchedulesByLoanID(ctx context.Context, loanID uint, tx *gorm.DB) error
 }
@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaymentScheduleSuite struct {
+	suite.Suite
+}
+
+func installment() domain.PaymentSchedule {
+	return domain.PaymentSchedule{
+		DueAmount:       money.FromFloat(100),
+		PrincipalAmount: money.FromFloat(75),
+		InterestAmount:  money.FromFloat(20),
+		FeeAmount:       money.FromFloat(5),
+	}
+}
+
+func (s *PaymentScheduleSuite) TestPay() {
+	schedule := installment()
+	s.Equal(domain.ScheduleUnpaid, schedule.Status())
+
+	applied := schedule.Pay(money.FromFloat(15), nil)
+	s.Equal(domain.ScheduleAllocation{Fee: money.FromFloat(5), Interest: money.FromFloat(10)}, applied)
+	s.Equal(money.FromFloat(15), schedule.PaidAmount)
+	s.Equal(domain.SchedulePartiallyPaid, schedule.Status())
+	s.Nil(schedule.PaidAt)
+
+	applied = schedule.Pay(money.FromFloat(200), nil)
+	s.Equal(domain.ScheduleAllocation{Interest: money.FromFloat(10), Principal: money.FromFloat(75)}, applied)
+	s.Equal(money.FromFloat(100), schedule.PaidAmount)
+	s.Equal(domain.SchedulePaid, schedule.Status())
+	s.NotNil(schedule.PaidAt)
+
+	s.Equal(domain.ScheduleAllocation{}, schedule.Pay(money.FromFloat(10), nil))
+}
+
+func (s *PaymentScheduleSuite) TestPayInAllocationOrder() {
+	schedule := installment()
+	schedule.DueAmount = money.FromFloat(110)
+	schedule.PenaltyAmount = money.FromFloat(10)
+	order := domain.AllocationOrder{domain.ComponentPrincipal, domain.ComponentInterest, domain.ComponentPenalty, domain.ComponentFee}
+
+	applied := schedule.Pay(money.FromFloat(100), order)
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(75), Interest: money.FromFloat(20), Penalty: money.FromFloat(5)}, applied)
+	s.Equal(domain.ScheduleAllocation{Penalty: money.FromFloat(5), Fee: money.FromFloat(5)}, schedule.Outstanding())
+
+	s.Equal([]domain.PaymentAllocation{
+		{PaymentScheduleID: 7, Component: domain.ComponentPrincipal, Amount: money.FromFloat(75)},
+		{PaymentScheduleID: 7, Component: domain.ComponentInterest, Amount: money.FromFloat(20)},
+		{PaymentScheduleID: 7, Component: domain.ComponentPenalty, Amount: money.FromFloat(5)},
+	}, applied.Allocations(7, order))
+}
+
+func (s *PaymentScheduleSuite) TestSettle() {
+	schedule := installment()
+	schedule.Pay(money.FromFloat(15), nil)
+
+	applied := schedule.Settle(money.FromFloat(4))
+	s.Equal(domain.ScheduleAllocation{Interest: money.FromFloat(6), Principal: money.FromFloat(75)}, applied)
+	s.Equal(money.FromFloat(96), schedule.PaidAmount)
+	s.Equal(domain.SchedulePaid, schedule.Status())
+}
+
+func (s *PaymentScheduleSuite) TestUnpay() {
+	schedule := installment()
+	first := schedule.Pay(money.FromFloat(15), nil)
+	second := schedule.Pay(money.FromFloat(85), nil)
+	s.Equal(domain.SchedulePaid, schedule.Status())
+
+	allocations := append(first.Allocations(7, nil), second.Allocations(7, nil)...)
+	applied := domain.SumAllocations(allocations)
+	s.Equal(map[uint]domain.ScheduleAllocation{
+		7: {Principal: money.FromFloat(75), Interest: money.FromFloat(20), Fee: money.FromFloat(5)},
+	}, applied)
+
+	schedule.Unpay(second)
+	s.Equal(money.FromFloat(15), schedule.PaidAmount)
+	s.Equal(domain.SchedulePartiallyPaid, schedule.Status())
+	s.Nil(schedule.PaidAt)
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(75), Interest: money.FromFloat(10)}, schedule.Outstanding())
+
+	schedule.Unpay(first)
+	s.Equal(installment(), schedule)
+}
+
+func (s *PaymentScheduleSuite) TestUnpayWaivedInterest() {
+	schedule := installment()
+	schedule.Settle(money.FromFloat(4))
+
+	schedule.Unpay(domain.ScheduleAllocation{Principal: money.FromFloat(75), Interest: money.FromFloat(16), Fee: money.FromFloat(5)})
+	s.Equal(domain.ScheduleUnpaid, schedule.Status())
+	s.Equal(installment().Outstanding(), schedule.Outstanding())
+}
+
+func (s *PaymentScheduleSuite) TestOutstanding() {
+	schedule := installment()
+	schedule.FeePaid = money.FromFloat(5)
+	schedule.InterestPaid = money.FromFloat(20)
+	schedule.PrincipalPaid = money.FromFloat(5)
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(70)}, schedule.Outstanding())
+
+	legacy := domain.PaymentSchedule{DueAmount: money.FromFloat(100)}
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(100)}, legacy.Outstanding())
+}
+
+func (s *PaymentScheduleSuite) TestDeferAndCapitalize() {
+	schedule := installment()
+	schedule.Pay(money.FromFloat(15), nil)
+
+	deferred := schedule.Defer()
+	s.Equal(domain.ScheduleAllocation{Interest: money.FromFloat(10), Principal: money.FromFloat(75)}, deferred)
+	s.Equal(money.FromFloat(15), schedule.DueAmount)
+	s.Equal(domain.ScheduleAllocation{}, schedule.Outstanding())
+	s.Equal(domain.ScheduleDeferred, schedule.Status())
+
+	next := installment()
+	next.Capitalize(deferred)
+	s.Equal(money.FromFloat(185), next.DueAmount)
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(160), Interest: money.FromFloat(20), Fee: money.FromFloat(5)}, next.Outstanding())
+}
+
+func (s *PaymentScheduleSuite) TestAllocationOrderValidate() {
+	s.NoError(domain.AllocationOrder(nil).Validate())
+	s.NoError(domain.DefaultAllocationOrder.Validate())
+	s.Error(domain.AllocationOrder{domain.ComponentFee, domain.ComponentFee, domain.ComponentInterest, domain.ComponentPrincipal}.Validate())
+	s.Error(domain.AllocationOrder{domain.ComponentFee, domain.ComponentInterest, domain.ComponentPrincipal}.Validate())
+	s.Error(domain.AllocationOrder{"tax", domain.ComponentPenalty, domain.ComponentInterest, domain.ComponentPrincipal}.Validate())
+}
+
+func TestPaymentScheduleSuite(t *testing.T) {
+	suite.Run(t, new(PaymentScheduleSuite))
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+// PenaltyType says how late payment is penalised: a flat amount once per
+// missed installment, or a daily percentage of the overdue amount.
+type PenaltyType string
+
+const (
+	PenaltyNone      PenaltyType = "none"
+	PenaltyFlat      PenaltyType = "flat"
+	PenaltyDailyRate PenaltyType = "daily_rate"
+)
+
+func (t PenaltyType) IsValid() bool {
+	switch t {
+	case PenaltyNone, PenaltyFlat, PenaltyDailyRate:
+		return true
+	}
+	return false
+}
+
+// PenaltyPolicy prices late payment. Flat penalties charge Amount, daily
+// penalties charge Rate percent of the overdue amount for every day late. Cap
+// limits the total penalty on one installment; zero means uncapped.
+type PenaltyPolicy struct {
+	Type   PenaltyType `gorm:"not null;default:none" json:"type"`
+	Amount money.Money `gorm:"not null;default:0" json:"amount"`
+	Rate   float64     `gorm:"not null;default:0" json:"rate"`
+	Cap    money.Money `gorm:"not null;default:0" json:"cap"`
+}
+
+func (p PenaltyPolicy) Validate() error {
+	if !p.Type.IsValid() {
+		return fmt.Errorf("invalid penalty type")
+	}
+	if p.Amount < 0 || p.Rate < 0 || p.Cap < 0 {
+		return fmt.Errorf("penalty must not be negative")
+	}
+	return nil
+}
+
+// Charge returns the penalty an overdue installment accrues up to the given
+// date. Flat penalties are charged on the first accrual only; daily penalties
+// cover the days since the installment fell due or was last accrued.
+func (p PenaltyPolicy) Charge(schedule PaymentSchedule, date time.Time) money.Money {
+	if schedule.Paid || !schedule.DueDate.Before(date) {
+		return 0
+	}
+
+	var charge money.Money
+	switch p.Type {
+	case PenaltyFlat:
+		if schedule.PenaltyAccruedAt == nil {
+			charge = p.Amount
+		}
+	case PenaltyDailyRate:
+		from := schedule.DueDate
+		if schedule.PenaltyAccruedAt != nil {
+			from = *schedule.PenaltyAccruedAt
+		}
+		days := int(date.Sub(from).Hours() / 24)
+		if days > 0 {
+			outstanding := schedule.Outstanding()
+			overdue := outstanding.Total() - outstanding.Penalty
+			charge = overdue.MulRate(p.Rate / 100 * float64(days))
+		}
+	}
+
+	if p.Cap > 0 {
+		charge = max(min(charge, p.Cap-schedule.PenaltyAmount), 0)
+	}
+	return charge
+}
+
+// PenaltyCharge is a penalty accrued on an overdue installment.
+type PenaltyCharge struct {
+	gorm.Model
+	LoanID            uint        `gorm:"not null;index" json:"loan_id"`
+	PaymentScheduleID uint        `gorm:"not null;index" json:"payment_schedule_id"`
+	Amount            money.Money `gorm:"not null" json:"amount"`
+	AccruedOn         time.Time   `gorm:"not null" json:"accrued_on"`
+}
+
+type PenaltyUsecase interface {
+	AccruePenalties(ctx context.Context, date time.Time) (*dto.AccruePenaltiesResponse, error)
+}
+
+type PenaltyRepository interface {
+	CreatePenaltyCharges(ctx context.Context, charges []PenaltyCharge, tx *gorm.DB) error
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type PenaltyPolicySuite struct {
+	suite.Suite
+}
+
+func (s *PenaltyPolicySuite) TestCharge() {
+	dueDate := time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC)
+	accruedAt := dueDate.AddDate(0, 0, 2)
+
+	tests := []struct {
+		name     string
+		policy   domain.PenaltyPolicy
+		modify   func(*domain.PaymentSchedule)
+		date     time.Time
+		expected money.Money
+	}{
+		{
+			name:     "No Penalty",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyNone},
+			date:     dueDate.AddDate(0, 0, 3),
+			expected: 0,
+		},
+		{
+			name:     "Flat On First Accrual",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5)},
+			date:     dueDate.AddDate(0, 0, 1),
+			expected: money.FromFloat(5),
+		},
+		{
+			name:     "Flat Charged Once",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5)},
+			modify:   func(ps *domain.PaymentSchedule) { ps.AddPenalty(money.FromFloat(5), accruedAt) },
+			date:     dueDate.AddDate(0, 0, 5),
+			expected: 0,
+		},
+		{
+			name:     "Daily Rate Since Due Date",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyDailyRate, Rate: 1},
+			date:     dueDate.AddDate(0, 0, 3),
+			expected: money.FromFloat(3),
+		},
+		{
+			name:   "Daily Rate Since Last Accrual On Overdue Amount",
+			policy: domain.PenaltyPolicy{Type: domain.PenaltyDailyRate, Rate: 1},
+			modify: func(ps *domain.PaymentSchedule) {
+				ps.AddPenalty(money.FromFloat(2), accruedAt)
+				ps.Pay(money.FromFloat(52), nil)
+			},
+			date:     accruedAt.AddDate(0, 0, 2),
+			expected: money.FromFloat(1),
+		},
+		{
+			name:     "Daily Rate Capped",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyDailyRate, Rate: 1, Cap: money.FromFloat(4)},
+			modify:   func(ps *domain.PaymentSchedule) { ps.AddPenalty(money.FromFloat(2), accruedAt) },
+			date:     accruedAt.AddDate(0, 0, 5),
+			expected: money.FromFloat(2),
+		},
+		{
+			name:     "Not Yet Overdue",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5)},
+			date:     dueDate,
+			expected: 0,
+		},
+		{
+			name:     "Paid Installment",
+			policy:   domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5)},
+			modify:   func(ps *domain.PaymentSchedule) { ps.Pay(money.FromFloat(100), nil) },
+			date:     dueDate.AddDate(0, 0, 1),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			schedule := domain.PaymentSchedule{
+				DueAmount:      money.FromFloat(100),
+				InterestAmount: money.FromFloat(20),
+				DueDate:        dueDate,
+			}
+			if tt.modify != nil {
+				tt.modify(&schedule)
+			}
+
+			s.Equal(tt.expected, tt.policy.Charge(schedule, tt.date))
+		})
+	}
+}
+
+func (s *PenaltyPolicySuite) TestAddPenalty() {
+	schedule := domain.PaymentSchedule{DueAmount: money.FromFloat(100)}
+	accruedAt := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	schedule.AddPenalty(money.FromFloat(5), accruedAt)
+
+	s.Equal(money.FromFloat(105), schedule.DueAmount)
+	s.Equal(money.FromFloat(5), schedule.PenaltyAmount)
+	s.Equal(domain.ScheduleAllocation{Principal: money.FromFloat(100), Penalty: money.FromFloat(5)}, schedule.Outstanding())
+	s.Equal(&accruedAt, schedule.PenaltyAccruedAt)
+}
+
+func TestPenaltyPolicySuite(t *testing.T) {
+	suite.Run(t, new(PenaltyPolicySuite))
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+// RebatePolicy decides how much of the interest not yet due is waived when a
+// loan is settled early.
+type RebatePolicy string
+
+const (
+	RebateNone      RebatePolicy = "none"
+	RebateFull      RebatePolicy = "full"
+	RebateRuleOf78s RebatePolicy = "rule_of_78"
+)
+
+func (p RebatePolicy) IsValid() bool {
+	switch p {
+	case RebateNone, RebateFull, RebateRuleOf78s:
+		return true
+	}
+	return false
+}
+
+// Rebate returns the interest waived when the loan is paid off on the given
+// date. Only interest still outstanding on installments falling due after the
+// date earns a rebate. The rule of 78s waives the share of the total interest
+// given by the sum of the digits of the remaining installments, capped at the
+// interest still to come.
+func (p RebatePolicy) Rebate(schedules []PaymentSchedule, date time.Time) money.Money {
+	var totalInterest, futureInterest money.Money
+	var remaining int
+	for _, schedule := range schedules {
+		totalInterest += schedule.InterestAmount
+		if !schedule.Paid && schedule.DueDate.After(date) {
+			futureInterest += schedule.Outstanding().Interest
+			remaining++
+		}
+	}
+
+	switch p {
+	case RebateFull:
+		return futureInterest
+	case RebateRuleOf78s:
+		n := len(schedules)
+		rebate := totalInterest.MulRate(float64(remaining*(remaining+1)) / float64(n*(n+1)))
+		if rebate > futureInterest {
+			return futureInterest
+		}
+		return rebate
+	}
+	return 0
+}
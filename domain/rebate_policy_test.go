@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+type RebatePolicySuite struct {
+	suite.Suite
+}
+
+func (s *RebatePolicySuite) TestRebate() {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	schedules := make([]domain.PaymentSchedule, 4)
+	for i := range schedules {
+		schedules[i] = domain.PaymentSchedule{
+			InterestAmount: money.FromFloat(10),
+			DueDate:        start.AddDate(0, 0, 7*(i+1)),
+		}
+	}
+	schedules[0].Paid = true
+
+	payoffDate := start.AddDate(0, 0, 15)
+
+	tests := []struct {
+		policy   domain.RebatePolicy
+		expected money.Money
+	}{
+		{domain.RebateNone, 0},
+		{domain.RebateFull, money.FromFloat(20)},
+		{domain.RebateRuleOf78s, money.FromFloat(12)},
+	}
+
+	for _, tt := range tests {
+		s.Run(string(tt.policy), func() {
+			s.Equal(tt.expected, tt.policy.Rebate(schedules, payoffDate))
+		})
+	}
+}
+
+func (s *RebatePolicySuite) TestRebateAfterLastInstallment() {
+	schedules := []domain.PaymentSchedule{
+		{InterestAmount: money.FromFloat(10), DueDate: time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC)},
+	}
+
+	s.Equal(money.Money(0), domain.RebateRuleOf78s.Rebate(schedules, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func (s *RebatePolicySuite) TestIsValid() {
+	s.True(domain.RebateRuleOf78s.IsValid())
+	s.False(domain.RebatePolicy("half").IsValid())
+}
+
+func TestRebatePolicySuite(t *testing.T) {
+	suite.Run(t, new(RebatePolicySuite))
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+type RepaymentFrequency string
+
+const (
+	FrequencyDaily    RepaymentFrequency = "daily"
+	FrequencyWeekly   RepaymentFrequency = "weekly"
+	FrequencyBiWeekly RepaymentFrequency = "bi_weekly"
+	FrequencyMonthly  RepaymentFrequency = "monthly"
+)
+
+func (f RepaymentFrequency) IsValid() bool {
+	return f.PeriodsPerYear() > 0
+}
+
+func (f RepaymentFrequency) PeriodsPerYear() int {
+	switch f {
+	case FrequencyDaily:
+		return 365
+	case FrequencyWeekly:
+		return 52
+	case FrequencyBiWeekly:
+		return 26
+	case FrequencyMonthly:
+		return 12
+	}
+	return 0
+}
+
+// PeriodicRate converts an annual percentage rate into the rate charged per installment.
+func (f RepaymentFrequency) PeriodicRate(annualRate float64) float64 {
+	return (annualRate / 100) / float64(f.PeriodsPerYear())
+}
+
+// DueDate returns the due date of the nth installment counted from start. Monthly
+// installments keep the start day of month, falling back to the last day of
+// shorter months (Jan 31 → Feb 28/29 → Mar 31).
+func (f RepaymentFrequency) DueDate(start time.Time, n int) time.Time {
+	switch f {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, n)
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case FrequencyBiWeekly:
+		return start.AddDate(0, 0, 14*n)
+	case FrequencyMonthly:
+		return addMonths(start, n)
+	}
+	return start
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type RepaymentFrequencySuite struct {
+	suite.Suite
+}
+
+func (s *RepaymentFrequencySuite) TestDueDate() {
+	tests := []struct {
+		name      string
+		frequency domain.RepaymentFrequency
+		start     time.Time
+		expected  []time.Time
+	}{
+		{
+			name:      "Daily",
+			frequency: domain.FrequencyDaily,
+			start:     time.Date(2023, time.February, 27, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "Weekly",
+			frequency: domain.FrequencyWeekly,
+			start:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2023, time.January, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "Bi-Weekly",
+			frequency: domain.FrequencyBiWeekly,
+			start:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, time.January, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "Monthly From Month End In Leap Year",
+			frequency: domain.FrequencyMonthly,
+			start:     time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "Monthly Across Year End",
+			frequency: domain.FrequencyMonthly,
+			start:     time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			for i, expected := range tt.expected {
+				s.Equal(expected, tt.frequency.DueDate(tt.start, i+1))
+			}
+		})
+	}
+}
+
+func (s *RepaymentFrequencySuite) TestPeriodicRate() {
+	s.InDelta(0.10/52, domain.FrequencyWeekly.PeriodicRate(10), 1e-12)
+	s.InDelta(0.12/12, domain.FrequencyMonthly.PeriodicRate(12), 1e-12)
+	s.False(domain.RepaymentFrequency("yearly").IsValid())
+}
+
+func TestRepaymentFrequencySuite(t *testing.T) {
+	suite.Run(t, new(RepaymentFrequencySuite))
+}
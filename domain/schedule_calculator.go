@@ -0,0 +1,29 @@
+package domain
+
+import "github.com/greekrode/loan-engine-amartha/domain/money"
+
+type AmortizationMethod string
+
+const (
+	AmortizationFlat             AmortizationMethod = "flat"
+	AmortizationDecliningBalance AmortizationMethod = "declining_balance"
+	AmortizationInterestOnly     AmortizationMethod = "interest_only"
+)
+
+func (m AmortizationMethod) IsValid() bool {
+	switch m {
+	case AmortizationFlat, AmortizationDecliningBalance, AmortizationInterestOnly:
+		return true
+	}
+	return false
+}
+
+// Installment is a single period of a computed schedule, before due dates are assigned.
+type Installment struct {
+	Principal money.Money
+	Interest  money.Money
+}
+
+type ScheduleCalculator interface {
+	Calculate(principal money.Money, periodicRate float64, periods int) []Installment
+}
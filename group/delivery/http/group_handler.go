@@ -0,0 +1,192 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type BorrowerGroupHandler struct {
+	BorrowerGroupUsecase domain.BorrowerGroupUsecase
+}
+
+func NewBorrowerGroupHandler(g *gin.Engine, b domain.BorrowerGroupUsecase) {
+	handler := &BorrowerGroupHandler{BorrowerGroupUsecase: b}
+
+	g.POST("/groups", handler.CreateGroup)
+	g.GET("/groups/:group_id", handler.GetGroup)
+	g.POST("/groups/:group_id/members", handler.AddMember)
+	g.GET("/groups/:group_id/meetings/next", handler.GetNextMeeting)
+	g.GET("/groups/:group_id/debts", handler.GetMemberDebts)
+	g.POST("/groups/:group_id/debts/:debt_id/repay", handler.RepayMemberDebt)
+}
+
+func (b *BorrowerGroupHandler) CreateGroup(c *gin.Context) {
+	var req dto.CreateBorrowerGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	meetingDay, err := domain.ParseWeekday(req.MeetingDay)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	group := domain.BorrowerGroup{
+		Name:       req.Name,
+		Village:    req.Village,
+		MeetingDay: meetingDay,
+		LeaderID:   req.LeaderID,
+	}
+	if err := group.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	groupResponse, err := b.BorrowerGroupUsecase.CreateGroup(ctx, group, req.MemberIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, groupResponse)
+}
+
+func (b *BorrowerGroupHandler) GetGroup(c *gin.Context) {
+	groupID, ok := bindGroupID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	groupResponse, err := b.BorrowerGroupUsecase.GetGroup(ctx, groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, groupResponse)
+}
+
+func (b *BorrowerGroupHandler) AddMember(c *gin.Context) {
+	groupID, ok := bindGroupID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AddGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.BorrowerID == 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "borrower ID is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	groupResponse, err := b.BorrowerGroupUsecase.AddMember(ctx, groupID, req.BorrowerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, groupResponse)
+}
+
+// GetNextMeeting lists the installments due at the group's next meeting on
+// or after the date in the query string, or today when none is given.
+func (b *BorrowerGroupHandler) GetNextMeeting(c *gin.Context) {
+	groupID, ok := bindGroupID(c)
+	if !ok {
+		return
+	}
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("date"); query != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	meetingResponse, err := b.BorrowerGroupUsecase.GetNextMeeting(ctx, groupID, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, meetingResponse)
+}
+
+func (b *BorrowerGroupHandler) GetMemberDebts(c *gin.Context) {
+	groupID, ok := bindGroupID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	debtsResponse, err := b.BorrowerGroupUsecase.GetMemberDebts(ctx, groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debtsResponse)
+}
+
+func (b *BorrowerGroupHandler) RepayMemberDebt(c *gin.Context) {
+	groupID, ok := bindGroupID(c)
+	if !ok {
+		return
+	}
+
+	debtID, err := strconv.ParseUint(c.Param("debt_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid debt ID format"})
+		return
+	}
+
+	var req dto.RepayMemberDebtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "repayment amount must be positive"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	debtResponse, err := b.BorrowerGroupUsecase.RepayMemberDebt(ctx, groupID, uint(debtID), req.Amount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debtResponse)
+}
+
+// bindGroupID parses the group_id path parameter. It writes the error
+// response itself and reports whether the handler should continue.
+func bindGroupID(c *gin.Context) (uint, bool) {
+	groupID, err := strconv.ParseUint(c.Param("group_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid group ID format"})
+		return 0, false
+	}
+
+	return uint(groupID), true
+}
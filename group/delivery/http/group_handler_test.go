@@ -0,0 +1,290 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	groupHttp "github.com/greekrode/loan-engine-amartha/group/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.BorrowerGroupUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := groupHttp.BorrowerGroupHandler{
+		BorrowerGroupUsecase: mockUCase,
+	}
+	router.POST("/groups", handler.CreateGroup)
+	router.GET("/groups/:group_id/meetings/next", handler.GetNextMeeting)
+	router.POST("/groups/:group_id/debts/:debt_id/repay", handler.RepayMemberDebt)
+	return router
+}
+
+func TestCreateGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	group := domain.BorrowerGroup{
+		Name:       "Mawar",
+		Village:    "Ciseeng",
+		MeetingDay: time.Tuesday,
+		LeaderID:   1,
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockUsecase    *mocks.BorrowerGroupUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Group",
+			requestBody: `{"name": "Mawar", "village": "Ciseeng", "meeting_day": "Tuesday", "leader_id": 1, "member_ids": [1, 3]}`,
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("CreateGroup", mock.Anything, group, []uint{1, 3}).Return(&dto.GetBorrowerGroupResponse{
+					ID:         4,
+					Name:       "Mawar",
+					Village:    "Ciseeng",
+					MeetingDay: "tuesday",
+					LeaderID:   1,
+					Members:    []dto.GetBorrowerResponse{{ID: 1}, {ID: 3}},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{
+				"id": 4,
+				"name": "Mawar",
+				"village": "Ciseeng",
+				"meeting_day": "tuesday",
+				"leader_id": 1,
+				"members": [
+					{"id": 1, "first_name": "", "last_name": "", "email": "", "created_at": "0001-01-01T00:00:00Z"},
+					{"id": 3, "first_name": "", "last_name": "", "email": "", "created_at": "0001-01-01T00:00:00Z"}
+				],
+				"created_at": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:           "Invalid Meeting Day",
+			requestBody:    `{"name": "Mawar", "village": "Ciseeng", "meeting_day": "market day", "leader_id": 1, "member_ids": [1]}`,
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid meeting day"}`,
+		},
+		{
+			name:           "Missing Village",
+			requestBody:    `{"name": "Mawar", "meeting_day": "tuesday", "leader_id": 1, "member_ids": [1]}`,
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"village is required"}`,
+		},
+		{
+			name:        "Usecase Error",
+			requestBody: `{"name": "Mawar", "village": "Ciseeng", "meeting_day": "tuesday", "leader_id": 1, "member_ids": [3]}`,
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("CreateGroup", mock.Anything, group, []uint{3}).Return(nil, errors.New("leader must be a member of the group"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"leader must be a member of the group"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/groups", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetNextMeeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	date := time.Date(2023, time.January, 4, 0, 0, 0, 0, time.UTC)
+	meeting := time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		mockUsecase    *mocks.BorrowerGroupUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Valid Request",
+			url:  "/groups/4/meetings/next?date=2023-01-04",
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("GetNextMeeting", mock.Anything, uint(4), date).Return(&dto.GroupMeetingResponse{
+					GroupID:     4,
+					MeetingDate: meeting,
+					TotalDue:    money.FromFloat(100),
+					Installments: []dto.GroupInstallmentResponse{
+						{BorrowerID: 1, LoanID: 7, PaymentScheduleID: 71, DueDate: meeting, DueAmount: money.FromFloat(100), OutstandingAmount: money.FromFloat(100)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"group_id": 4,
+				"meeting_date": "2023-01-10T00:00:00Z",
+				"total_due": 100,
+				"installments": [
+					{"borrower_id": 1, "loan_id": 7, "payment_schedule_id": 71, "due_date": "2023-01-10T00:00:00Z", "due_amount": 100, "paid_amount": 0, "outstanding_amount": 100, "overdue": false}
+				]
+			}`,
+		},
+		{
+			name:           "Invalid Group ID",
+			url:            "/groups/abc/meetings/next",
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid group ID format"}`,
+		},
+		{
+			name:           "Invalid Date",
+			url:            "/groups/4/meetings/next?date=04-01-2023",
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name: "Group Not Found",
+			url:  "/groups/9/meetings/next?date=2023-01-04",
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("GetNextMeeting", mock.Anything, uint(9), date).Return(nil, errors.New("Group not found"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"Group not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", tt.url, nil)
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRepayMemberDebt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		url            string
+		requestBody    string
+		mockUsecase    *mocks.BorrowerGroupUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Request",
+			url:         "/groups/4/debts/1/repay",
+			requestBody: `{"amount": 30}`,
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("RepayMemberDebt", mock.Anything, uint(4), uint(1), money.FromFloat(30)).Return(&dto.MemberDebtResponse{
+					ID:                1,
+					SettlementID:      9,
+					CreditorID:        1,
+					DebtorID:          2,
+					Amount:            money.FromFloat(110),
+					RepaidAmount:      money.FromFloat(90),
+					OutstandingAmount: money.FromFloat(20),
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"id": 1,
+				"settlement_id": 9,
+				"creditor_id": 1,
+				"debtor_id": 2,
+				"amount": 110,
+				"repaid_amount": 90,
+				"outstanding_amount": 20,
+				"created_at": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:           "Invalid Debt ID",
+			url:            "/groups/4/debts/abc/repay",
+			requestBody:    `{"amount": 30}`,
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid debt ID format"}`,
+		},
+		{
+			name:           "Missing Amount",
+			url:            "/groups/4/debts/1/repay",
+			requestBody:    `{}`,
+			mockUsecase:    new(mocks.BorrowerGroupUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"repayment amount must be positive"}`,
+		},
+		{
+			name:        "Exceeds Outstanding Debt",
+			url:         "/groups/4/debts/1/repay",
+			requestBody: `{"amount": 60}`,
+			mockUsecase: func() *mocks.BorrowerGroupUsecase {
+				mockUsecase := new(mocks.BorrowerGroupUsecase)
+				mockUsecase.On("RepayMemberDebt", mock.Anything, uint(4), uint(1), money.FromFloat(60)).Return(nil, errors.New("repayment exceeds the outstanding debt of 20.00"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"repayment exceeds the outstanding debt of 20.00"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", tt.url, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqliteBorrowerGroupRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteBorrowerGroupRepository(tm db.TransactionManager) *sqliteBorrowerGroupRepository {
+	return &sqliteBorrowerGroupRepository{TransactionManager: tm}
+}
+
+func (s *sqliteBorrowerGroupRepository) CreateGroup(ctx context.Context, group *domain.BorrowerGroup, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Omit("Members").Create(group).Error
+}
+
+func (s *sqliteBorrowerGroupRepository) FindGroupByID(ctx context.Context, groupID uint) (*domain.BorrowerGroup, error) {
+	var group domain.BorrowerGroup
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&group, groupID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Group not found")
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (s *sqliteBorrowerGroupRepository) AssignMembers(ctx context.Context, groupID uint, borrowerIDs []uint, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Model(&domain.Borrower{}).Where("id IN ?", borrowerIDs).Update("group_id", groupID).Error
+}
+
+func (s *sqliteBorrowerGroupRepository) CreateMemberDebts(ctx context.Context, debts []domain.MemberDebt, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&debts).Error
+}
+
+func (s *sqliteBorrowerGroupRepository) FindMemberDebtByID(ctx context.Context, debtID uint) (*domain.MemberDebt, error) {
+	var debt domain.MemberDebt
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).First(&debt, debtID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Debt not found")
+		}
+		return nil, err
+	}
+
+	return &debt, nil
+}
+
+func (s *sqliteBorrowerGroupRepository) GetMemberDebtsByGroupID(ctx context.Context, groupID uint) ([]domain.MemberDebt, error) {
+	var debts []domain.MemberDebt
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("group_id = ?", groupID).Order("id").Find(&debts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return debts, nil
+}
+
+func (s *sqliteBorrowerGroupRepository) UpdateMemberDebt(ctx context.Context, debt *domain.MemberDebt, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Save(debt).Error
+}
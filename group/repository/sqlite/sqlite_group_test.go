@@ -0,0 +1,145 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/group/repository/sqlite"
+	"github.com/greekrode/loan-engine-amartha/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type BorrowerGroupRepositorySuite struct {
+	suite.Suite
+	tm   db.TransactionManager
+	mock sqlmock.Sqlmock
+}
+
+func (s *BorrowerGroupRepositorySuite) SetupSuite() {
+	var err error
+	s.tm, s.mock, err = utils.SetupMockDB(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *BorrowerGroupRepositorySuite) AfterTest(_, _ string) {
+	s.Require().NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *BorrowerGroupRepositorySuite) TestCreateGroup() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("INSERT INTO `borrower_groups`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "Mawar", "Ciseeng", time.Tuesday, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+	group := domain.BorrowerGroup{
+		Name:       "Mawar",
+		Village:    "Ciseeng",
+		MeetingDay: time.Tuesday,
+		LeaderID:   1,
+		Members:    []domain.Borrower{{FirstName: "Siti"}},
+	}
+	err := repo.CreateGroup(context.TODO(), &group, nil)
+	s.NoError(err)
+	s.Equal(uint(1), group.ID)
+}
+
+func (s *BorrowerGroupRepositorySuite) TestAssignMembers() {
+	updateQuery := regexp.QuoteMeta("UPDATE `borrowers` SET `group_id`=?,`updated_at`=? WHERE id IN (?,?) AND `borrowers`.`deleted_at` IS NULL")
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				s.mock.ExpectBegin()
+				s.mock.ExpectExec(updateQuery).WithArgs(3, sqlmock.AnyArg(), 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+				s.mock.ExpectCommit()
+			},
+		},
+		{
+			name: "DatabaseError",
+			setup: func() {
+				s.mock.ExpectBegin()
+				s.mock.ExpectExec(updateQuery).WithArgs(3, sqlmock.AnyArg(), 1, 2).WillReturnError(fmt.Errorf("database error"))
+				s.mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setup()
+			repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+			err := repo.AssignMembers(context.TODO(), 3, []uint{1, 2}, nil)
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *BorrowerGroupRepositorySuite) TestFindGroupByID() {
+	groupQuery := regexp.QuoteMeta("SELECT * FROM `borrower_groups` WHERE `borrower_groups`.`id` = ? AND `borrower_groups`.`deleted_at` IS NULL ORDER BY `borrower_groups`.`id` LIMIT 1")
+	membersQuery := regexp.QuoteMeta("SELECT * FROM `borrowers` WHERE `borrowers`.`group_id` = ? AND `borrowers`.`deleted_at` IS NULL ORDER BY id")
+
+	s.Run("Success", func() {
+		s.mock.ExpectQuery(groupQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "village", "meeting_day", "leader_id"}).AddRow(1, "Mawar", "Ciseeng", 2, 5))
+		s.mock.ExpectQuery(membersQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "group_id"}).AddRow(5, "Siti", 1).AddRow(6, "Ani", 1))
+
+		repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+		group, err := repo.FindGroupByID(context.TODO(), 1)
+		s.NoError(err)
+		s.Equal(time.Tuesday, group.MeetingDay)
+		s.Equal(uint(5), group.LeaderID)
+		s.Len(group.Members, 2)
+	})
+
+	s.Run("NotFound", func() {
+		s.mock.ExpectQuery(groupQuery).WithArgs(9).WillReturnRows(sqlmock.NewRows(nil))
+
+		repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+		_, err := repo.FindGroupByID(context.TODO(), 9)
+		s.EqualError(err, "Group not found")
+	})
+}
+
+func (s *BorrowerGroupRepositorySuite) TestGetMemberDebtsByGroupID() {
+	debtsQuery := regexp.QuoteMeta("SELECT * FROM `member_debts` WHERE group_id = ? AND `member_debts`.`deleted_at` IS NULL ORDER BY id")
+
+	s.mock.ExpectQuery(debtsQuery).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"id", "group_id", "settlement_id", "creditor_id", "debtor_id", "amount", "repaid_amount"}).
+		AddRow(1, 4, 9, 1, 2, 11000, 6000).
+		AddRow(2, 4, 9, 1, 3, 5000, 0))
+
+	repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+	debts, err := repo.GetMemberDebtsByGroupID(context.TODO(), 4)
+	s.NoError(err)
+	s.Len(debts, 2)
+	s.Equal(uint(2), debts[0].DebtorID)
+	s.Equal(int64(5000), int64(debts[0].Outstanding()))
+}
+
+func (s *BorrowerGroupRepositorySuite) TestFindMemberDebtByID() {
+	debtQuery := regexp.QuoteMeta("SELECT * FROM `member_debts` WHERE `member_debts`.`id` = ? AND `member_debts`.`deleted_at` IS NULL ORDER BY `member_debts`.`id` LIMIT 1")
+
+	s.mock.ExpectQuery(debtQuery).WithArgs(9).WillReturnRows(sqlmock.NewRows(nil))
+
+	repo := sqlite.NewSQLiteBorrowerGroupRepository(s.tm)
+	_, err := repo.FindMemberDebtByID(context.TODO(), 9)
+	s.EqualError(err, "Debt not found")
+}
+
+func TestBorrowerGroupRepositorySuite(t *testing.T) {
+	suite.Run(t, new(BorrowerGroupRepositorySuite))
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type borrowerGroupUsecase struct {
+	groupRepo          domain.BorrowerGroupRepository
+	borrowerRepo       domain.BorrowerRepository
+	loanRepo           domain.LoanRepository
+	transactionManager db.TransactionManager
+	contextTimeout     time.Duration
+}
+
+func NewBorrowerGroupUsecase(g domain.BorrowerGroupRepository, b domain.BorrowerRepository, l domain.LoanRepository, tm db.TransactionManager, timeout time.Duration) domain.BorrowerGroupUsecase {
+	return &borrowerGroupUsecase{
+		groupRepo:          g,
+		borrowerRepo:       b,
+		loanRepo:           l,
+		transactionManager: tm,
+		contextTimeout:     timeout,
+	}
+}
+
+// CreateGroup forms a group from borrowers who are not yet in one. The leader
+// must be one of the members.
+func (g *borrowerGroupUsecase) CreateGroup(ctx context.Context, group domain.BorrowerGroup, memberIDs []uint) (*dto.GetBorrowerGroupResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	if !slices.Contains(memberIDs, group.LeaderID) {
+		return nil, fmt.Errorf("leader must be a member of the group")
+	}
+
+	members := make([]domain.Borrower, len(memberIDs))
+	for i, borrowerID := range memberIDs {
+		borrower, err := g.findUngroupedBorrower(ctx, borrowerID)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = *borrower
+	}
+
+	tx := g.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err := g.groupRepo.CreateGroup(ctx, &group, tx)
+	if err != nil {
+		g.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = g.groupRepo.AssignMembers(ctx, group.ID, memberIDs, tx)
+	if err != nil {
+		g.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = g.transactionManager.Commit(tx)
+	if err != nil {
+		g.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	group.Members = members
+	return assembleGroupResponse(&group), nil
+}
+
+func (g *borrowerGroupUsecase) GetGroup(ctx context.Context, groupID uint) (*dto.GetBorrowerGroupResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	group, err := g.groupRepo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleGroupResponse(group), nil
+}
+
+// AddMember brings a borrower who is not yet in a group into the group. Only
+// loans created afterwards are aligned to the group's meeting day.
+func (g *borrowerGroupUsecase) AddMember(ctx context.Context, groupID uint, borrowerID uint) (*dto.GetBorrowerGroupResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	group, err := g.groupRepo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	borrower, err := g.findUngroupedBorrower(ctx, borrowerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.groupRepo.AssignMembers(ctx, group.ID, []uint{borrower.ID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Members = append(group.Members, *borrower)
+	return assembleGroupResponse(group), nil
+}
+
+func (g *borrowerGroupUsecase) findUngroupedBorrower(ctx context.Context, borrowerID uint) (*domain.Borrower, error) {
+	borrower, err := g.borrowerRepo.FindBorrowerByID(ctx, borrowerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if borrower.GroupID != nil {
+		return nil, fmt.Errorf("borrower %d already belongs to group %d", borrower.ID, *borrower.GroupID)
+	}
+
+	return borrower, nil
+}
+
+// GetNextMeeting lists what the members have to bring to the group's first
+// meeting on or after date: every unpaid installment of their disbursed loans
+// due by the meeting, including ones already overdue.
+func (g *borrowerGroupUsecase) GetNextMeeting(ctx context.Context, groupID uint, date time.Time) (*dto.GroupMeetingResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	group, err := g.groupRepo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	meeting := group.NextMeeting(date)
+	memberIDs := make([]uint, len(group.Members))
+	for i, member := range group.Members {
+		memberIDs[i] = member.ID
+	}
+
+	loans, err := g.loanRepo.GetActiveLoansByBorrowerIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	installments := []dto.GroupInstallmentResponse{}
+	var totalDue money.Money
+	for _, loan := range loans {
+		for _, schedule := range loan.PaymentSchedules {
+			if schedule.DueDate.After(meeting) {
+				break
+			}
+
+			outstanding := schedule.Outstanding().Total()
+			totalDue += outstanding
+			installments = append(installments, dto.GroupInstallmentResponse{
+				BorrowerID:        loan.BorrowerID,
+				LoanID:            loan.ID,
+				PaymentScheduleID: schedule.ID,
+				DueDate:           schedule.DueDate,
+				DueAmount:         schedule.DueAmount,
+				PaidAmount:        schedule.PaidAmount,
+				OutstandingAmount: outstanding,
+				Overdue:           schedule.DueDate.Before(date),
+			})
+		}
+	}
+
+	return &dto.GroupMeetingResponse{
+		GroupID:      group.ID,
+		MeetingDate:  meeting,
+		TotalDue:     totalDue,
+		Installments: installments,
+	}, nil
+}
+
+// GetMemberDebts lists what members owe each other for installments covered
+// in the group's settlements.
+func (g *borrowerGroupUsecase) GetMemberDebts(ctx context.Context, groupID uint) (*dto.GroupDebtsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	group, err := g.groupRepo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	debts, err := g.groupRepo.GetMemberDebtsByGroupID(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.GroupDebtsResponse{
+		GroupID: group.ID,
+		Debts:   make([]dto.MemberDebtResponse, len(debts)),
+	}
+	for i, debt := range debts {
+		response.TotalOutstanding += debt.Outstanding()
+		response.Debts[i] = assembleMemberDebtResponse(debt)
+	}
+
+	return response, nil
+}
+
+// RepayMemberDebt records a member paying back the member who covered her
+// installments.
+func (g *borrowerGroupUsecase) RepayMemberDebt(ctx context.Context, groupID uint, debtID uint, amount money.Money) (*dto.MemberDebtResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("repayment amount must be positive")
+	}
+
+	debt, err := g.groupRepo.FindMemberDebtByID(ctx, debtID)
+	if err != nil {
+		return nil, err
+	}
+
+	if debt.GroupID != groupID {
+		return nil, fmt.Errorf("debt does not belong to the group")
+	}
+
+	if amount > debt.Outstanding() {
+		return nil, fmt.Errorf("repayment exceeds the outstanding debt of %s", debt.Outstanding())
+	}
+
+	debt.RepaidAmount += amount
+	err = g.groupRepo.UpdateMemberDebt(ctx, debt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := assembleMemberDebtResponse(*debt)
+	return &response, nil
+}
+
+func assembleMemberDebtResponse(debt domain.MemberDebt) dto.MemberDebtResponse {
+	return dto.MemberDebtResponse{
+		ID:                debt.ID,
+		SettlementID:      debt.SettlementID,
+		CreditorID:        debt.CreditorID,
+		DebtorID:          debt.DebtorID,
+		Amount:            debt.Amount,
+		RepaidAmount:      debt.RepaidAmount,
+		OutstandingAmount: debt.Outstanding(),
+		CreatedAt:         debt.CreatedAt,
+	}
+}
+
+func assembleGroupResponse(group *domain.BorrowerGroup) *dto.GetBorrowerGroupResponse {
+	memberResponses := make([]dto.GetBorrowerResponse, len(group.Members))
+	for i, member := range group.Members {
+		memberResponses[i] = dto.GetBorrowerResponse{
+			ID:        member.ID,
+			FirstName: member.FirstName,
+			LastName:  member.LastName,
+			Email:     member.Email,
+			CreatedAt: member.CreatedAt,
+		}
+	}
+
+	return &dto.GetBorrowerGroupResponse{
+		ID:         group.ID,
+		Name:       group.Name,
+		Village:    group.Village,
+		MeetingDay: strings.ToLower(group.MeetingDay.String()),
+		LeaderID:   group.LeaderID,
+		Members:    memberResponses,
+		CreatedAt:  group.CreatedAt,
+	}
+}
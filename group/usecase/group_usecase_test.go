@@ -0,0 +1,282 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	groupUsecase "github.com/greekrode/loan-engine-amartha/group/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BorrowerGroupUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *BorrowerGroupUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *BorrowerGroupUsecaseSuite) TestCreateGroup() {
+	groupID := uint(2)
+	group := domain.BorrowerGroup{
+		Name:       "Mawar",
+		Village:    "Ciseeng",
+		MeetingDay: time.Tuesday,
+		LeaderID:   1,
+	}
+
+	tests := []struct {
+		name          string
+		memberIDs     []uint
+		setupMocks    func(*mocks.BorrowerGroupRepository, *mocks.BorrowerRepository, *mocks.TransactionManager)
+		expected      *dto.GetBorrowerGroupResponse
+		expectedError error
+	}{
+		{
+			name:      "Successful Creation",
+			memberIDs: []uint{1, 3},
+			setupMocks: func(mgr *mocks.BorrowerGroupRepository, mbr *mocks.BorrowerRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{Model: gorm.Model{ID: 1}, FirstName: "Siti"}, nil)
+				mbr.On("FindBorrowerByID", mock.Anything, uint(3)).Return(&domain.Borrower{Model: gorm.Model{ID: 3}, FirstName: "Ani"}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mgr.On("CreateGroup", mock.Anything, &group, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.BorrowerGroup).ID = 4
+				}).Return(nil)
+				mgr.On("AssignMembers", mock.Anything, uint(4), []uint{1, 3}, mock.Anything).Return(nil)
+			},
+			expected: &dto.GetBorrowerGroupResponse{
+				ID:         4,
+				Name:       "Mawar",
+				Village:    "Ciseeng",
+				MeetingDay: "tuesday",
+				LeaderID:   1,
+				Members: []dto.GetBorrowerResponse{
+					{ID: 1, FirstName: "Siti"},
+					{ID: 3, FirstName: "Ani"},
+				},
+			},
+		},
+		{
+			name:      "Leader Not A Member",
+			memberIDs: []uint{3},
+			setupMocks: func(mgr *mocks.BorrowerGroupRepository, mbr *mocks.BorrowerRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("leader must be a member of the group"),
+		},
+		{
+			name:      "Member Already In A Group",
+			memberIDs: []uint{1, 3},
+			setupMocks: func(mgr *mocks.BorrowerGroupRepository, mbr *mocks.BorrowerRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{Model: gorm.Model{ID: 1}}, nil)
+				mbr.On("FindBorrowerByID", mock.Anything, uint(3)).Return(&domain.Borrower{Model: gorm.Model{ID: 3}, GroupID: &groupID}, nil)
+			},
+			expectedError: errors.New("borrower 3 already belongs to group 2"),
+		},
+		{
+			name:      "Error Assigning Members",
+			memberIDs: []uint{1},
+			setupMocks: func(mgr *mocks.BorrowerGroupRepository, mbr *mocks.BorrowerRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{Model: gorm.Model{ID: 1}}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mgr.On("CreateGroup", mock.Anything, mock.AnythingOfType("*domain.BorrowerGroup"), mock.Anything).Return(nil)
+				mgr.On("AssignMembers", mock.Anything, mock.Anything, []uint{1}, mock.Anything).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockGroupRepo := new(mocks.BorrowerGroupRepository)
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := groupUsecase.NewBorrowerGroupUsecase(mockGroupRepo, mockBorrowerRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+
+			tt.setupMocks(mockGroupRepo, mockBorrowerRepo, mockTransactionManager)
+			result, err := uc.CreateGroup(context.TODO(), group, tt.memberIDs)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockGroupRepo.AssertExpectations(s.T())
+			mockTransactionManager.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *BorrowerGroupUsecaseSuite) TestAddMember() {
+	mockGroupRepo := new(mocks.BorrowerGroupRepository)
+	mockBorrowerRepo := new(mocks.BorrowerRepository)
+	uc := groupUsecase.NewBorrowerGroupUsecase(mockGroupRepo, mockBorrowerRepo, new(mocks.LoanRepository), new(mocks.TransactionManager), s.timeout)
+
+	mockGroupRepo.On("FindGroupByID", mock.Anything, uint(4)).Return(&domain.BorrowerGroup{
+		Model:      gorm.Model{ID: 4},
+		Name:       "Mawar",
+		Village:    "Ciseeng",
+		MeetingDay: time.Friday,
+		LeaderID:   1,
+		Members:    []domain.Borrower{{Model: gorm.Model{ID: 1}}},
+	}, nil)
+	mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(5)).Return(&domain.Borrower{Model: gorm.Model{ID: 5}}, nil)
+	mockGroupRepo.On("AssignMembers", mock.Anything, uint(4), []uint{5}, mock.Anything).Return(nil)
+
+	result, err := uc.AddMember(context.TODO(), 4, 5)
+	s.NoError(err)
+	s.Equal("friday", result.MeetingDay)
+	s.Equal([]dto.GetBorrowerResponse{{ID: 1}, {ID: 5}}, result.Members)
+	mockGroupRepo.AssertExpectations(s.T())
+}
+
+func (s *BorrowerGroupUsecaseSuite) TestGetNextMeeting() {
+	// 2023-01-04 is a Wednesday; the group meets on Tuesdays.
+	date := time.Date(2023, time.January, 4, 0, 0, 0, 0, time.UTC)
+	lastMeeting := time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC)
+	nextMeeting := time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	mockGroupRepo := new(mocks.BorrowerGroupRepository)
+	mockLoanRepo := new(mocks.LoanRepository)
+	uc := groupUsecase.NewBorrowerGroupUsecase(mockGroupRepo, new(mocks.BorrowerRepository), mockLoanRepo, new(mocks.TransactionManager), s.timeout)
+
+	mockGroupRepo.On("FindGroupByID", mock.Anything, uint(4)).Return(&domain.BorrowerGroup{
+		Model:      gorm.Model{ID: 4},
+		MeetingDay: time.Tuesday,
+		Members:    []domain.Borrower{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 3}}},
+	}, nil)
+	mockLoanRepo.On("GetActiveLoansByBorrowerIDs", mock.Anything, []uint{1, 3}).Return([]domain.Loan{
+		{
+			Model:      gorm.Model{ID: 7},
+			BorrowerID: 1,
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 70}, DueDate: lastMeeting, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100), PaidAmount: money.FromFloat(40), PrincipalPaid: money.FromFloat(40)},
+				{Model: gorm.Model{ID: 71}, DueDate: nextMeeting, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100)},
+				{Model: gorm.Model{ID: 72}, DueDate: nextMeeting.AddDate(0, 0, 7), DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100)},
+			},
+		},
+		{
+			Model:      gorm.Model{ID: 8},
+			BorrowerID: 3,
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 80}, DueDate: nextMeeting, DueAmount: money.FromFloat(50), PrincipalAmount: money.FromFloat(50)},
+			},
+		},
+	}, nil)
+
+	result, err := uc.GetNextMeeting(context.TODO(), 4, date)
+	s.NoError(err)
+	s.Equal(&dto.GroupMeetingResponse{
+		GroupID:     4,
+		MeetingDate: nextMeeting,
+		TotalDue:    money.FromFloat(210),
+		Installments: []dto.GroupInstallmentResponse{
+			{BorrowerID: 1, LoanID: 7, PaymentScheduleID: 70, DueDate: lastMeeting, DueAmount: money.FromFloat(100), PaidAmount: money.FromFloat(40), OutstandingAmount: money.FromFloat(60), Overdue: true},
+			{BorrowerID: 1, LoanID: 7, PaymentScheduleID: 71, DueDate: nextMeeting, DueAmount: money.FromFloat(100), OutstandingAmount: money.FromFloat(100)},
+			{BorrowerID: 3, LoanID: 8, PaymentScheduleID: 80, DueDate: nextMeeting, DueAmount: money.FromFloat(50), OutstandingAmount: money.FromFloat(50)},
+		},
+	}, result)
+}
+
+func (s *BorrowerGroupUsecaseSuite) TestGetMemberDebts() {
+	mockGroupRepo := new(mocks.BorrowerGroupRepository)
+	uc := groupUsecase.NewBorrowerGroupUsecase(mockGroupRepo, new(mocks.BorrowerRepository), new(mocks.LoanRepository), new(mocks.TransactionManager), s.timeout)
+
+	mockGroupRepo.On("FindGroupByID", mock.Anything, uint(4)).Return(&domain.BorrowerGroup{Model: gorm.Model{ID: 4}}, nil)
+	mockGroupRepo.On("GetMemberDebtsByGroupID", mock.Anything, uint(4)).Return([]domain.MemberDebt{
+		{Model: gorm.Model{ID: 1}, GroupID: 4, SettlementID: 9, CreditorID: 1, DebtorID: 2, Amount: money.FromFloat(110), RepaidAmount: money.FromFloat(60)},
+		{Model: gorm.Model{ID: 2}, GroupID: 4, SettlementID: 9, CreditorID: 1, DebtorID: 3, Amount: money.FromFloat(50)},
+	}, nil)
+
+	result, err := uc.GetMemberDebts(context.TODO(), 4)
+	s.NoError(err)
+	s.Equal(&dto.GroupDebtsResponse{
+		GroupID:          4,
+		TotalOutstanding: money.FromFloat(100),
+		Debts: []dto.MemberDebtResponse{
+			{ID: 1, SettlementID: 9, CreditorID: 1, DebtorID: 2, Amount: money.FromFloat(110), RepaidAmount: money.FromFloat(60), OutstandingAmount: money.FromFloat(50)},
+			{ID: 2, SettlementID: 9, CreditorID: 1, DebtorID: 3, Amount: money.FromFloat(50), OutstandingAmount: money.FromFloat(50)},
+		},
+	}, result)
+}
+
+func (s *BorrowerGroupUsecaseSuite) TestRepayMemberDebt() {
+	tests := []struct {
+		name          string
+		groupID       uint
+		amount        money.Money
+		setupMocks    func(*mocks.BorrowerGroupRepository)
+		expectedError error
+	}{
+		{
+			name:    "Success",
+			groupID: 4,
+			amount:  money.FromFloat(30),
+			setupMocks: func(mgr *mocks.BorrowerGroupRepository) {
+				mgr.On("UpdateMemberDebt", mock.Anything, mock.MatchedBy(func(debt *domain.MemberDebt) bool {
+					return debt.RepaidAmount == money.FromFloat(90)
+				}), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:          "Debt Of Another Group",
+			groupID:       5,
+			amount:        money.FromFloat(30),
+			setupMocks:    func(*mocks.BorrowerGroupRepository) {},
+			expectedError: errors.New("debt does not belong to the group"),
+		},
+		{
+			name:          "Exceeds Outstanding Debt",
+			groupID:       4,
+			amount:        money.FromFloat(60),
+			setupMocks:    func(*mocks.BorrowerGroupRepository) {},
+			expectedError: errors.New("repayment exceeds the outstanding debt of 50.00"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockGroupRepo := new(mocks.BorrowerGroupRepository)
+			uc := groupUsecase.NewBorrowerGroupUsecase(mockGroupRepo, new(mocks.BorrowerRepository), new(mocks.LoanRepository), new(mocks.TransactionManager), s.timeout)
+
+			mockGroupRepo.On("FindMemberDebtByID", mock.Anything, uint(1)).Return(&domain.MemberDebt{
+				Model:        gorm.Model{ID: 1},
+				GroupID:      4,
+				CreditorID:   1,
+				DebtorID:     2,
+				Amount:       money.FromFloat(110),
+				RepaidAmount: money.FromFloat(60),
+			}, nil)
+			tt.setupMocks(mockGroupRepo)
+
+			result, err := uc.RepayMemberDebt(context.TODO(), tt.groupID, 1, tt.amount)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(money.FromFloat(90), result.RepaidAmount)
+			s.Equal(money.FromFloat(20), result.OutstandingAmount)
+			mockGroupRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func TestBorrowerGroupUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(BorrowerGroupUsecaseSuite))
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+const (
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotentReplayHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// NewIdempotencyMiddleware makes the routes it guards safe to retry. The first
+// request sent with an Idempotency-Key header is processed and its response is
+// stored; a retry with the same key and body gets that response back without
+// being processed again. Requests without the header are processed as usual.
+func NewIdempotencyMiddleware(u domain.IdempotencyUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.CommonResponse{Message: "idempotency key must be at most 255 characters"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := u.BeginRequest(c.Request.Context(), key, fingerprint(c.Request, body))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrIdempotencyKeyReused):
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, dto.CommonResponse{Message: err.Error()})
+			case errors.Is(err, domain.ErrIdempotencyKeyInProgress):
+				c.AbortWithStatusJSON(http.StatusConflict, dto.CommonResponse{Message: err.Error()})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+			}
+			return
+		}
+
+		if stored != nil {
+			c.Header(IdempotentReplayHeader, "true")
+			c.Data(stored.StatusCode, "application/json; charset=utf-8", stored.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// The outcome is saved even if the handler panics, so the key is never
+		// left claimed by a request that is gone.
+		defer func() {
+			// The client may have hung up while waiting, which is exactly when
+			// it will retry, so the outcome is saved even if the request was
+			// cancelled.
+			ctx := context.WithoutCancel(c.Request.Context())
+
+			if r := recover(); r != nil {
+				releaseKey(ctx, u, key)
+				panic(r)
+			}
+
+			// Failed requests are rolled back, so the key is released to let
+			// the retry be processed rather than replaying the failure. The
+			// handlers answer business rule violations with a 500 as well;
+			// those are re-run on retry and fail the same way again, which is
+			// harmless since nothing was stored.
+			if recorder.Status() >= http.StatusInternalServerError {
+				releaseKey(ctx, u, key)
+				return
+			}
+
+			// The request went through, so the key is not released if its
+			// response cannot be stored: a retry then gets a conflict until
+			// the key is reconciled, rather than being processed twice.
+			if err := u.CompleteRequest(ctx, key, recorder.Status(), recorder.body.Bytes()); err != nil {
+				log.Printf("failed to store the response for idempotency key %q: %v", key, err)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+func releaseKey(ctx context.Context, u domain.IdempotencyUsecase, key string) {
+	if err := u.ReleaseKey(ctx, key); err != nil {
+		log.Printf("failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+// fingerprint identifies a request by its method, path and body, so a key
+// reused for another endpoint or another body is told apart from a retry.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body while it is written to
+// the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,232 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	idempotencyHttp "github.com/greekrode/loan-engine-amartha/idempotency/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRouter guards a route that echoes the request body back, failing or
+// panicking when the body asks it to, and counts how often it is reached.
+func setupRouter(mockUCase *mocks.IdempotencyUsecase, calls *int) *gin.Engine {
+	router := gin.Default()
+	router.POST("/payments/:loan_id", idempotencyHttp.NewIdempotencyMiddleware(mockUCase), func(c *gin.Context) {
+		*calls++
+		body, _ := io.ReadAll(c.Request.Body)
+		if strings.Contains(string(body), "panic") {
+			panic("handler panicked")
+		}
+		status := http.StatusOK
+		if strings.Contains(string(body), "fail") {
+			status = http.StatusInternalServerError
+		}
+		c.Data(status, "application/json; charset=utf-8", body)
+	})
+	return router
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		key              string
+		requestBody      string
+		mockUsecase      *mocks.IdempotencyUsecase
+		expectedStatus   int
+		expectedBody     string
+		expectedCalls    int
+		expectedReplayed string
+	}{
+		{
+			name:           "No Key",
+			requestBody:    `{"amount":100}`,
+			mockUsecase:    new(mocks.IdempotencyUsecase),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"amount":100}`,
+			expectedCalls:  1,
+		},
+		{
+			name:        "First Request",
+			key:         "abc-1",
+			requestBody: `{"amount":100}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, nil)
+				mockUsecase.On("CompleteRequest", mock.Anything, "abc-1", http.StatusOK, []byte(`{"amount":100}`)).Return(nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"amount":100}`,
+			expectedCalls:  1,
+		},
+		{
+			name:        "Retried Request",
+			key:         "abc-1",
+			requestBody: `{"amount":100}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(&domain.IdempotencyKey{
+					Key:          "abc-1",
+					StatusCode:   http.StatusOK,
+					ResponseBody: []byte(`{"payment_id":1}`),
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"payment_id":1}`,
+			expectedReplayed: "true",
+		},
+		{
+			name:        "Key Reused With Different Body",
+			key:         "abc-1",
+			requestBody: `{"amount":50}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, domain.ErrIdempotencyKeyReused)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"idempotency key was already used with a different request"}`,
+		},
+		{
+			name:        "First Request Still Being Processed",
+			key:         "abc-1",
+			requestBody: `{"amount":100}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, domain.ErrIdempotencyKeyInProgress)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"a request with this idempotency key has not been answered yet"}`,
+		},
+		{
+			name:        "Failed Request Releases Key",
+			key:         "abc-1",
+			requestBody: `{"message":"fail"}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, nil)
+				mockUsecase.On("ReleaseKey", mock.Anything, "abc-1").Return(nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"fail"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:        "Panicking Request Releases Key",
+			key:         "abc-1",
+			requestBody: `{"message":"panic"}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, nil)
+				mockUsecase.On("ReleaseKey", mock.Anything, "abc-1").Return(nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCalls:  1,
+		},
+		{
+			name:        "Error Storing Response Keeps Key Claimed",
+			key:         "abc-1",
+			requestBody: `{"amount":100}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, nil)
+				mockUsecase.On("CompleteRequest", mock.Anything, "abc-1", http.StatusOK, []byte(`{"amount":100}`)).Return(errors.New("database error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"amount":100}`,
+			expectedCalls:  1,
+		},
+		{
+			name:        "Error Claiming Key",
+			key:         "abc-1",
+			requestBody: `{"amount":100}`,
+			mockUsecase: func() *mocks.IdempotencyUsecase {
+				mockUsecase := new(mocks.IdempotencyUsecase)
+				mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Return(nil, errors.New("database error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"database error"}`,
+		},
+		{
+			name:           "Key Too Long",
+			key:            strings.Repeat("k", 256),
+			requestBody:    `{"amount":100}`,
+			mockUsecase:    new(mocks.IdempotencyUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"idempotency key must be at most 255 characters"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			router := setupRouter(tt.mockUsecase, &calls)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/payments/1", bytes.NewBufferString(tt.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set(idempotencyHttp.IdempotencyKeyHeader, tt.key)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedReplayed, rec.Header().Get(idempotencyHttp.IdempotentReplayHeader))
+			tt.mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareFingerprint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var fingerprints []string
+	mockUsecase := new(mocks.IdempotencyUsecase)
+	mockUsecase.On("BeginRequest", mock.Anything, "abc-1", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		fingerprints = append(fingerprints, args.String(2))
+	}).Return(nil, domain.ErrIdempotencyKeyReused)
+
+	calls := 0
+	router := setupRouter(mockUsecase, &calls)
+	for _, request := range []struct{ path, body string }{
+		{"/payments/1", `{"amount":100}`},
+		{"/payments/1", `{"amount":100}`},
+		{"/payments/1", `{"amount":50}`},
+		{"/payments/2", `{"amount":100}`},
+	} {
+		req, err := http.NewRequestWithContext(context.TODO(), "POST", request.path, bytes.NewBufferString(request.body))
+		require.NoError(t, err)
+		req.Header.Set(idempotencyHttp.IdempotencyKeyHeader, "abc-1")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, fingerprints, 4)
+	assert.Equal(t, fingerprints[0], fingerprints[1])
+	assert.NotEqual(t, fingerprints[0], fingerprints[2])
+	assert.NotEqual(t, fingerprints[0], fingerprints[3])
+}
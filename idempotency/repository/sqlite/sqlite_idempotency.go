@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqliteIdempotencyRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteIdempotencyRepository(tm db.TransactionManager) *sqliteIdempotencyRepository {
+	return &sqliteIdempotencyRepository{TransactionManager: tm}
+}
+
+// CreateIdempotencyKey stores the key unless another request has stored it
+// first, and reports whether it was stored.
+func (s *sqliteIdempotencyRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoNothing: true,
+	}).Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (s *sqliteIdempotencyRepository) FindIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	var idempotencyKey domain.IdempotencyKey
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where(&domain.IdempotencyKey{Key: key}).First(&idempotencyKey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Idempotency key not found")
+		}
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (s *sqliteIdempotencyRepository) UpdateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Save(key).Error
+}
+
+// DeleteIdempotencyKey removes the key for good, so the next request sent
+// with it is processed as a new one.
+func (s *sqliteIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, key string, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Unscoped().Where(&domain.IdempotencyKey{Key: key}).Delete(&domain.IdempotencyKey{}).Error
+}
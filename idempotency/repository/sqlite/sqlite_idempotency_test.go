@@ -0,0 +1,104 @@
+package sqlite_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/idempotency/repository/sqlite"
+	"github.com/greekrode/loan-engine-amartha/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyRepositorySuite struct {
+	suite.Suite
+	tm   db.TransactionManager
+	mock sqlmock.Sqlmock
+}
+
+func (s *IdempotencyRepositorySuite) SetupSuite() {
+	var err error
+	s.tm, s.mock, err = utils.SetupMockDB(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *IdempotencyRepositorySuite) AfterTest(_, _ string) {
+	s.Require().NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositorySuite) TestCreateIdempotencyKey() {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expected     bool
+	}{
+		{
+			name:         "Stores New Key",
+			rowsAffected: 1,
+			expected:     true,
+		},
+		{
+			name:         "Key Already Stored",
+			rowsAffected: 0,
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.mock.ExpectBegin()
+			s.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotency_keys`")+".*"+regexp.QuoteMeta("ON CONFLICT (`key`) DO NOTHING")).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "abc-1", "f1", 0, []byte(nil)).
+				WillReturnResult(sqlmock.NewResult(1, tt.rowsAffected))
+			s.mock.ExpectCommit()
+
+			repo := sqlite.NewSQLiteIdempotencyRepository(s.tm)
+			created, err := repo.CreateIdempotencyKey(context.TODO(), &domain.IdempotencyKey{Key: "abc-1", Fingerprint: "f1"}, nil)
+			s.NoError(err)
+			s.Equal(tt.expected, created)
+		})
+	}
+}
+
+func (s *IdempotencyRepositorySuite) TestFindIdempotencyKey() {
+	query := regexp.QuoteMeta("SELECT * FROM `idempotency_keys` WHERE `idempotency_keys`.`key` = ? AND `idempotency_keys`.`deleted_at` IS NULL ORDER BY `idempotency_keys`.`id` LIMIT 1")
+
+	s.Run("Found", func() {
+		s.mock.ExpectQuery(query).WithArgs("abc-1").WillReturnRows(sqlmock.NewRows([]string{"id", "key", "fingerprint", "status_code", "response_body"}).
+			AddRow(1, "abc-1", "f1", 201, []byte(`{"id":1}`)))
+
+		repo := sqlite.NewSQLiteIdempotencyRepository(s.tm)
+		key, err := repo.FindIdempotencyKey(context.TODO(), "abc-1")
+		s.NoError(err)
+		s.Equal("f1", key.Fingerprint)
+		s.Equal(201, key.StatusCode)
+		s.Equal([]byte(`{"id":1}`), key.ResponseBody)
+	})
+
+	s.Run("Not Found", func() {
+		s.mock.ExpectQuery(query).WithArgs("abc-2").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		repo := sqlite.NewSQLiteIdempotencyRepository(s.tm)
+		key, err := repo.FindIdempotencyKey(context.TODO(), "abc-2")
+		s.EqualError(err, "Idempotency key not found")
+		s.Nil(key)
+	})
+}
+
+func (s *IdempotencyRepositorySuite) TestDeleteIdempotencyKey() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotency_keys` WHERE `idempotency_keys`.`key` = ?")).
+		WithArgs("abc-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	repo := sqlite.NewSQLiteIdempotencyRepository(s.tm)
+	s.NoError(repo.DeleteIdempotencyKey(context.TODO(), "abc-1", nil))
+}
+
+func TestIdempotencyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepositorySuite))
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+)
+
+type idempotencyUsecase struct {
+	idempotencyRepo domain.IdempotencyRepository
+	contextTimeout  time.Duration
+}
+
+func NewIdempotencyUsecase(i domain.IdempotencyRepository, timeout time.Duration) domain.IdempotencyUsecase {
+	return &idempotencyUsecase{
+		idempotencyRepo: i,
+		contextTimeout:  timeout,
+	}
+}
+
+// BeginRequest claims the key for the request with the given fingerprint. It
+// returns nil once the key is claimed and the request should be processed, or
+// the stored key when the same request has already been answered and its
+// response should be replayed. A key first used for a different request, or
+// whose first request has not been answered, is refused. The first request may
+// still be running, or may have been lost after its changes were committed, so
+// it is never processed again under the same key.
+func (i *idempotencyUsecase) BeginRequest(ctx context.Context, key, fingerprint string) (*domain.IdempotencyKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	created, err := i.idempotencyRepo.CreateIdempotencyKey(ctx, &domain.IdempotencyKey{
+		Key:         key,
+		Fingerprint: fingerprint,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		return nil, nil
+	}
+
+	existing, err := i.idempotencyRepo.FindIdempotencyKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+
+	return existing, nil
+}
+
+// CompleteRequest stores the response to the request that claimed the key.
+func (i *idempotencyUsecase) CompleteRequest(ctx context.Context, key string, statusCode int, responseBody []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	existing, err := i.idempotencyRepo.FindIdempotencyKey(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	existing.StatusCode = statusCode
+	existing.ResponseBody = responseBody
+
+	return i.idempotencyRepo.UpdateIdempotencyKey(ctx, existing, nil)
+}
+
+// ReleaseKey gives up the claim on a key whose request failed without being
+// processed, so a retry with the key is processed again.
+func (i *idempotencyUsecase) ReleaseKey(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	return i.idempotencyRepo.DeleteIdempotencyKey(ctx, key, nil)
+}
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	idempotencyUsecase "github.com/greekrode/loan-engine-amartha/idempotency/usecase"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type IdempotencyUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *IdempotencyUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *IdempotencyUsecaseSuite) TestBeginRequest() {
+	answered := &domain.IdempotencyKey{
+		Model:        gorm.Model{ID: 1},
+		Key:          "abc-1",
+		Fingerprint:  "f1",
+		StatusCode:   200,
+		ResponseBody: []byte(`{"payment_id":1}`),
+	}
+
+	tests := []struct {
+		name          string
+		fingerprint   string
+		setupMocks    func(*mocks.IdempotencyRepository)
+		expected      *domain.IdempotencyKey
+		expectedError error
+	}{
+		{
+			name:        "Claims New Key",
+			fingerprint: "f1",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, &domain.IdempotencyKey{Key: "abc-1", Fingerprint: "f1"}, mock.Anything).Return(true, nil)
+			},
+		},
+		{
+			name:        "Replays Answered Request",
+			fingerprint: "f1",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				mir.On("FindIdempotencyKey", mock.Anything, "abc-1").Return(answered, nil)
+			},
+			expected: answered,
+		},
+		{
+			name:        "Key Used For Different Request",
+			fingerprint: "f2",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				mir.On("FindIdempotencyKey", mock.Anything, "abc-1").Return(answered, nil)
+			},
+			expectedError: domain.ErrIdempotencyKeyReused,
+		},
+		{
+			name:        "First Request Still Being Processed",
+			fingerprint: "f1",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				mir.On("FindIdempotencyKey", mock.Anything, "abc-1").Return(&domain.IdempotencyKey{Model: gorm.Model{UpdatedAt: time.Now()}, Key: "abc-1", Fingerprint: "f1"}, nil)
+			},
+			expectedError: domain.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:        "First Request Lost Without An Answer",
+			fingerprint: "f1",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				mir.On("FindIdempotencyKey", mock.Anything, "abc-1").Return(&domain.IdempotencyKey{Model: gorm.Model{UpdatedAt: time.Now().Add(-24 * time.Hour)}, Key: "abc-1", Fingerprint: "f1"}, nil)
+			},
+			expectedError: domain.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:        "Error Creating Key",
+			fingerprint: "f1",
+			setupMocks: func(mir *mocks.IdempotencyRepository) {
+				mir.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockIdempotencyRepo := new(mocks.IdempotencyRepository)
+			tt.setupMocks(mockIdempotencyRepo)
+
+			uc := idempotencyUsecase.NewIdempotencyUsecase(mockIdempotencyRepo, s.timeout)
+			result, err := uc.BeginRequest(context.TODO(), "abc-1", tt.fingerprint)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				s.Nil(result)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.expected, result)
+			mockIdempotencyRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *IdempotencyUsecaseSuite) TestCompleteRequest() {
+	mockIdempotencyRepo := new(mocks.IdempotencyRepository)
+	mockIdempotencyRepo.On("FindIdempotencyKey", mock.Anything, "abc-1").Return(&domain.IdempotencyKey{Model: gorm.Model{ID: 1}, Key: "abc-1", Fingerprint: "f1"}, nil)
+	mockIdempotencyRepo.On("UpdateIdempotencyKey", mock.Anything, &domain.IdempotencyKey{
+		Model:        gorm.Model{ID: 1},
+		Key:          "abc-1",
+		Fingerprint:  "f1",
+		StatusCode:   201,
+		ResponseBody: []byte(`{"id":3}`),
+	}, mock.Anything).Return(nil)
+
+	uc := idempotencyUsecase.NewIdempotencyUsecase(mockIdempotencyRepo, s.timeout)
+	s.NoError(uc.CompleteRequest(context.TODO(), "abc-1", 201, []byte(`{"id":3}`)))
+	mockIdempotencyRepo.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyUsecaseSuite) TestReleaseKey() {
+	mockIdempotencyRepo := new(mocks.IdempotencyRepository)
+	mockIdempotencyRepo.On("DeleteIdempotencyKey", mock.Anything, "abc-1", mock.Anything).Return(nil)
+
+	uc := idempotencyUsecase.NewIdempotencyUsecase(mockIdempotencyRepo, s.timeout)
+	s.NoError(uc.ReleaseKey(context.TODO(), "abc-1"))
+	mockIdempotencyRepo.AssertExpectations(s.T())
+}
+
+func TestIdempotencyUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyUsecaseSuite))
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type InvestorHandler struct {
+	InvestorUsecase domain.InvestorUsecase
+}
+
+func NewInvestorHandler(g *gin.Engine, i domain.InvestorUsecase) {
+	handler := &InvestorHandler{InvestorUsecase: i}
+
+	g.POST("/investors", handler.CreateInvestor)
+	g.GET("/investors/:investor_id", handler.GetInvestor)
+	g.POST("/loans/:loan_id/investments", handler.InvestInLoan)
+	g.GET("/loans/:loan_id/investments", handler.GetLoanInvestments)
+}
+
+func (i *InvestorHandler) CreateInvestor(c *gin.Context) {
+	var req dto.CreateInvestorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Email) == "" {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "name and email are required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	investorResponse, err := i.InvestorUsecase.CreateInvestor(ctx, domain.Investor{Name: req.Name, Email: req.Email})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, investorResponse)
+}
+
+func (i *InvestorHandler) GetInvestor(c *gin.Context) {
+	investorID, err := strconv.ParseUint(c.Param("investor_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid investor ID format"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	investorResponse, err := i.InvestorUsecase.GetInvestor(ctx, uint(investorID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, investorResponse)
+}
+
+func (i *InvestorHandler) InvestInLoan(c *gin.Context) {
+	loanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return
+	}
+
+	var req dto.InvestInLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.InvestorID == 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "investor ID is required"})
+		return
+	}
+
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "investment amount must be positive"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	investmentResponse, err := i.InvestorUsecase.InvestInLoan(ctx, uint(loanID), req.InvestorID, req.Amount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, investmentResponse)
+}
+
+func (i *InvestorHandler) GetLoanInvestments(c *gin.Context) {
+	loanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	investmentsResponse, err := i.InvestorUsecase.GetLoanInvestments(ctx, uint(loanID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, investmentsResponse)
+}
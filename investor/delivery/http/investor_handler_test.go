@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	investorHttp "github.com/greekrode/loan-engine-amartha/investor/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.InvestorUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := investorHttp.InvestorHandler{
+		InvestorUsecase: mockUCase,
+	}
+	router.POST("/investors", handler.CreateInvestor)
+	router.POST("/loans/:loan_id/investments", handler.InvestInLoan)
+	return router
+}
+
+func TestCreateInvestor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockUsecase    *mocks.InvestorUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Investor",
+			requestBody: `{"name": "Jane Roe", "email": "jane@example.com"}`,
+			mockUsecase: func() *mocks.InvestorUsecase {
+				mockUsecase := new(mocks.InvestorUsecase)
+				mockUsecase.On("CreateInvestor", mock.Anything, domain.Investor{Name: "Jane Roe", Email: "jane@example.com"}).Return(&dto.GetInvestorResponse{
+					ID:          1,
+					Name:        "Jane Roe",
+					Email:       "jane@example.com",
+					Investments: []dto.GetLoanInvestmentResponse{},
+					Returns:     []dto.GetInvestorReturnResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id": 1, "name": "Jane Roe", "email": "jane@example.com", "created_at": "0001-01-01T00:00:00Z", "investments": [], "total_return": 0, "returns": []}`,
+		},
+		{
+			name:           "Missing Email",
+			requestBody:    `{"name": "Jane Roe"}`,
+			mockUsecase:    new(mocks.InvestorUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"name and email are required"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/investors", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestInvestInLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.InvestorUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Investment",
+			loanID:      "1",
+			requestBody: `{"investor_id": 2, "amount": 400}`,
+			mockUsecase: func() *mocks.InvestorUsecase {
+				mockUsecase := new(mocks.InvestorUsecase)
+				mockUsecase.On("InvestInLoan", mock.Anything, uint(1), uint(2), money.FromFloat(400)).Return(&dto.InvestInLoanResponse{
+					Investment:    dto.GetLoanInvestmentResponse{ID: 5, LoanID: 1, InvestorID: 2, Amount: money.FromFloat(400)},
+					TotalInvested: money.FromFloat(1000),
+					Remaining:     0,
+					LoanStatus:    "invested",
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{
+				"investment": {"id": 5, "loan_id": 1, "investor_id": 2, "amount": 400, "created_at": "0001-01-01T00:00:00Z"},
+				"total_invested": 1000,
+				"remaining": 0,
+				"loan_status": "invested"
+			}`,
+		},
+		{
+			name:           "Invalid Loan ID",
+			loanID:         "abc",
+			requestBody:    `{"investor_id": 2, "amount": 400}`,
+			mockUsecase:    new(mocks.InvestorUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid loan ID format"}`,
+		},
+		{
+			name:           "Non Positive Amount",
+			loanID:         "1",
+			requestBody:    `{"investor_id": 2, "amount": 0}`,
+			mockUsecase:    new(mocks.InvestorUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"investment amount must be positive"}`,
+		},
+		{
+			name:        "Over Investment",
+			loanID:      "1",
+			requestBody: `{"investor_id": 2, "amount": 400}`,
+			mockUsecase: func() *mocks.InvestorUsecase {
+				mockUsecase := new(mocks.InvestorUsecase)
+				mockUsecase.On("InvestInLoan", mock.Anything, uint(1), uint(2), money.FromFloat(400)).Return(nil, errors.New("investment exceeds the remaining principal of 300.00"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"investment exceeds the remaining principal of 300.00"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/investments", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
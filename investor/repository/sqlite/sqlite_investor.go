@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
+)
+
+type sqliteInvestorRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteInvestorRepository(tm db.TransactionManager) *sqliteInvestorRepository {
+	return &sqliteInvestorRepository{TransactionManager: tm}
+}
+
+func (s *sqliteInvestorRepository) CreateInvestor(ctx context.Context, investor *domain.Investor, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(investor).Error
+}
+
+func (s *sqliteInvestorRepository) FindInvestorByID(ctx context.Context, investorID uint) (*domain.Investor, error) {
+	var investor domain.Investor
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Investments").Preload("Returns").First(&investor, investorID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Investor not found")
+		}
+		return nil, err
+	}
+
+	return &investor, nil
+}
+
+func (s *sqliteInvestorRepository) CreateInvestment(ctx context.Context, investment *domain.LoanInvestment, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(investment).Error
+}
+
+func (s *sqliteInvestorRepository) GetInvestmentsByLoanID(ctx context.Context, loanID uint) ([]domain.LoanInvestment, error) {
+	var investments []domain.LoanInvestment
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("loan_id = ?", loanID).Order("id").Find(&investments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return investments, nil
+}
+
+// SumInvestmentsByLoanID totals the investments in a loan. Reading through the
+// funding transaction lets the caller see its own uncommitted investment.
+func (s *sqliteInvestorRepository) SumInvestmentsByLoanID(ctx context.Context, loanID uint, tx *gorm.DB) (money.Money, error) {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	var total money.Money
+	err := tx.WithContext(ctx).Model(&domain.LoanInvestment{}).Where("loan_id = ?", loanID).Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (s *sqliteInvestorRepository) CreateInvestorReturns(ctx context.Context, returns []domain.InvestorReturn, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&returns).Error
+}
+
+func (s *sqliteInvestorRepository) GetReturnsByPaymentID(ctx context.Context, paymentID uint) ([]domain.InvestorReturn, error) {
+	var returns []domain.InvestorReturn
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("payment_id = ?", paymentID).Order("id").Find(&returns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return returns, nil
+}
@@ -0,0 +1,82 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/greekrode/loan-engine-amartha/investor/repository/sqlite"
+	"github.com/greekrode/loan-engine-amartha/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvestorRepositorySuite struct {
+	suite.Suite
+	tm   db.TransactionManager
+	mock sqlmock.Sqlmock
+}
+
+func (s *InvestorRepositorySuite) SetupSuite() {
+	var err error
+	s.tm, s.mock, err = utils.SetupMockDB(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *InvestorRepositorySuite) AfterTest(_, _ string) {
+	s.Require().NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *InvestorRepositorySuite) TestSumInvestmentsByLoanID() {
+	sumQuery := regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM `loan_investments` WHERE loan_id = ? AND `loan_investments`.`deleted_at` IS NULL")
+
+	tests := []struct {
+		name     string
+		setup    func()
+		expected money.Money
+		wantErr  bool
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				s.mock.ExpectQuery(sumQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(70000))
+			},
+			expected: money.FromFloat(700),
+		},
+		{
+			name: "No Investments",
+			setup: func() {
+				s.mock.ExpectQuery(sumQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(0))
+			},
+			expected: 0,
+		},
+		{
+			name: "DatabaseError",
+			setup: func() {
+				s.mock.ExpectQuery(sumQuery).WithArgs(1).WillReturnError(fmt.Errorf("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setup()
+			repo := sqlite.NewSQLiteInvestorRepository(s.tm)
+			got, err := repo.SumInvestmentsByLoanID(context.TODO(), 1, nil)
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInvestorRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InvestorRepositorySuite))
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type investorUsecase struct {
+	investorRepo       domain.InvestorRepository
+	loanRepo           domain.LoanRepository
+	transactionManager db.TransactionManager
+	contextTimeout     time.Duration
+}
+
+func NewInvestorUsecase(i domain.InvestorRepository, l domain.LoanRepository, tm db.TransactionManager, timeout time.Duration) domain.InvestorUsecase {
+	return &investorUsecase{
+		investorRepo:       i,
+		loanRepo:           l,
+		transactionManager: tm,
+		contextTimeout:     timeout,
+	}
+}
+
+func (i *investorUsecase) CreateInvestor(ctx context.Context, investor domain.Investor) (*dto.GetInvestorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	err := i.investorRepo.CreateInvestor(ctx, &investor, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleInvestorResponse(&investor), nil
+}
+
+func (i *investorUsecase) GetInvestor(ctx context.Context, investorID uint) (*dto.GetInvestorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	investor, err := i.investorRepo.FindInvestorByID(ctx, investorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleInvestorResponse(investor), nil
+}
+
+// InvestInLoan funds part of an approved loan. The investment is written
+// before the loan total is re-read in the same transaction, so two investors
+// racing for the last part of a loan cannot both succeed. The loan moves to
+// invested once its principal is fully funded.
+func (i *investorUsecase) InvestInLoan(ctx context.Context, loanID uint, investorID uint, amount money.Money) (*dto.InvestInLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	_, err := i.investorRepo.FindInvestorByID(ctx, investorID)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := i.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != domain.LoanApproved {
+		return nil, fmt.Errorf("loan is not open for investment, loan is %s", loan.Status)
+	}
+
+	tx := i.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			i.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	investment := domain.LoanInvestment{
+		LoanID:     loan.ID,
+		InvestorID: investorID,
+		Amount:     amount,
+	}
+	err = i.investorRepo.CreateInvestment(ctx, &investment, tx)
+	if err != nil {
+		i.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	totalInvested, err := i.investorRepo.SumInvestmentsByLoanID(ctx, loan.ID, tx)
+	if err != nil {
+		i.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if totalInvested > loan.Principal {
+		i.transactionManager.Rollback(tx)
+		return nil, fmt.Errorf("investment exceeds the remaining principal of %s", loan.Principal-(totalInvested-amount))
+	}
+
+	if totalInvested == loan.Principal {
+		change, err := loan.TransitionTo(domain.LoanInvested)
+		if err != nil {
+			i.transactionManager.Rollback(tx)
+			return nil, err
+		}
+
+		err = i.loanRepo.UpdateLoan(ctx, loan, tx)
+		if err != nil {
+			i.transactionManager.Rollback(tx)
+			return nil, err
+		}
+
+		err = i.loanRepo.CreateStatusChange(ctx, change, tx)
+		if err != nil {
+			i.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = i.transactionManager.Commit(tx)
+	if err != nil {
+		i.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return &dto.InvestInLoanResponse{
+		Investment:    assembleInvestmentResponse(investment),
+		TotalInvested: totalInvested,
+		Remaining:     loan.Principal - totalInvested,
+		LoanStatus:    string(loan.Status),
+	}, nil
+}
+
+func (i *investorUsecase) GetLoanInvestments(ctx context.Context, loanID uint) (*dto.GetLoanInvestmentsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	loan, err := i.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	investments, err := i.investorRepo.GetInvestmentsByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalInvested money.Money
+	for _, investment := range investments {
+		totalInvested += investment.Amount
+	}
+
+	return &dto.GetLoanInvestmentsResponse{
+		LoanID:        loan.ID,
+		Principal:     loan.Principal,
+		TotalInvested: totalInvested,
+		Remaining:     loan.Principal - totalInvested,
+		Investments:   assembleInvestmentResponses(investments),
+	}, nil
+}
+
+func assembleInvestmentResponse(investment domain.LoanInvestment) dto.GetLoanInvestmentResponse {
+	return dto.GetLoanInvestmentResponse{
+		ID:         investment.ID,
+		LoanID:     investment.LoanID,
+		InvestorID: investment.InvestorID,
+		Amount:     investment.Amount,
+		CreatedAt:  investment.CreatedAt,
+	}
+}
+
+func assembleInvestmentResponses(investments []domain.LoanInvestment) []dto.GetLoanInvestmentResponse {
+	investmentResponses := make([]dto.GetLoanInvestmentResponse, len(investments))
+	for i, investment := range investments {
+		investmentResponses[i] = assembleInvestmentResponse(investment)
+	}
+
+	return investmentResponses
+}
+
+func assembleInvestorResponse(investor *domain.Investor) *dto.GetInvestorResponse {
+	var totalReturn money.Money
+	returnResponses := make([]dto.GetInvestorReturnResponse, len(investor.Returns))
+	for i, r := range investor.Returns {
+		totalReturn += r.NetAmount
+		returnResponses[i] = dto.GetInvestorReturnResponse{
+			PaymentID:  r.PaymentID,
+			LoanID:     r.LoanID,
+			Principal:  r.Principal,
+			Interest:   r.Interest,
+			ServiceFee: r.ServiceFee,
+			NetAmount:  r.NetAmount,
+			CreatedAt:  r.CreatedAt,
+		}
+	}
+
+	return &dto.GetInvestorResponse{
+		ID:          investor.ID,
+		Name:        investor.Name,
+		Email:       investor.Email,
+		CreatedAt:   investor.CreatedAt,
+		Investments: assembleInvestmentResponses(investor.Investments),
+		TotalReturn: totalReturn,
+		Returns:     returnResponses,
+	}
+}
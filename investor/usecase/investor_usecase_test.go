@@ -0,0 +1,168 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	investorUsecase "github.com/greekrode/loan-engine-amartha/investor/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type InvestorUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *InvestorUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *InvestorUsecaseSuite) TestInvestInLoan() {
+	tests := []struct {
+		name          string
+		amount        money.Money
+		loan          *domain.Loan
+		setupMocks    func(*mocks.InvestorRepository, *mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.InvestInLoanResponse
+		expectedError error
+	}{
+		{
+			name:   "Partial Investment",
+			amount: money.FromFloat(400),
+			loan:   &domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000), Status: domain.LoanApproved},
+			setupMocks: func(mir *mocks.InvestorRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mir.On("CreateInvestment", mock.Anything, &domain.LoanInvestment{LoanID: 1, InvestorID: 2, Amount: money.FromFloat(400)}, mock.Anything).Return(nil)
+				mir.On("SumInvestmentsByLoanID", mock.Anything, uint(1), mock.Anything).Return(money.FromFloat(700), nil)
+			},
+			expected: &dto.InvestInLoanResponse{
+				Investment:    dto.GetLoanInvestmentResponse{LoanID: 1, InvestorID: 2, Amount: money.FromFloat(400)},
+				TotalInvested: money.FromFloat(700),
+				Remaining:     money.FromFloat(300),
+				LoanStatus:    "approved",
+			},
+		},
+		{
+			name:   "Investment Completes Funding",
+			amount: money.FromFloat(300),
+			loan:   &domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000), Status: domain.LoanApproved},
+			setupMocks: func(mir *mocks.InvestorRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mir.On("CreateInvestment", mock.Anything, mock.AnythingOfType("*domain.LoanInvestment"), mock.Anything).Return(nil)
+				mir.On("SumInvestmentsByLoanID", mock.Anything, uint(1), mock.Anything).Return(money.FromFloat(1000), nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Status == domain.LoanInvested
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanApproved,
+					ToStatus:   domain.LoanInvested,
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.InvestInLoanResponse{
+				Investment:    dto.GetLoanInvestmentResponse{LoanID: 1, InvestorID: 2, Amount: money.FromFloat(300)},
+				TotalInvested: money.FromFloat(1000),
+				Remaining:     0,
+				LoanStatus:    "invested",
+			},
+		},
+		{
+			name:   "Over Investment Is Rolled Back",
+			amount: money.FromFloat(500),
+			loan:   &domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000), Status: domain.LoanApproved},
+			setupMocks: func(mir *mocks.InvestorRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mir.On("CreateInvestment", mock.Anything, mock.AnythingOfType("*domain.LoanInvestment"), mock.Anything).Return(nil)
+				mir.On("SumInvestmentsByLoanID", mock.Anything, uint(1), mock.Anything).Return(money.FromFloat(1200), nil)
+			},
+			expectedError: errors.New("investment exceeds the remaining principal of 300.00"),
+		},
+		{
+			name:          "Loan Not Approved",
+			amount:        money.FromFloat(500),
+			loan:          &domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000), Status: domain.LoanProposed},
+			setupMocks:    func(mir *mocks.InvestorRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {},
+			expectedError: errors.New("loan is not open for investment, loan is proposed"),
+		},
+		{
+			name:   "Error Creating Investment",
+			amount: money.FromFloat(500),
+			loan:   &domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000), Status: domain.LoanApproved},
+			setupMocks: func(mir *mocks.InvestorRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mir.On("CreateInvestment", mock.Anything, mock.AnythingOfType("*domain.LoanInvestment"), mock.Anything).Return(errors.New("error creating investment"))
+			},
+			expectedError: errors.New("error creating investment"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockInvestorRepo := new(mocks.InvestorRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := investorUsecase.NewInvestorUsecase(mockInvestorRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+
+			mockInvestorRepo.On("FindInvestorByID", mock.Anything, uint(2)).Return(&domain.Investor{Model: gorm.Model{ID: 2}}, nil)
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			tt.setupMocks(mockInvestorRepo, mockLoanRepo, mockTransactionManager)
+
+			result, err := uc.InvestInLoan(context.TODO(), 1, 2, tt.amount)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+				mockTransactionManager.AssertNotCalled(s.T(), "Commit", mock.Anything)
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockInvestorRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *InvestorUsecaseSuite) TestGetLoanInvestments() {
+	mockInvestorRepo := new(mocks.InvestorRepository)
+	mockLoanRepo := new(mocks.LoanRepository)
+	mockTransactionManager := new(mocks.TransactionManager)
+
+	mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{Model: gorm.Model{ID: 1}, Principal: money.FromFloat(1000)}, nil)
+	mockInvestorRepo.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+		{Model: gorm.Model{ID: 1}, LoanID: 1, InvestorID: 2, Amount: money.FromFloat(250)},
+		{Model: gorm.Model{ID: 2}, LoanID: 1, InvestorID: 3, Amount: money.FromFloat(150)},
+	}, nil)
+
+	uc := investorUsecase.NewInvestorUsecase(mockInvestorRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+
+	result, err := uc.GetLoanInvestments(context.TODO(), 1)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &dto.GetLoanInvestmentsResponse{
+		LoanID:        1,
+		Principal:     money.FromFloat(1000),
+		TotalInvested: money.FromFloat(400),
+		Remaining:     money.FromFloat(600),
+		Investments: []dto.GetLoanInvestmentResponse{
+			{ID: 1, LoanID: 1, InvestorID: 2, Amount: money.FromFloat(250)},
+			{ID: 2, LoanID: 1, InvestorID: 3, Amount: money.FromFloat(150)},
+		},
+	}, result)
+}
+
+func TestInvestorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(InvestorUsecaseSuite))
+}
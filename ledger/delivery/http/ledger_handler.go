@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type LedgerHandler struct {
+	LedgerUsecase domain.LedgerUsecase
+}
+
+func NewLedgerHandler(g *gin.Engine, l domain.LedgerUsecase) {
+	handler := &LedgerHandler{LedgerUsecase: l}
+
+	g.GET("/ledger/trial-balance", handler.GetTrialBalance)
+}
+
+// GetTrialBalance reports the ledger as of the date in the query string, or
+// as of today when none is given.
+func (l *LedgerHandler) GetTrialBalance(c *gin.Context) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("date"); query != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	response, err := l.LedgerUsecase.GetTrialBalance(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
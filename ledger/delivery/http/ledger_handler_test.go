@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	ledgerHttp "github.com/greekrode/loan-engine-amartha/ledger/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.LedgerUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := ledgerHttp.LedgerHandler{
+		LedgerUsecase: mockUCase,
+	}
+	router.GET("/ledger/trial-balance", handler.GetTrialBalance)
+	return router
+}
+
+func TestGetTrialBalance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	date := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockUsecase    *mocks.LedgerUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Valid Request",
+			query: "?date=2024-03-31",
+			mockUsecase: func() *mocks.LedgerUsecase {
+				mockUsecase := new(mocks.LedgerUsecase)
+				mockUsecase.On("GetTrialBalance", mock.Anything, date).Return(&dto.TrialBalanceResponse{
+					Date:        date,
+					TotalDebit:  money.FromFloat(1000),
+					TotalCredit: money.FromFloat(1000),
+					Balanced:    true,
+					Accounts: []dto.TrialBalanceAccountResponse{
+						{Code: "1000", Name: "Cash", Type: "asset", Credit: money.FromFloat(1000), Balance: money.FromFloat(-1000)},
+						{Code: "1100", Name: "Loans receivable", Type: "asset", Debit: money.FromFloat(1000), Balance: money.FromFloat(1000)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"date": "2024-03-31T00:00:00Z",
+				"total_debit": 1000,
+				"total_credit": 1000,
+				"balanced": true,
+				"accounts": [
+					{"code": "1000", "name": "Cash", "type": "asset", "debit": 0, "credit": 1000, "balance": -1000},
+					{"code": "1100", "name": "Loans receivable", "type": "asset", "debit": 1000, "credit": 0, "balance": 1000}
+				]
+			}`,
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?date=31-03-2024",
+			mockUsecase:    new(mocks.LedgerUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:  "Usecase Error",
+			query: "?date=2024-03-31",
+			mockUsecase: func() *mocks.LedgerUsecase {
+				mockUsecase := new(mocks.LedgerUsecase)
+				mockUsecase.On("GetTrialBalance", mock.Anything, date).Return(nil, errors.New("database error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"database error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", "/ledger/trial-balance"+tt.query, nil)
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqliteLedgerRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteLedgerRepository(tm db.TransactionManager) *sqliteLedgerRepository {
+	return &sqliteLedgerRepository{TransactionManager: tm}
+}
+
+// CreateJournalEntry stores the entry with its postings. Unbalanced entries
+// are refused so that the ledger always balances.
+func (s *sqliteLedgerRepository) CreateJournalEntry(ctx context.Context, entry *domain.JournalEntry, tx *gorm.DB) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(entry).Error
+}
+
+func (s *sqliteLedgerRepository) GetAccounts(ctx context.Context) ([]domain.Account, error) {
+	var accounts []domain.Account
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Order("code").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// GetAccountBalances totals the postings of every account over the journal
+// entries dated on or before date.
+func (s *sqliteLedgerRepository) GetAccountBalances(ctx context.Context, date time.Time) ([]domain.AccountBalance, error) {
+	var balances []domain.AccountBalance
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Model(&domain.Posting{}).
+		Select("postings.account_code, COALESCE(SUM(postings.debit), 0) AS debit, COALESCE(SUM(postings.credit), 0) AS credit").
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id AND journal_entries.deleted_at IS NULL").
+		Where("journal_entries.date < ?", date.AddDate(0, 0, 1)).
+		Group("postings.account_code").
+		Order("postings.account_code").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,85 @@
+package sqlite_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"github.com/greekrode/loan-engine-amartha/ledger/repository/sqlite"
+	"github.com/greekrode/loan-engine-amartha/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerRepositorySuite struct {
+	suite.Suite
+	tm   db.TransactionManager
+	mock sqlmock.Sqlmock
+}
+
+func (s *LedgerRepositorySuite) SetupSuite() {
+	var err error
+	s.tm, s.mock, err = utils.SetupMockDB(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *LedgerRepositorySuite) AfterTest(_, _ string) {
+	s.Require().NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *LedgerRepositorySuite) TestCreateJournalEntry() {
+	s.Run("Balanced Entry", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("INSERT INTO `journal_entries`").WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec("INSERT INTO `postings`").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, domain.AccountCash, money.FromFloat(100), 0,
+				sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, domain.AccountLoansReceivable, 0, money.FromFloat(100)).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		entry := &domain.JournalEntry{Date: time.Now(), Description: "Repayment on loan 1"}
+		entry.Debit(domain.AccountCash, money.FromFloat(100))
+		entry.Credit(domain.AccountLoansReceivable, money.FromFloat(100))
+
+		repo := sqlite.NewSQLiteLedgerRepository(s.tm)
+		err := repo.CreateJournalEntry(context.TODO(), entry, nil)
+		s.NoError(err)
+		s.Equal(uint(1), entry.ID)
+	})
+
+	s.Run("Unbalanced Entry", func() {
+		entry := &domain.JournalEntry{Date: time.Now(), Description: "Repayment on loan 1"}
+		entry.Debit(domain.AccountCash, money.FromFloat(100))
+
+		repo := sqlite.NewSQLiteLedgerRepository(s.tm)
+		err := repo.CreateJournalEntry(context.TODO(), entry, nil)
+		s.EqualError(err, "journal entry is not balanced: debits 100.00, credits 0.00")
+	})
+}
+
+func (s *LedgerRepositorySuite) TestGetAccountBalances() {
+	date := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	balancesQuery := regexp.QuoteMeta("SELECT postings.account_code, COALESCE(SUM(postings.debit), 0) AS debit, COALESCE(SUM(postings.credit), 0) AS credit FROM `postings` " +
+		"JOIN journal_entries ON journal_entries.id = postings.journal_entry_id AND journal_entries.deleted_at IS NULL " +
+		"WHERE journal_entries.date < ? AND `postings`.`deleted_at` IS NULL GROUP BY `postings`.`account_code` ORDER BY postings.account_code")
+
+	s.mock.ExpectQuery(balancesQuery).WithArgs(date.AddDate(0, 0, 1)).WillReturnRows(sqlmock.NewRows([]string{"account_code", "debit", "credit"}).
+		AddRow(domain.AccountCash, 10000, 95000).
+		AddRow(domain.AccountLoansReceivable, 100000, 10000))
+
+	repo := sqlite.NewSQLiteLedgerRepository(s.tm)
+	balances, err := repo.GetAccountBalances(context.TODO(), date)
+	s.NoError(err)
+	s.Equal([]domain.AccountBalance{
+		{AccountCode: domain.AccountCash, Debit: money.FromFloat(100), Credit: money.FromFloat(950)},
+		{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(1000), Credit: money.FromFloat(100)},
+	}, balances)
+}
+
+func TestLedgerRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LedgerRepositorySuite))
+}
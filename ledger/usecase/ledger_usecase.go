@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+)
+
+type ledgerUsecase struct {
+	ledgerRepo     domain.LedgerRepository
+	contextTimeout time.Duration
+}
+
+func NewLedgerUsecase(l domain.LedgerRepository, timeout time.Duration) domain.LedgerUsecase {
+	return &ledgerUsecase{
+		ledgerRepo:     l,
+		contextTimeout: timeout,
+	}
+}
+
+// GetTrialBalance lists every account with the debits and credits posted to
+// it up to the end of date. Balances are shown on the account's normal side.
+func (l *ledgerUsecase) GetTrialBalance(ctx context.Context, date time.Time) (*dto.TrialBalanceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	accounts, err := l.ledgerRepo.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := l.ledgerRepo.GetAccountBalances(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]domain.AccountBalance, len(balances))
+	for _, balance := range balances {
+		byCode[balance.AccountCode] = balance
+	}
+
+	response := &dto.TrialBalanceResponse{
+		Date:     date,
+		Accounts: make([]dto.TrialBalanceAccountResponse, len(accounts)),
+	}
+	for i, account := range accounts {
+		balance := byCode[account.Code]
+		response.TotalDebit += balance.Debit
+		response.TotalCredit += balance.Credit
+		response.Accounts[i] = dto.TrialBalanceAccountResponse{
+			Code:    account.Code,
+			Name:    account.Name,
+			Type:    string(account.Type),
+			Debit:   balance.Debit,
+			Credit:  balance.Credit,
+			Balance: normalBalance(account.Type, balance),
+		}
+	}
+	response.Balanced = response.TotalDebit == response.TotalCredit
+
+	return response, nil
+}
+
+func normalBalance(accountType domain.AccountType, balance domain.AccountBalance) money.Money {
+	if accountType.DebitNormal() {
+		return balance.Debit - balance.Credit
+	}
+	return balance.Credit - balance.Debit
+}
@@ -0,0 +1,96 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	ledgerUsecase "github.com/greekrode/loan-engine-amartha/ledger/usecase"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *LedgerUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *LedgerUsecaseSuite) TestGetTrialBalance() {
+	date := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	accounts := []domain.Account{
+		{Code: domain.AccountCash, Name: "Cash", Type: domain.AccountAsset},
+		{Code: domain.AccountLoansReceivable, Name: "Loans receivable", Type: domain.AccountAsset},
+		{Code: domain.AccountInterestIncome, Name: "Interest income", Type: domain.AccountIncome},
+		{Code: domain.AccountLoanLoss, Name: "Loan losses", Type: domain.AccountExpense},
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.LedgerRepository)
+		expected      *dto.TrialBalanceResponse
+		expectedError error
+	}{
+		{
+			name: "Balanced Ledger",
+			setupMocks: func(mlr *mocks.LedgerRepository) {
+				mlr.On("GetAccounts", mock.Anything).Return(accounts, nil)
+				mlr.On("GetAccountBalances", mock.Anything, date).Return([]domain.AccountBalance{
+					{AccountCode: domain.AccountCash, Debit: money.FromFloat(110), Credit: money.FromFloat(1000)},
+					{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(1000), Credit: money.FromFloat(100)},
+					{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10)},
+				}, nil)
+			},
+			expected: &dto.TrialBalanceResponse{
+				Date:        date,
+				TotalDebit:  money.FromFloat(1110),
+				TotalCredit: money.FromFloat(1110),
+				Balanced:    true,
+				Accounts: []dto.TrialBalanceAccountResponse{
+					{Code: domain.AccountCash, Name: "Cash", Type: "asset", Debit: money.FromFloat(110), Credit: money.FromFloat(1000), Balance: money.FromFloat(-890)},
+					{Code: domain.AccountLoansReceivable, Name: "Loans receivable", Type: "asset", Debit: money.FromFloat(1000), Credit: money.FromFloat(100), Balance: money.FromFloat(900)},
+					{Code: domain.AccountInterestIncome, Name: "Interest income", Type: "income", Credit: money.FromFloat(10), Balance: money.FromFloat(10)},
+					{Code: domain.AccountLoanLoss, Name: "Loan losses", Type: "expense"},
+				},
+			},
+		},
+		{
+			name: "Error Getting Balances",
+			setupMocks: func(mlr *mocks.LedgerRepository) {
+				mlr.On("GetAccounts", mock.Anything).Return(accounts, nil)
+				mlr.On("GetAccountBalances", mock.Anything, date).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			uc := ledgerUsecase.NewLedgerUsecase(mockLedgerRepo, s.timeout)
+
+			tt.setupMocks(mockLedgerRepo)
+			result, err := uc.GetTrialBalance(context.TODO(), date)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.expected, result)
+			mockLedgerRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func TestLedgerUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(LedgerUsecaseSuite))
+}
@@ -14,11 +14,23 @@ type LoanHandler struct {
 	LoanUsecase domain.LoanUsecase
 }
 
-func NewLoanHandler(g *gin.Engine, l domain.LoanUsecase) {
+func NewLoanHandler(g *gin.Engine, l domain.LoanUsecase, idempotent gin.HandlerFunc) {
 	handler := &LoanHandler{LoanUsecase: l}
-	g.POST("/loans", handler.CreateLoan)
+	g.POST("/loans", idempotent, handler.CreateLoan)
+	g.POST("/loans/quote", handler.QuoteLoan)
 	g.GET("/loans/:loan_id", handler.GetLoanDetails)
+	g.PUT("/loans/:loan_id", handler.UpdateLoan)
 	g.GET("/loans/:loan_id/outstanding", handler.GetOutstanding)
+	g.POST("/loans/:loan_id/approve", handler.ApproveLoan)
+	g.GET("/loans/:loan_id/approve", handler.GetLoanApproval)
+	g.POST("/loans/:loan_id/disburse", handler.DisburseLoan)
+	g.POST("/loans/:loan_id/close", handler.CloseLoan)
+	g.POST("/loans/:loan_id/cancel", handler.CancelLoan)
+	g.POST("/loans/:loan_id/write-off", handler.WriteOffLoan)
+	g.POST("/loans/:loan_id/restructure", handler.RestructureLoan)
+	g.POST("/loans/:loan_id/deferrals", handler.DeferInstallments)
+	g.POST("/loans/:loan_id/refinance", handler.RefinanceLoan)
+	g.GET("/reports/portfolio", handler.GetPortfolio)
 }
 
 func (l *LoanHandler) CreateLoan(c *gin.Context) {
@@ -28,14 +40,39 @@ func (l *LoanHandler) CreateLoan(c *gin.Context) {
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	terms, ok := bindLoanTerms(c, req)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	loanResponse, err := l.LoanUsecase.CreateLoan(ctx, req.BorrowerID, terms)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loanResponse)
+}
+
+func (l *LoanHandler) UpdateLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	terms, ok := bindLoanTerms(c, req)
+	if !ok {
 		return
 	}
 
 	ctx := c.Request.Context()
-	loanResponse, err := l.LoanUsecase.CreateLoan(ctx, req.BorrowerID, req.Principal, req.InterestRate, int32(req.Duration), startDate)
+	loanResponse, err := l.LoanUsecase.UpdateLoan(ctx, loanID, terms)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
 		return
@@ -43,6 +80,74 @@ func (l *LoanHandler) CreateLoan(c *gin.Context) {
 	c.JSON(http.StatusOK, loanResponse)
 }
 
+func (l *LoanHandler) QuoteLoan(c *gin.Context) {
+	var req dto.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	terms, ok := bindLoanTerms(c, req)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	quoteResponse, err := l.LoanUsecase.QuoteLoan(ctx, terms)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, quoteResponse)
+}
+
+// bindLoanTerms validates the pricing part of a loan request and fills in
+// defaults. It writes the error response itself and reports whether the
+// handler should continue.
+func bindLoanTerms(c *gin.Context, req dto.CreateLoanRequest) (domain.LoanTerms, bool) {
+	if req.ProductID == 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "product ID is required"})
+		return domain.LoanTerms{}, false
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+		return domain.LoanTerms{}, false
+	}
+
+	frequency := domain.FrequencyWeekly
+	if req.Frequency != "" {
+		frequency = domain.RepaymentFrequency(req.Frequency)
+	}
+	if !frequency.IsValid() {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid repayment frequency"})
+		return domain.LoanTerms{}, false
+	}
+
+	rollConvention := domain.RollUnadjusted
+	if req.Calendar != "" {
+		rollConvention = domain.RollFollowing
+	}
+	if req.RollConvention != "" {
+		rollConvention = domain.RollConvention(req.RollConvention)
+	}
+	if !rollConvention.IsValid() {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid roll convention"})
+		return domain.LoanTerms{}, false
+	}
+
+	return domain.LoanTerms{
+		ProductID:      req.ProductID,
+		Principal:      req.Principal,
+		Tenor:          req.Duration,
+		Frequency:      frequency,
+		StartDate:      startDate,
+		CalendarName:   req.Calendar,
+		RollConvention: rollConvention,
+	}, true
+}
+
 func (l *LoanHandler) GetLoanDetails(c *gin.Context) {
 	loanID := c.Param("loan_id")
 	parsedLoanID, err := strconv.ParseUint(loanID, 10, 32)
@@ -77,3 +182,244 @@ func (l *LoanHandler) GetOutstanding(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, dto.GetOutstandingResponse{OutstandingAmount: outstanding})
 }
+
+func (l *LoanHandler) ApproveLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ApproveLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	approvalDate, err := time.Parse("2006-01-02", req.ApprovalDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+		return
+	}
+
+	approval := domain.LoanApproval{
+		ValidatorID:       req.ValidatorID,
+		ApprovalDate:      approvalDate,
+		ProofOfVisitImage: req.ProofOfVisitImage,
+	}
+	if err := approval.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	approvalResponse, err := l.LoanUsecase.ApproveLoan(ctx, loanID, approval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, approvalResponse)
+}
+
+func (l *LoanHandler) GetLoanApproval(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	approvalResponse, err := l.LoanUsecase.GetLoanApproval(ctx, loanID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, approvalResponse)
+}
+
+func (l *LoanHandler) DisburseLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	changeResponse, err := l.LoanUsecase.DisburseLoan(ctx, loanID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeResponse)
+}
+
+func (l *LoanHandler) CloseLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	changeResponse, err := l.LoanUsecase.CloseLoan(ctx, loanID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeResponse)
+}
+
+func (l *LoanHandler) CancelLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	changeResponse, err := l.LoanUsecase.CancelLoan(ctx, loanID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changeResponse)
+}
+
+func (l *LoanHandler) WriteOffLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WriteOffLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Reason == "" {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "reason is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	writeOffResponse, err := l.LoanUsecase.WriteOffLoan(ctx, loanID, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, writeOffResponse)
+}
+
+func (l *LoanHandler) GetPortfolio(c *gin.Context) {
+	ctx := c.Request.Context()
+	portfolioResponse, err := l.LoanUsecase.GetPortfolio(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, portfolioResponse)
+}
+
+func (l *LoanHandler) RestructureLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RestructureLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Tenor < 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "tenor must be positive"})
+		return
+	}
+
+	if req.InterestRate != nil && *req.InterestRate < 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "interest rate must not be negative"})
+		return
+	}
+
+	terms := domain.RestructureTerms{
+		Tenor:             req.Tenor,
+		InterestRate:      req.InterestRate,
+		CapitalizeArrears: req.CapitalizeArrears,
+		Reason:            req.Reason,
+	}
+
+	ctx := c.Request.Context()
+	restructureResponse, err := l.LoanUsecase.RestructureLoan(ctx, loanID, terms)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, restructureResponse)
+}
+
+func (l *LoanHandler) DeferInstallments(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DeferInstallmentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	deferral := domain.LoanDeferral{
+		Installments: req.Installments,
+		Policy:       domain.DeferralPolicy(req.Policy),
+		Reason:       req.Reason,
+		ApproverID:   req.ApproverID,
+	}
+	if err := deferral.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	deferralResponse, err := l.LoanUsecase.DeferInstallments(ctx, loanID, deferral)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, deferralResponse)
+}
+
+func (l *LoanHandler) RefinanceLoan(c *gin.Context) {
+	loanID, ok := bindLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	terms, ok := bindLoanTerms(c, req)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	loanResponse, err := l.LoanUsecase.RefinanceLoan(ctx, loanID, terms)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loanResponse)
+}
+
+// bindLoanID parses the loan_id path parameter. It writes the error response
+// itself and reports whether the handler should continue.
+func bindLoanID(c *gin.Context) (uint, bool) {
+	loanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return 0, false
+	}
+
+	return uint(loanID), true
+}
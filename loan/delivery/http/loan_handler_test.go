@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
 	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	loanHttp "github.com/greekrode/loan-engine-amartha/loan/delivery/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,6 +40,54 @@ func setupRouter(mockUCase *mocks.LoanUsecase) *gin.Engine {
 		}
 		handler.CreateLoan(c)
 	})
+	router.POST("/loans/quote", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.QuoteLoan(c)
+	})
+	router.POST("/loans/:loan_id/approve", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.ApproveLoan(c)
+	})
+	router.PUT("/loans/:loan_id", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.UpdateLoan(c)
+	})
+	router.POST("/loans/:loan_id/restructure", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.RestructureLoan(c)
+	})
+	router.POST("/loans/:loan_id/deferrals", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.DeferInstallments(c)
+	})
+	router.POST("/loans/:loan_id/refinance", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.RefinanceLoan(c)
+	})
+	router.POST("/loans/:loan_id/write-off", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.WriteOffLoan(c)
+	})
+	router.GET("/reports/portfolio", func(c *gin.Context) {
+		handler := loanHttp.LoanHandler{
+			LoanUsecase: mockUCase,
+		}
+		handler.GetPortfolio(c)
+	})
 	return router
 }
 
@@ -57,12 +107,19 @@ func TestGetLoanDetails(t *testing.T) {
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
 				mockUsecase.On("GetLoanDetails", mock.Anything, uint(1)).Return(&dto.GetLoanDetailsResponse{
-					Principal:         100,
-					InterestRate:      10,
-					OutstandingAmount: 1000,
-					Duration:          52,
-					StartDate:         time.Time{},
-					CreatedAt:         time.Time{},
+					ProductID:          1,
+					Principal:          money.FromFloat(100),
+					TotalFees:          money.FromFloat(2),
+					NetDisbursed:       money.FromFloat(98),
+					Fees:               []dto.GetLoanFeeResponse{{Name: "Admin", Type: "percentage", Charge: "upfront", Amount: money.FromFloat(2)}},
+					InterestRate:       10,
+					OutstandingAmount:  money.FromFloat(1000),
+					Duration:           52,
+					Frequency:          "weekly",
+					AmortizationMethod: "flat",
+					StartDate:          time.Time{},
+					Status:             "disbursed",
+					CreatedAt:          time.Time{},
 					Borrower: dto.GetBorrowerResponse{
 						ID:        1,
 						FirstName: "John",
@@ -72,22 +129,37 @@ func TestGetLoanDetails(t *testing.T) {
 					},
 					PaymentSchedule: []dto.GetPaymentScheduleResponse{
 						{
-							DueAmount: 10,
+							DueAmount: money.FromFloat(10),
 							DueDate:   time.Time{},
 							Paid:      false,
+							Status:    "unpaid",
 						},
 					},
+					StatusHistory: []dto.LoanStatusChangeResponse{
+						{LoanID: 1, FromStatus: "invested", ToStatus: "disbursed", ChangedAt: time.Time{}},
+					},
 				},
 					nil)
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusOK,
 			expectedBody: `{
+				"product_id": 1,
 				"principal": 100,
+				"total_fees": 2,
+				"net_disbursed": 98,
+				"fees": [{"name": "Admin", "type": "percentage", "charge": "upfront", "amount": 2}],
 				"interest_rate": 10,
 				"outstanding_amount": 1000,
+				"credit_balance": 0,
+				"total_penalties": 0,
+				"written_off_amount": 0,
+				"recovered_amount": 0,
 				"duration": 52,
+				"frequency": "weekly",
+				"amortization_method": "flat",
 				"start_date": "0001-01-01T00:00:00Z",
+				"status": "disbursed",
 				"created_at": "0001-01-01T00:00:00Z",
 				"borrower": {
 					"id": 1,
@@ -99,9 +171,18 @@ func TestGetLoanDetails(t *testing.T) {
 				"payment_schedules": [
 					{
 						"due_amount": 10,
+						"principal_amount": 0,
+						"interest_amount": 0,
+						"fee_amount": 0,
+						"penalty_amount": 0,
+						"paid_amount": 0,
 						"due_date": "0001-01-01T00:00:00Z",
-						"paid": false
+						"paid": false,
+						"status": "unpaid"
 					}
+				],
+				"status_history": [
+					{"loan_id": 1, "from_status": "invested", "to_status": "disbursed", "changed_at": "0001-01-01T00:00:00Z"}
 				]
 			}`,
 		},
@@ -161,7 +242,7 @@ func TestGetOutstanding(t *testing.T) {
 			loanID: "1",
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(1000.00, nil)
+				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(money.FromFloat(1000), nil)
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusOK,
@@ -174,7 +255,7 @@ func TestGetOutstanding(t *testing.T) {
 			loanID: "abc",
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(0.00, nil)
+				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(money.Money(0), nil)
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusBadRequest,
@@ -185,7 +266,7 @@ func TestGetOutstanding(t *testing.T) {
 			loanID: "1",
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(0.00, errors.New("internal error"))
+				mockUsecase.On("GetOutstandingAmount", mock.Anything, uint(1)).Return(money.Money(0), errors.New("internal error"))
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusInternalServerError,
@@ -214,6 +295,14 @@ func TestCreateLoan(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID:      1,
+		Principal:      money.FromFloat(100),
+		Tenor:          52,
+		Frequency:      domain.FrequencyWeekly,
+		StartDate:      fixedTime,
+		RollConvention: domain.RollUnadjusted,
+	}
 
 	tests := []struct {
 		name           string
@@ -227,24 +316,31 @@ func TestCreateLoan(t *testing.T) {
 			requestBody: `{
 				"borrower_id": 1,
 				"principal": 100.00,
-				"interest_rate": 10.00,
+				"product_id": 1,
 				"duration": 52,
 				"start_date": "2023-01-01"
 			}`,
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("CreateLoan", mock.Anything, uint(1), 100.00, 10.00, int32(52), fixedTime).Return(&dto.CreateLoanResponse{
-					ID:                1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					Duration:          52,
-					StartDate:         fixedTime,
-					OutstandingAmount: 1000,
+				mockUsecase.On("CreateLoan", mock.Anything, uint(1), terms).Return(&dto.CreateLoanResponse{
+					ProductID:          1,
+					ID:                 1,
+					Principal:          money.FromFloat(100.00),
+					NetDisbursed:       money.FromFloat(100.00),
+					Fees:               []dto.GetLoanFeeResponse{},
+					InterestRate:       10.00,
+					Duration:           52,
+					Frequency:          "weekly",
+					AmortizationMethod: "flat",
+					StartDate:          fixedTime,
+					Status:             "proposed",
+					OutstandingAmount:  money.FromFloat(1000),
 					PaymentSchedules: []dto.GetPaymentScheduleResponse{
 						{
 							ID:        1,
-							DueAmount: 100,
+							DueAmount: money.FromFloat(100),
 							DueDate:   fixedTime,
+							Status:    "unpaid",
 						},
 					},
 				}, nil)
@@ -253,17 +349,30 @@ func TestCreateLoan(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody: `{
 				"id": 1,
+				"product_id": 1,
 				"principal": 100.00,
+				"total_fees": 0,
+				"net_disbursed": 100.00,
+				"fees": [],
 				"interest_rate": 10.00,
 				"duration": 52,
+				"frequency": "weekly",
+				"amortization_method": "flat",
+				"status": "proposed",
 				"outstanding_amount": 1000,
 				"start_date": "2023-01-01T00:00:00Z",
 				"payment_schedules": [
 					{
 						"id": 1,
 						"due_amount": 100.00,
+						"principal_amount": 0,
+						"interest_amount": 0,
+						"fee_amount": 0,
+						"penalty_amount": 0,
+						"paid_amount": 0,
 						"due_date": "2023-01-01T00:00:00Z",
-						"paid": false
+						"paid": false,
+						"status": "unpaid"
 					}
 				]
 			}`,
@@ -275,7 +384,7 @@ func TestCreateLoan(t *testing.T) {
 			}`,
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("CreateLoan", mock.Anything, uint(1), 100.00, 10.00, int32(52), fixedTime).Return(&dto.CreateLoanResponse{}, nil)
+				mockUsecase.On("CreateLoan", mock.Anything, uint(1), terms).Return(&dto.CreateLoanResponse{}, nil)
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusBadRequest,
@@ -286,30 +395,71 @@ func TestCreateLoan(t *testing.T) {
 			requestBody: `{
 				"borrower_id" : 1,
 				"principal": 100.00,
-				"interest_rate": 10.00,
+				"product_id": 1,
 				"duration": 52,
 				"start_date": "23-01-01"
 			}`,
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("CreateLoan", mock.Anything, uint(1), 100.00, 10.00, int32(52), fixedTime).Return(&dto.CreateLoanResponse{}, nil)
+				mockUsecase.On("CreateLoan", mock.Anything, uint(1), terms).Return(&dto.CreateLoanResponse{}, nil)
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
 		},
+		{
+			name: "Missing Product ID",
+			requestBody: `{
+				"borrower_id": 1,
+				"principal": 100.00,
+				"duration": 52,
+				"start_date": "2023-01-01"
+			}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"product ID is required"}`,
+		},
+		{
+			name: "Invalid Repayment Frequency",
+			requestBody: `{
+				"borrower_id": 1,
+				"principal": 100.00,
+				"product_id": 1,
+				"duration": 52,
+				"start_date": "2023-01-01",
+				"frequency": "yearly"
+			}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid repayment frequency"}`,
+		},
+		{
+			name: "Invalid Roll Convention",
+			requestBody: `{
+				"borrower_id": 1,
+				"principal": 100.00,
+				"product_id": 1,
+				"duration": 52,
+				"start_date": "2023-01-01",
+				"calendar": "ID",
+				"roll_convention": "nearest"
+			}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid roll convention"}`,
+		},
 		{
 			name: "Loan Usecase Error",
 			requestBody: `{
 				"borrower_id": 1,
 				"principal": 100.00,
-				"interest_rate": 10.00,
+				"product_id": 1,
 				"duration": 52,
 				"start_date": "2023-01-01"
 			}`,
 			mockUsecase: func() *mocks.LoanUsecase {
 				mockUsecase := new(mocks.LoanUsecase)
-				mockUsecase.On("CreateLoan", mock.Anything, uint(1), 100.00, 10.00, int32(52), fixedTime).Return(nil, errors.New("internal error"))
+				mockUsecase.On("CreateLoan", mock.Anything, uint(1), terms).Return(nil, errors.New("internal error"))
 				return mockUsecase
 			}(),
 			expectedStatus: http.StatusInternalServerError,
@@ -333,3 +483,776 @@ func TestCreateLoan(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID:      1,
+		Principal:      money.FromFloat(1000),
+		Tenor:          2,
+		Frequency:      domain.FrequencyWeekly,
+		StartDate:      fixedTime,
+		RollConvention: domain.RollUnadjusted,
+	}
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.LoanUsecase
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Valid Quote Loan",
+			requestBody: `{
+				"principal": 1000.00,
+				"product_id": 1,
+				"duration": 2,
+				"start_date": "2023-01-01"
+			}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("QuoteLoan", mock.Anything, terms).Return(&dto.QuoteLoanResponse{
+					ProductID:          1,
+					Principal:          money.FromFloat(1000),
+					NetDisbursed:       money.FromFloat(1000),
+					Fees:               []dto.GetLoanFeeResponse{},
+					InterestRate:       5.00,
+					Duration:           2,
+					Frequency:          "weekly",
+					AmortizationMethod: "flat",
+					StartDate:          fixedTime,
+					TotalInterest:      money.FromFloat(1.92),
+					TotalRepayable:     money.FromFloat(1001.92),
+					EffectiveRate:      6.88,
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{
+						{DueAmount: money.FromFloat(500.96), DueDate: fixedTime.AddDate(0, 0, 7), Status: "unpaid"},
+						{DueAmount: money.FromFloat(500.96), DueDate: fixedTime.AddDate(0, 0, 14), Status: "unpaid"},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"product_id": 1,
+				"principal": 1000,
+				"total_fees": 0,
+				"net_disbursed": 1000,
+				"fees": [],
+				"interest_rate": 5,
+				"duration": 2,
+				"frequency": "weekly",
+				"amortization_method": "flat",
+				"start_date": "2023-01-01T00:00:00Z",
+				"total_interest": 1.92,
+				"total_repayable": 1001.92,
+				"effective_rate": 6.88,
+				"payment_schedules": [
+					{"due_amount": 500.96, "principal_amount": 0, "interest_amount": 0, "fee_amount": 0, "penalty_amount": 0, "paid_amount": 0, "due_date": "2023-01-08T00:00:00Z", "paid": false, "status": "unpaid"},
+					{"due_amount": 500.96, "principal_amount": 0, "interest_amount": 0, "fee_amount": 0, "penalty_amount": 0, "paid_amount": 0, "due_date": "2023-01-15T00:00:00Z", "paid": false, "status": "unpaid"}
+				]
+			}`,
+		},
+		{
+			name: "Invalid Start Date",
+			requestBody: `{
+				"principal": 1000.00,
+				"product_id": 1,
+				"duration": 2,
+				"start_date": "23-01-01"
+			}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name: "Loan Usecase Error",
+			requestBody: `{
+				"principal": 1000.00,
+				"product_id": 1,
+				"duration": 2,
+				"start_date": "2023-01-01"
+			}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("QuoteLoan", mock.Anything, terms).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/quote", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestUpdateLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID:      1,
+		Principal:      money.FromFloat(200),
+		Tenor:          52,
+		Frequency:      domain.FrequencyWeekly,
+		StartDate:      fixedTime,
+		RollConvention: domain.RollUnadjusted,
+	}
+	requestBody := `{"product_id": 1, "principal": 200, "duration": 52, "start_date": "2023-01-01"}`
+
+	tests := []struct {
+		name           string
+		loanID         string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Valid Update",
+			loanID: "1",
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("UpdateLoan", mock.Anything, uint(1), terms).Return(&dto.CreateLoanResponse{
+					ID:                 1,
+					ProductID:          1,
+					Principal:          money.FromFloat(200),
+					NetDisbursed:       money.FromFloat(200),
+					Fees:               []dto.GetLoanFeeResponse{},
+					InterestRate:       10,
+					Duration:           52,
+					Frequency:          "weekly",
+					AmortizationMethod: "flat",
+					StartDate:          fixedTime,
+					Status:             "proposed",
+					OutstandingAmount:  money.FromFloat(220),
+					PaymentSchedules:   []dto.GetPaymentScheduleResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"id": 1,
+				"product_id": 1,
+				"principal": 200,
+				"total_fees": 0,
+				"net_disbursed": 200,
+				"fees": [],
+				"interest_rate": 10,
+				"duration": 52,
+				"frequency": "weekly",
+				"amortization_method": "flat",
+				"start_date": "2023-01-01T00:00:00Z",
+				"status": "proposed",
+				"outstanding_amount": 220,
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:   "Approved Loan",
+			loanID: "1",
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("UpdateLoan", mock.Anything, uint(1), terms).Return(nil, errors.New("loan is approved and can no longer be edited"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"loan is approved and can no longer be edited"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "PUT", "/loans/"+tt.loanID, bytes.NewBufferString(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestApproveLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	approvalDate := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	approval := domain.LoanApproval{
+		ValidatorID:       7,
+		ApprovalDate:      approvalDate,
+		ProofOfVisitImage: "visits/loan-1.jpg",
+	}
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Approval",
+			loanID:      "1",
+			requestBody: `{"validator_id": 7, "approval_date": "2023-01-01", "proof_of_visit_image": "visits/loan-1.jpg"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("ApproveLoan", mock.Anything, uint(1), approval).Return(&dto.GetLoanApprovalResponse{
+					LoanID:            1,
+					ValidatorID:       7,
+					ApprovalDate:      approvalDate,
+					ProofOfVisitImage: "visits/loan-1.jpg",
+					ApprovedAt:        approvalDate,
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"validator_id": 7,
+				"approval_date": "2023-01-01T00:00:00Z",
+				"proof_of_visit_image": "visits/loan-1.jpg",
+				"approved_at": "2023-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:           "Invalid Loan ID",
+			loanID:         "abc",
+			requestBody:    `{"validator_id": 7, "approval_date": "2023-01-01", "proof_of_visit_image": "visits/loan-1.jpg"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid loan ID format"}`,
+		},
+		{
+			name:           "Missing Proof Of Visit",
+			loanID:         "1",
+			requestBody:    `{"validator_id": 7, "approval_date": "2023-01-01"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"proof of visit image is required"}`,
+		},
+		{
+			name:           "Invalid Approval Date",
+			loanID:         "1",
+			requestBody:    `{"validator_id": 7, "approval_date": "01/01/2023", "proof_of_visit_image": "visits/loan-1.jpg"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:        "Invalid Transition",
+			loanID:      "1",
+			requestBody: `{"validator_id": 7, "approval_date": "2023-01-01", "proof_of_visit_image": "visits/loan-1.jpg"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("ApproveLoan", mock.Anything, uint(1), approval).Return(nil, errors.New("cannot move loan from disbursed to approved"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"cannot move loan from disbursed to approved"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/approve", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRestructureLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dueDate := time.Date(2023, time.January, 8, 0, 0, 0, 0, time.UTC)
+	rate := 5.0
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Restructure",
+			loanID:      "1",
+			requestBody: `{"tenor": 1, "interest_rate": 5, "capitalize_arrears": true, "reason": "harvest failure"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("RestructureLoan", mock.Anything, uint(1), domain.RestructureTerms{
+					Tenor:             1,
+					InterestRate:      &rate,
+					CapitalizeArrears: true,
+					Reason:            "harvest failure",
+				}).Return(&dto.RestructureLoanResponse{
+					LoanID:              1,
+					RestructureID:       3,
+					PreviousTenor:       2,
+					Tenor:               1,
+					PreviousRate:        10,
+					InterestRate:        5,
+					CapitalizedArrears:  money.FromFloat(5),
+					PreviousOutstanding: money.FromFloat(205),
+					OutstandingAmount:   money.FromFloat(205),
+					SupersededSchedules: 2,
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{
+						{DueAmount: money.FromFloat(205), PrincipalAmount: money.FromFloat(205), DueDate: dueDate, Status: "unpaid"},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"restructure_id": 3,
+				"previous_tenor": 2,
+				"tenor": 1,
+				"previous_rate": 10,
+				"interest_rate": 5,
+				"capitalized_arrears": 5,
+				"previous_outstanding": 205,
+				"outstanding_amount": 205,
+				"superseded_schedules": 2,
+				"payment_schedules": [
+					{"due_amount": 205, "principal_amount": 205, "interest_amount": 0, "fee_amount": 0, "penalty_amount": 0, "paid_amount": 0, "due_date": "2023-01-08T00:00:00Z", "paid": false, "status": "unpaid"}
+				]
+			}`,
+		},
+		{
+			name:           "Invalid Loan ID",
+			loanID:         "abc",
+			requestBody:    `{"tenor": 1}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid loan ID format"}`,
+		},
+		{
+			name:           "Negative Tenor",
+			loanID:         "1",
+			requestBody:    `{"tenor": -1}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"tenor must be positive"}`,
+		},
+		{
+			name:           "Negative Interest Rate",
+			loanID:         "1",
+			requestBody:    `{"interest_rate": -1}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"interest rate must not be negative"}`,
+		},
+		{
+			name:        "Usecase Error",
+			loanID:      "1",
+			requestBody: `{}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("RestructureLoan", mock.Anything, uint(1), domain.RestructureTerms{}).Return(nil, errors.New("only disbursed loans can be restructured, loan is approved"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"only disbursed loans can be restructured, loan is approved"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/restructure", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestDeferInstallments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dueDate := time.Date(2023, time.January, 8, 0, 0, 0, 0, time.UTC)
+	deferral := domain.LoanDeferral{
+		Installments: 1,
+		Policy:       domain.DeferralCapitalizeInterest,
+		Reason:       "crop failure",
+		ApproverID:   7,
+	}
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Deferral",
+			loanID:      "1",
+			requestBody: `{"installments": 1, "policy": "capitalize_interest", "reason": "crop failure", "approver_id": 7}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("DeferInstallments", mock.Anything, uint(1), deferral).Return(&dto.DeferInstallmentsResponse{
+					LoanID:            1,
+					DeferralID:        2,
+					Installments:      1,
+					Policy:            "capitalize_interest",
+					Reason:            "crop failure",
+					ApproverID:        7,
+					CapitalizedAmount: money.FromFloat(100),
+					HolidayInterest:   money.FromFloat(1.50),
+					Tenor:             1,
+					OutstandingAmount: money.FromFloat(200),
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{
+						{DueDate: dueDate, Paid: true, Status: "deferred"},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"deferral_id": 2,
+				"installments": 1,
+				"policy": "capitalize_interest",
+				"reason": "crop failure",
+				"approver_id": 7,
+				"capitalized_amount": 100,
+				"holiday_interest": 1.5,
+				"tenor": 1,
+				"outstanding_amount": 200,
+				"payment_schedules": [
+					{"due_amount": 0, "principal_amount": 0, "interest_amount": 0, "fee_amount": 0, "penalty_amount": 0, "paid_amount": 0, "due_date": "2023-01-08T00:00:00Z", "paid": true, "status": "deferred"}
+				]
+			}`,
+		},
+		{
+			name:           "Invalid Loan ID",
+			loanID:         "abc",
+			requestBody:    `{"installments": 1, "policy": "capitalize_interest", "reason": "crop failure", "approver_id": 7}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid loan ID format"}`,
+		},
+		{
+			name:           "Invalid Policy",
+			loanID:         "1",
+			requestBody:    `{"installments": 1, "policy": "forgive", "reason": "crop failure", "approver_id": 7}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid deferral policy"}`,
+		},
+		{
+			name:           "Missing Approver",
+			loanID:         "1",
+			requestBody:    `{"installments": 1, "policy": "capitalize_interest", "reason": "crop failure"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"approver ID is required"}`,
+		},
+		{
+			name:        "Usecase Error",
+			loanID:      "1",
+			requestBody: `{"installments": 1, "policy": "capitalize_interest", "reason": "crop failure", "approver_id": 7}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("DeferInstallments", mock.Anything, uint(1), deferral).Return(nil, errors.New("loan has only 0 upcoming installments to defer"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"loan has only 0 upcoming installments to defer"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/deferrals", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRefinanceLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID:      1,
+		Principal:      money.FromFloat(1000),
+		Tenor:          4,
+		Frequency:      domain.FrequencyWeekly,
+		StartDate:      fixedTime,
+		RollConvention: domain.RollUnadjusted,
+	}
+	refinancedLoanID := uint(1)
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Refinance",
+			loanID:      "1",
+			requestBody: `{"product_id": 1, "principal": 1000, "duration": 4, "start_date": "2023-01-01"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("RefinanceLoan", mock.Anything, uint(1), terms).Return(&dto.CreateLoanResponse{
+					ID:                 2,
+					ProductID:          1,
+					Principal:          money.FromFloat(1000),
+					NetDisbursed:       money.FromFloat(600),
+					Fees:               []dto.GetLoanFeeResponse{},
+					InterestRate:       10,
+					Duration:           4,
+					Frequency:          "weekly",
+					AmortizationMethod: "flat",
+					StartDate:          fixedTime,
+					Status:             "proposed",
+					OutstandingAmount:  money.FromFloat(1100),
+					RefinancedLoanID:   &refinancedLoanID,
+					RefinancePayoff:    money.FromFloat(400),
+					PaymentSchedules:   []dto.GetPaymentScheduleResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"id": 2,
+				"product_id": 1,
+				"principal": 1000,
+				"total_fees": 0,
+				"net_disbursed": 600,
+				"fees": [],
+				"interest_rate": 10,
+				"duration": 4,
+				"frequency": "weekly",
+				"amortization_method": "flat",
+				"start_date": "2023-01-01T00:00:00Z",
+				"status": "proposed",
+				"outstanding_amount": 1100,
+				"refinanced_loan_id": 1,
+				"refinance_payoff": 400,
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:           "Invalid Loan ID",
+			loanID:         "abc",
+			requestBody:    `{"product_id": 1, "principal": 1000, "duration": 4, "start_date": "2023-01-01"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid loan ID format"}`,
+		},
+		{
+			name:           "Missing Product",
+			loanID:         "1",
+			requestBody:    `{"principal": 1000, "duration": 4, "start_date": "2023-01-01"}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"product ID is required"}`,
+		},
+		{
+			name:        "Usecase Error",
+			loanID:      "1",
+			requestBody: `{"product_id": 1, "principal": 1000, "duration": 4, "start_date": "2023-01-01"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("RefinanceLoan", mock.Anything, uint(1), terms).Return(nil, errors.New("only disbursed loans can be refinanced, loan is closed"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"only disbursed loans can be refinanced, loan is closed"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/refinance", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestWriteOffLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	writtenOffAt := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		loanID         string
+		requestBody    string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Write-Off",
+			loanID:      "1",
+			requestBody: `{"reason": "borrower deceased"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("WriteOffLoan", mock.Anything, uint(1), "borrower deceased").Return(&dto.WriteOffLoanResponse{
+					LoanID:        1,
+					WriteOffID:    4,
+					LossAmount:    money.FromFloat(200),
+					ForgoneIncome: money.FromFloat(50),
+					Reason:        "borrower deceased",
+					Status:        "written_off",
+					WrittenOffAt:  writtenOffAt,
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"loan_id": 1, "write_off_id": 4, "loss_amount": 200, "forgone_income": 50, "reason": "borrower deceased", "status": "written_off", "written_off_at": "2023-03-01T00:00:00Z"}`,
+		},
+		{
+			name:           "Missing Reason",
+			loanID:         "1",
+			requestBody:    `{}`,
+			mockUsecase:    new(mocks.LoanUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"reason is required"}`,
+		},
+		{
+			name:        "Invalid Transition",
+			loanID:      "1",
+			requestBody: `{"reason": "borrower deceased"}`,
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("WriteOffLoan", mock.Anything, uint(1), "borrower deceased").Return(nil, errors.New("cannot move loan from closed to written_off"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"cannot move loan from closed to written_off"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/"+tt.loanID+"/write-off", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetPortfolio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.LoanUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Portfolio",
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("GetPortfolio", mock.Anything).Return(&dto.PortfolioResponse{
+					ActiveLoans:       3,
+					ActiveOutstanding: money.FromFloat(1500),
+					WrittenOffLoans:   1,
+					WrittenOffAmount:  money.FromFloat(200),
+					RecoveredAmount:   money.FromFloat(50),
+					NetChargeOff:      money.FromFloat(150),
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"active_loans": 3, "active_outstanding": 1500, "written_off_loans": 1, "written_off_amount": 200, "recovered_amount": 50, "net_charge_off": 150}`,
+		},
+		{
+			name: "Usecase Error",
+			mockUsecase: func() *mocks.LoanUsecase {
+				mockUsecase := new(mocks.LoanUsecase)
+				mockUsecase.On("GetPortfolio", mock.Anything).Return(nil, errors.New("database error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"database error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", "/reports/portfolio", nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/greekrode/loan-engine-amartha/db"
 	"github.com/greekrode/loan-engine-amartha/domain"
@@ -26,10 +27,30 @@ func (s *sqliteLoanRepository) CreateLoan(ctx context.Context, loan *domain.Loan
 	return tx.WithContext(ctx).Create(&loan).Error
 }
 
+func (s *sqliteLoanRepository) CreateLoanFees(ctx context.Context, fees []domain.LoanFee, tx *gorm.DB) error {
+	if len(fees) == 0 {
+		return nil
+	}
+
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&fees).Error
+}
+
+func (s *sqliteLoanRepository) CreateLoanApproval(ctx context.Context, approval *domain.LoanApproval, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(approval).Error
+}
+
 func (s *sqliteLoanRepository) FindLoanByID(ctx context.Context, loanID uint) (*domain.Loan, error) {
 	var loan domain.Loan
 
-	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("PaymentSchedules").First(&loan, loanID).Error
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("PaymentSchedules", "superseded = ?", false).Preload("Fees").Preload("StatusHistory").Preload("Approval").First(&loan, loanID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("Loan not found")
@@ -42,7 +63,7 @@ func (s *sqliteLoanRepository) FindLoanByID(ctx context.Context, loanID uint) (*
 
 func (s *sqliteLoanRepository) GetLoansByBorrowerID(ctx context.Context, borrowerID uint) ([]domain.Loan, error) {
 	var loans []domain.Loan
-	err := s.TransactionManager.GetDB().WithContext(ctx).Where("borrower_id = ?", borrowerID).Preload("PaymentSchedules").Find(&loans).Error
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("borrower_id = ?", borrowerID).Preload("PaymentSchedules", "superseded = ?", false).Find(&loans).Error
 	if err != nil {
 		return nil, err
 	}
@@ -54,9 +75,122 @@ func (s *sqliteLoanRepository) GetLoansByBorrowerID(ctx context.Context, borrowe
 	return loans, nil
 }
 
+// GetOverdueLoans returns the disbursed loans with installments left unpaid
+// past their due date, loaded with those installments only.
+func (s *sqliteLoanRepository) GetOverdueLoans(ctx context.Context, date time.Time) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	err := s.TransactionManager.GetDB().WithContext(ctx).
+		Where("status = ?", domain.LoanDisbursed).
+		Where("EXISTS (SELECT 1 FROM payment_schedules WHERE payment_schedules.loan_id = loans.id AND payment_schedules.paid = ? AND payment_schedules.superseded = ? AND payment_schedules.due_date < ? AND payment_schedules.deleted_at IS NULL)", false, false, date).
+		Preload("PaymentSchedules", "paid = ? AND superseded = ? AND due_date < ?", false, false, date).
+		Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// GetActiveLoansByBorrowerIDs returns the disbursed loans of the borrowers,
+// loaded with their unpaid installments in due date order.
+func (s *sqliteLoanRepository) GetActiveLoansByBorrowerIDs(ctx context.Context, borrowerIDs []uint) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	err := s.TransactionManager.GetDB().WithContext(ctx).
+		Where("borrower_id IN ? AND status = ?", borrowerIDs, domain.LoanDisbursed).
+		Preload("PaymentSchedules", func(db *gorm.DB) *gorm.DB {
+			return db.Where("paid = ? AND superseded = ?", false, false).Order("due_date")
+		}).
+		Order("borrower_id, id").
+		Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// GetDisbursedLoans returns the disbursed loans, loaded with the installments
+// of their current repayment plan in due date order.
+func (s *sqliteLoanRepository) GetDisbursedLoans(ctx context.Context) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	err := s.TransactionManager.GetDB().WithContext(ctx).
+		Where("status = ?", domain.LoanDisbursed).
+		Preload("PaymentSchedules", func(db *gorm.DB) *gorm.DB {
+			return db.Where("superseded = ?", false).Order("due_date")
+		}).
+		Order("id").
+		Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// GetPortfolioSummary totals the outstanding balance of disbursed loans and
+// the balance written off and recovered across the book.
+func (s *sqliteLoanRepository) GetPortfolioSummary(ctx context.Context) (*domain.PortfolioSummary, error) {
+	var summary domain.PortfolioSummary
+	err := s.TransactionManager.GetDB().WithContext(ctx).Model(&domain.Loan{}).
+		Select("COUNT(CASE WHEN status = ? THEN 1 END) AS active_loans, "+
+			"COALESCE(SUM(CASE WHEN status = ? THEN outstanding_amount END), 0) AS active_outstanding, "+
+			"COUNT(CASE WHEN status = ? THEN 1 END) AS written_off_loans, "+
+			"COALESCE(SUM(written_off_amount), 0) AS written_off_amount, "+
+			"COALESCE(SUM(recovered_amount), 0) AS recovered_amount",
+			domain.LoanDisbursed, domain.LoanDisbursed, domain.LoanWrittenOff).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
 func (s *sqliteLoanRepository) UpdateLoan(ctx context.Context, loan *domain.Loan, tx *gorm.DB) error {
 	if tx == nil {
 		tx = s.TransactionManager.GetDB()
 	}
 	return tx.WithContext(ctx).Save(loan).Error
 }
+
+func (s *sqliteLoanRepository) CreateStatusChange(ctx context.Context, change *domain.LoanStatusChange, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Create(change).Error
+}
+
+func (s *sqliteLoanRepository) CreateRestructure(ctx context.Context, restructure *domain.LoanRestructure, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Create(restructure).Error
+}
+
+func (s *sqliteLoanRepository) CreateDeferral(ctx context.Context, deferral *domain.LoanDeferral, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Create(deferral).Error
+}
+
+func (s *sqliteLoanRepository) CreateWriteOff(ctx context.Context, writeOff *domain.LoanWriteOff, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Create(writeOff).Error
+}
+
+func (s *sqliteLoanRepository) CreateRefinance(ctx context.Context, refinance *domain.LoanRefinance, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Create(refinance).Error
+}
+
+func (s *sqliteLoanRepository) DeleteLoanFees(ctx context.Context, loanID uint, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+	return tx.WithContext(ctx).Where("loan_id = ?", loanID).Delete(&domain.LoanFee{}).Error
+}
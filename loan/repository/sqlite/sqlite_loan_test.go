@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/greekrode/loan-engine-amartha/db"
 	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	"github.com/greekrode/loan-engine-amartha/loan/repository/sqlite"
 	"github.com/greekrode/loan-engine-amartha/utils"
 	"github.com/stretchr/testify/suite"
@@ -43,15 +44,20 @@ func (s *LoanRepositorySuite) TestCreateLoan() {
 			name: "Success",
 			setup: func() {
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec("INSERT INTO `loans`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, 100.00, 10.00, 52, 1000.00, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				s.mock.ExpectExec("INSERT INTO `loans`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, 1, 10000, 0, 10000, 10.00, 0.00, 52, "weekly", "flat", "none", "act/365", nil, "none", 0, 0.00, 0, 100000, 0, 0, 0, sqlmock.AnyArg(), "", "unadjusted", "proposed", nil, nil).WillReturnResult(sqlmock.NewResult(1, 1))
 				s.mock.ExpectCommit()
 			},
 			loan: domain.Loan{
-				BorrowerID:        1,
-				Principal:         100,
-				InterestRate:      10,
-				DurationWeeks:     52,
-				OutstandingAmount: 1000,
+				BorrowerID:         1,
+				ProductID:          1,
+				Principal:          money.FromFloat(100),
+				NetDisbursed:       money.FromFloat(100),
+				InterestRate:       10,
+				Tenor:              52,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				OutstandingAmount:  money.FromFloat(1000),
+				RollConvention:     domain.RollUnadjusted,
 			},
 			wantErr: false,
 		},
@@ -59,15 +65,20 @@ func (s *LoanRepositorySuite) TestCreateLoan() {
 			name: "Failure",
 			setup: func() {
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec("INSERT INTO `loans`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, 100.00, 10.00, 52, 1000.00, sqlmock.AnyArg()).WillReturnError(fmt.Errorf("insert error"))
+				s.mock.ExpectExec("INSERT INTO `loans`").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, 1, 10000, 0, 10000, 10.00, 0.00, 52, "weekly", "flat", "none", "act/365", nil, "none", 0, 0.00, 0, 100000, 0, 0, 0, sqlmock.AnyArg(), "", "unadjusted", "proposed", nil, nil).WillReturnError(fmt.Errorf("insert error"))
 				s.mock.ExpectRollback()
 			},
 			loan: domain.Loan{
-				BorrowerID:        1,
-				Principal:         100,
-				InterestRate:      10,
-				DurationWeeks:     52,
-				OutstandingAmount: 1000,
+				BorrowerID:         1,
+				ProductID:          1,
+				Principal:          money.FromFloat(100),
+				NetDisbursed:       money.FromFloat(100),
+				InterestRate:       10,
+				Tenor:              52,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				OutstandingAmount:  money.FromFloat(1000),
+				RollConvention:     domain.RollUnadjusted,
 			},
 			wantErr: true,
 		},
@@ -103,15 +114,33 @@ func (s *LoanRepositorySuite) TestFindLoanByID() {
 			setup: func() {
 				loanQuery := "SELECT * FROM `loans` WHERE `loans`.`id` = ? AND `loans`.`deleted_at` IS NULL ORDER BY `loans`.`id` LIMIT 1"
 				loanEscapedQuery := regexp.QuoteMeta(loanQuery)
-				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "duration_weeks", "outstanding_amount", "start_date"}).
-					AddRow(1, fixedTime, fixedTime, nil, 1, 100.00, 10.00, 52, 1000.00, fixedTime)
+				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "tenor", "outstanding_amount", "start_date", "status"}).
+					AddRow(1, fixedTime, fixedTime, nil, 1, 10000, 10.00, 52, 100000, fixedTime, "approved")
 				s.mock.ExpectQuery(loanEscapedQuery).WithArgs(1).WillReturnRows(loanRows)
 
-				paymentSchedulesQuery := "SELECT * FROM `payment_schedules` WHERE `payment_schedules`.`loan_id` = ? AND `payment_schedules`.`deleted_at` IS NULL"
+				approvalQuery := "SELECT * FROM `loan_approvals` WHERE `loan_approvals`.`loan_id` = ? AND `loan_approvals`.`deleted_at` IS NULL"
+				approvalEscapedQuery := regexp.QuoteMeta(approvalQuery)
+				approvalRows := sqlmock.NewRows([]string{"id", "loan_id", "validator_id", "approval_date", "proof_of_visit_image"}).
+					AddRow(1, 1, 7, fixedTime, "visits/loan-1.jpg")
+				s.mock.ExpectQuery(approvalEscapedQuery).WithArgs(1).WillReturnRows(approvalRows)
+
+				feesQuery := "SELECT * FROM `loan_fees` WHERE `loan_fees`.`loan_id` = ? AND `loan_fees`.`deleted_at` IS NULL"
+				feesEscapedQuery := regexp.QuoteMeta(feesQuery)
+				feesRows := sqlmock.NewRows([]string{"id", "loan_id", "name", "type", "charge", "amount"}).
+					AddRow(1, 1, "Admin", "percentage", "upfront", 200)
+				s.mock.ExpectQuery(feesEscapedQuery).WithArgs(1).WillReturnRows(feesRows)
+
+				paymentSchedulesQuery := "SELECT * FROM `payment_schedules` WHERE `payment_schedules`.`loan_id` = ? AND superseded = ? AND `payment_schedules`.`deleted_at` IS NULL"
 				paymentSchedulesEscapedQuery := regexp.QuoteMeta(paymentSchedulesQuery)
 				paymentSchedulesRows := sqlmock.NewRows([]string{"id", "loan_id", "due_date", "amount_due", "status"}).
-					AddRow(1, 1, fixedTime, 500.00, "pending")
-				s.mock.ExpectQuery(paymentSchedulesEscapedQuery).WithArgs(1).WillReturnRows(paymentSchedulesRows)
+					AddRow(1, 1, fixedTime, 50000, "pending")
+				s.mock.ExpectQuery(paymentSchedulesEscapedQuery).WithArgs(1, false).WillReturnRows(paymentSchedulesRows)
+
+				statusHistoryQuery := "SELECT * FROM `loan_status_changes` WHERE `loan_status_changes`.`loan_id` = ? AND `loan_status_changes`.`deleted_at` IS NULL"
+				statusHistoryEscapedQuery := regexp.QuoteMeta(statusHistoryQuery)
+				statusHistoryRows := sqlmock.NewRows([]string{"id", "loan_id", "from_status", "to_status"}).
+					AddRow(1, 1, "proposed", "approved")
+				s.mock.ExpectQuery(statusHistoryEscapedQuery).WithArgs(1).WillReturnRows(statusHistoryRows)
 			},
 			loan: &domain.Loan{
 				Model: gorm.Model{
@@ -121,11 +150,13 @@ func (s *LoanRepositorySuite) TestFindLoanByID() {
 					DeletedAt: gorm.DeletedAt{Valid: false},
 				},
 				BorrowerID:        1,
-				Principal:         100.00,
+				Principal:         money.FromFloat(100.00),
 				InterestRate:      10.00,
-				DurationWeeks:     52,
-				OutstandingAmount: 1000.00,
+				Tenor:             52,
+				Frequency:         domain.FrequencyWeekly,
+				OutstandingAmount: money.FromFloat(1000.00),
 				StartDate:         fixedTime,
+				Status:            domain.LoanApproved,
 				PaymentSchedules: []domain.PaymentSchedule{
 					{
 						Model: gorm.Model{
@@ -136,10 +167,35 @@ func (s *LoanRepositorySuite) TestFindLoanByID() {
 						},
 						LoanID:    1,
 						DueDate:   fixedTime,
-						DueAmount: 500.00,
+						DueAmount: money.FromFloat(500.00),
 						Paid:      false,
 					},
 				},
+				Fees: []domain.LoanFee{
+					{
+						Model:  gorm.Model{ID: 1},
+						LoanID: 1,
+						Name:   "Admin",
+						Type:   domain.FeePercentage,
+						Charge: domain.FeeUpfront,
+						Amount: money.FromFloat(2.00),
+					},
+				},
+				StatusHistory: []domain.LoanStatusChange{
+					{
+						Model:      gorm.Model{ID: 1},
+						LoanID:     1,
+						FromStatus: domain.LoanProposed,
+						ToStatus:   domain.LoanApproved,
+					},
+				},
+				Approval: &domain.LoanApproval{
+					Model:             gorm.Model{ID: 1},
+					LoanID:            1,
+					ValidatorID:       7,
+					ApprovalDate:      fixedTime,
+					ProofOfVisitImage: "visits/loan-1.jpg",
+				},
 			},
 			wantErr: false,
 		},
@@ -149,7 +205,7 @@ func (s *LoanRepositorySuite) TestFindLoanByID() {
 			setup: func() {
 				loanQuery := "SELECT * FROM `loans` WHERE `loans`.`id` = ? AND `loans`.`deleted_at` IS NULL ORDER BY `loans`.`id` LIMIT 1"
 				loanEscapedQuery := regexp.QuoteMeta(loanQuery)
-				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "duration_weeks", "outstanding_amount", "start_date"})
+				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "tenor", "outstanding_amount", "start_date"})
 				s.mock.ExpectQuery(loanEscapedQuery).WithArgs(1).WillReturnRows(loanRows)
 			},
 			loan:    nil,
@@ -180,10 +236,14 @@ func (s *LoanRepositorySuite) TestFindLoanByID() {
 				s.Equal(tt.loan.BorrowerID, got.BorrowerID)
 				s.Equal(tt.loan.Principal, got.Principal)
 				s.Equal(tt.loan.InterestRate, got.InterestRate)
-				s.Equal(tt.loan.DurationWeeks, got.DurationWeeks)
+				s.Equal(tt.loan.Tenor, got.Tenor)
 				s.Equal(tt.loan.OutstandingAmount, got.OutstandingAmount)
 				s.Equal(tt.loan.StartDate, got.StartDate)
 				s.Len(got.PaymentSchedules, 1)
+				s.Equal(tt.loan.Fees, got.Fees)
+				s.Equal(tt.loan.Status, got.Status)
+				s.Equal(tt.loan.StatusHistory, got.StatusHistory)
+				s.Equal(tt.loan.Approval, got.Approval)
 			}
 		})
 	}
@@ -205,15 +265,15 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 			setup: func() {
 				loanQuery := "SELECT * FROM `loans` WHERE borrower_id = ? AND `loans`.`deleted_at` IS NULL"
 				loanEscapedQuery := regexp.QuoteMeta(loanQuery)
-				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "duration_weeks", "outstanding_amount", "start_date"}).
-					AddRow(1, fixedTime, fixedTime, nil, 1, 100.00, 10.00, 52, 1000.00, fixedTime)
+				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "tenor", "outstanding_amount", "start_date", "status"}).
+					AddRow(1, fixedTime, fixedTime, nil, 1, 10000, 10.00, 52, 100000, fixedTime, "approved")
 				s.mock.ExpectQuery(loanEscapedQuery).WithArgs(1).WillReturnRows(loanRows)
 
-				paymentSchedulesQuery := "SELECT * FROM `payment_schedules` WHERE `payment_schedules`.`loan_id` = ? AND `payment_schedules`.`deleted_at` IS NULL"
+				paymentSchedulesQuery := "SELECT * FROM `payment_schedules` WHERE `payment_schedules`.`loan_id` = ? AND superseded = ? AND `payment_schedules`.`deleted_at` IS NULL"
 				paymentSchedulesEscapedQuery := regexp.QuoteMeta(paymentSchedulesQuery)
 				paymentSchedulesRows := sqlmock.NewRows([]string{"id", "loan_id", "due_date", "amount_due", "status"}).
-					AddRow(1, 1, fixedTime, 500.00, "pending")
-				s.mock.ExpectQuery(paymentSchedulesEscapedQuery).WithArgs(1).WillReturnRows(paymentSchedulesRows)
+					AddRow(1, 1, fixedTime, 50000, "pending")
+				s.mock.ExpectQuery(paymentSchedulesEscapedQuery).WithArgs(1, false).WillReturnRows(paymentSchedulesRows)
 			},
 			loan: []domain.Loan{
 				{
@@ -224,10 +284,11 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 						DeletedAt: gorm.DeletedAt{Valid: false},
 					},
 					BorrowerID:        1,
-					Principal:         100.00,
+					Principal:         money.FromFloat(100.00),
 					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
+					Tenor:             52,
+					Frequency:         domain.FrequencyWeekly,
+					OutstandingAmount: money.FromFloat(1000.00),
 					StartDate:         fixedTime,
 					PaymentSchedules: []domain.PaymentSchedule{
 						{
@@ -239,7 +300,7 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 							},
 							LoanID:    1,
 							DueDate:   fixedTime,
-							DueAmount: 500.00,
+							DueAmount: money.FromFloat(500.00),
 							Paid:      false,
 						},
 					},
@@ -253,7 +314,7 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 			setup: func() {
 				loanQuery := "SELECT * FROM `loans` WHERE borrower_id = ? AND `loans`.`deleted_at` IS NULL"
 				loanEscapedQuery := regexp.QuoteMeta(loanQuery)
-				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "duration_weeks", "outstanding_amount", "start_date"})
+				loanRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "borrower_id", "principal", "interest_rate", "tenor", "outstanding_amount", "start_date"})
 				s.mock.ExpectQuery(loanEscapedQuery).WithArgs(1).WillReturnRows(loanRows)
 			},
 			loan:    nil,
@@ -284,7 +345,7 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 				s.Equal(tt.loan[0].BorrowerID, got[0].BorrowerID)
 				s.Equal(tt.loan[0].Principal, got[0].Principal)
 				s.Equal(tt.loan[0].InterestRate, got[0].InterestRate)
-				s.Equal(tt.loan[0].DurationWeeks, got[0].DurationWeeks)
+				s.Equal(tt.loan[0].Tenor, got[0].Tenor)
 				s.Equal(tt.loan[0].OutstandingAmount, got[0].OutstandingAmount)
 				s.Equal(tt.loan[0].StartDate, got[0].StartDate)
 				s.Len(got[0].PaymentSchedules, 1)
@@ -293,6 +354,82 @@ func (s *LoanRepositorySuite) TestGetLoansByBorrowerID() {
 	}
 }
 
+func (s *LoanRepositorySuite) TestGetPortfolioSummary() {
+	tests := []struct {
+		name    string
+		setup   func()
+		summary *domain.PortfolioSummary
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				rows := sqlmock.NewRows([]string{"active_loans", "active_outstanding", "written_off_loans", "written_off_amount", "recovered_amount"}).
+					AddRow(3, 150000, 1, 20000, 5000)
+				s.mock.ExpectQuery("SELECT COUNT\\(CASE WHEN status = \\? THEN 1 END\\) AS active_loans.* FROM `loans`").
+					WithArgs("disbursed", "disbursed", "written_off").WillReturnRows(rows)
+			},
+			summary: &domain.PortfolioSummary{
+				ActiveLoans:       3,
+				ActiveOutstanding: money.FromFloat(1500.00),
+				WrittenOffLoans:   1,
+				WrittenOffAmount:  money.FromFloat(200.00),
+				RecoveredAmount:   money.FromFloat(50.00),
+			},
+		},
+		{
+			name: "DatabaseError",
+			setup: func() {
+				s.mock.ExpectQuery("SELECT COUNT").WillReturnError(fmt.Errorf("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setup()
+			repo := sqlite.NewSQLiteLoanRepository(s.tm)
+			got, err := repo.GetPortfolioSummary(context.TODO())
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.summary, got)
+			}
+		})
+	}
+}
+
+func (s *LoanRepositorySuite) TestGetActiveLoansByBorrowerIDs() {
+	loansQuery := regexp.QuoteMeta("SELECT * FROM `loans` WHERE (borrower_id IN (?,?) AND status = ?) AND `loans`.`deleted_at` IS NULL ORDER BY borrower_id, id")
+	schedulesQuery := regexp.QuoteMeta("SELECT * FROM `payment_schedules` WHERE (paid = ? AND superseded = ?) AND `payment_schedules`.`loan_id` = ? AND `payment_schedules`.`deleted_at` IS NULL ORDER BY due_date")
+
+	s.mock.ExpectQuery(loansQuery).WithArgs(1, 3, "disbursed").WillReturnRows(sqlmock.NewRows([]string{"id", "borrower_id", "status"}).AddRow(7, 1, "disbursed"))
+	s.mock.ExpectQuery(schedulesQuery).WithArgs(false, false, 7).WillReturnRows(sqlmock.NewRows([]string{"id", "loan_id", "due_amount"}).AddRow(70, 7, 10000).AddRow(71, 7, 10000))
+
+	repo := sqlite.NewSQLiteLoanRepository(s.tm)
+	loans, err := repo.GetActiveLoansByBorrowerIDs(context.TODO(), []uint{1, 3})
+	s.NoError(err)
+	s.Len(loans, 1)
+	s.Len(loans[0].PaymentSchedules, 2)
+}
+
+func (s *LoanRepositorySuite) TestGetDisbursedLoans() {
+	loansQuery := regexp.QuoteMeta("SELECT * FROM `loans` WHERE status = ? AND `loans`.`deleted_at` IS NULL ORDER BY id")
+	schedulesQuery := regexp.QuoteMeta("SELECT * FROM `payment_schedules` WHERE superseded = ? AND `payment_schedules`.`loan_id` IN (?,?) AND `payment_schedules`.`deleted_at` IS NULL ORDER BY due_date")
+
+	s.mock.ExpectQuery(loansQuery).WithArgs("disbursed").WillReturnRows(sqlmock.NewRows([]string{"id", "borrower_id", "status"}).AddRow(7, 1, "disbursed").AddRow(8, 2, "disbursed"))
+	s.mock.ExpectQuery(schedulesQuery).WithArgs(false, 7, 8).WillReturnRows(sqlmock.NewRows([]string{"id", "loan_id", "due_amount"}).AddRow(70, 7, 10000).AddRow(71, 7, 10000).AddRow(80, 8, 5000))
+
+	repo := sqlite.NewSQLiteLoanRepository(s.tm)
+	loans, err := repo.GetDisbursedLoans(context.TODO())
+	s.NoError(err)
+	s.Len(loans, 2)
+	s.Len(loans[0].PaymentSchedules, 2)
+	s.Len(loans[1].PaymentSchedules, 1)
+}
+
 func TestLoanRepositorySuite(t *testing.T) {
 	suite.Run(t, new(LoanRepositorySuite))
 }
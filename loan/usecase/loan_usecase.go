@@ -2,37 +2,51 @@ package usecase
 
 import (
 	"context"
-	"math"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/greekrode/loan-engine-amartha/amortization"
 	"github.com/greekrode/loan-engine-amartha/db"
 	"github.com/greekrode/loan-engine-amartha/domain"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
 )
 
 type loanUsecase struct {
 	borrowerRepo        domain.BorrowerRepository
 	paymentScheduleRepo domain.PaymentScheduleRepository
 	loanRepo            domain.LoanRepository
+	productRepo         domain.LoanProductRepository
+	calendarRepo        domain.HolidayCalendarRepository
+	paymentRepo         domain.PaymentRepository
+	investorRepo        domain.InvestorRepository
+	ledgerRepo          domain.LedgerRepository
 	transactionManager  db.TransactionManager
 	contextTimeout      time.Duration
 }
 
-func NewLoanUsecase(b domain.BorrowerRepository, p domain.PaymentScheduleRepository, l domain.LoanRepository, tm db.TransactionManager, timeout time.Duration) domain.LoanUsecase {
+func NewLoanUsecase(b domain.BorrowerRepository, p domain.PaymentScheduleRepository, l domain.LoanRepository, lp domain.LoanProductRepository, c domain.HolidayCalendarRepository, pr domain.PaymentRepository, ir domain.InvestorRepository, lr domain.LedgerRepository, tm db.TransactionManager, timeout time.Duration) domain.LoanUsecase {
 	return &loanUsecase{
 		borrowerRepo:        b,
 		paymentScheduleRepo: p,
 		loanRepo:            l,
+		productRepo:         lp,
+		calendarRepo:        c,
+		paymentRepo:         pr,
+		investorRepo:        ir,
+		ledgerRepo:          lr,
 		transactionManager:  tm,
 		contextTimeout:      timeout,
 	}
 }
 
-func (l *loanUsecase) CreateLoan(ctx context.Context, borrowerID uint, principal, interestRate float64, durationWeeks int32, startDate time.Time) (*dto.CreateLoanResponse, error) {
+func (l *loanUsecase) CreateLoan(ctx context.Context, borrowerID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
 	defer cancel()
 
-	_, err := l.borrowerRepo.FindBorrowerByID(ctx, borrowerID)
+	loan, paymentSchedules, err := l.prepareLoan(ctx, borrowerID, terms)
 	if err != nil {
 		return nil, err
 	}
@@ -49,38 +63,216 @@ func (l *loanUsecase) CreateLoan(ctx context.Context, borrowerID uint, principal
 		}
 	}()
 
-	weeklyInterestRate := (interestRate / 100) / 52
-	var totalOutstandingAmount float64 = 0
-	var paymentSchedules []domain.PaymentSchedule
+	err = l.insertLoan(ctx, loan, paymentSchedules, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return assembleCreateLoanResponse(loan, paymentSchedules), nil
+}
+
+// prepareLoan prices a new proposed loan for the borrower from the terms and
+// its product, and builds its installments. Nothing is persisted.
+func (l *loanUsecase) prepareLoan(ctx context.Context, borrowerID uint, terms domain.LoanTerms) (*domain.Loan, []domain.PaymentSchedule, error) {
+	terms, err := l.applyProduct(ctx, terms)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	loan := domain.Loan{
-		BorrowerID:    borrowerID,
-		Principal:     principal,
-		InterestRate:  interestRate,
-		DurationWeeks: int(durationWeeks),
-		StartDate:     startDate,
+	terms.MeetingDay, err = l.meetingDay(ctx, borrowerID, terms.Frequency)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fees, upfrontFees, err := assessFees(terms)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	err = l.loanRepo.CreateLoan(ctx, &loan, tx)
+	paymentSchedules, totalOutstandingAmount, err := l.buildPaymentSchedules(ctx, terms, amortizedFees(fees))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &domain.Loan{
+		BorrowerID:         borrowerID,
+		ProductID:          terms.ProductID,
+		Principal:          terms.Principal,
+		TotalFees:          totalFees(fees),
+		NetDisbursed:       terms.Principal - upfrontFees,
+		InterestRate:       terms.InterestRate,
+		ServiceFeeRate:     terms.ServiceFeeRate,
+		Tenor:              terms.Tenor,
+		Frequency:          terms.Frequency,
+		StartDate:          terms.StartDate,
+		AmortizationMethod: terms.AmortizationMethod,
+		RebatePolicy:       terms.RebatePolicy,
+		DayCount:           terms.DayCount,
+		AllocationOrder:    terms.AllocationOrder,
+		PenaltyPolicy:      terms.PenaltyPolicy,
+		CalendarName:       terms.CalendarName,
+		RollConvention:     terms.RollConvention,
+		Status:             domain.LoanProposed,
+		Fees:               fees,
+		OutstandingAmount:  totalOutstandingAmount,
+	}, paymentSchedules, nil
+}
+
+// meetingDay returns the weekday the borrower's group meets on, which the
+// installments of a loan to a group member fall due on, or nil when the
+// borrower is not in a group. Group members repay at the weekly meeting, so
+// their loans cannot be daily.
+func (l *loanUsecase) meetingDay(ctx context.Context, borrowerID uint, frequency domain.RepaymentFrequency) (*time.Weekday, error) {
+	borrower, err := l.borrowerRepo.FindBorrowerByID(ctx, borrowerID)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := 0; i < int(durationWeeks); i++ {
-		weeklyInterest := principal * weeklyInterestRate
-		weeklyPayment := principal/float64(durationWeeks) + weeklyInterest
-		weeklyPayment = math.Round(weeklyPayment*100) / 100
-		totalOutstandingAmount += weeklyPayment
+	if borrower.Group == nil {
+		return nil, nil
+	}
 
-		paymentSchedules = append(paymentSchedules, domain.PaymentSchedule{
-			LoanID:    loan.ID,
-			DueDate:   startDate.Add(time.Duration(i+1) * 7 * 24 * time.Hour),
-			DueAmount: weeklyPayment,
-		})
+	if frequency == domain.FrequencyDaily {
+		return nil, fmt.Errorf("loans to group members are repaid at the weekly meeting and cannot be daily")
+	}
+
+	return &borrower.Group.MeetingDay, nil
+}
+
+// insertLoan persists a prepared loan with its fees and installments.
+func (l *loanUsecase) insertLoan(ctx context.Context, loan *domain.Loan, paymentSchedules []domain.PaymentSchedule, tx *gorm.DB) error {
+	fees, outstandingAmount := loan.Fees, loan.OutstandingAmount
+	loan.Fees, loan.OutstandingAmount = nil, 0
+
+	err := l.loanRepo.CreateLoan(ctx, loan, tx)
+	if err != nil {
+		return err
+	}
+
+	for i := range fees {
+		fees[i].LoanID = loan.ID
+	}
+
+	err = l.loanRepo.CreateLoanFees(ctx, fees, tx)
+	if err != nil {
+		return err
+	}
+	loan.Fees = fees
+
+	for i := range paymentSchedules {
+		paymentSchedules[i].LoanID = loan.ID
+	}
+
+	loan.OutstandingAmount = outstandingAmount
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		return err
+	}
+
+	return l.paymentScheduleRepo.BulkCreatePaymentSchedule(ctx, paymentSchedules, tx)
+}
+
+// UpdateLoan re-prices a proposed loan with new terms, replacing its fees and
+// payment schedules. Loan terms are fixed once the loan is approved.
+func (l *loanUsecase) UpdateLoan(ctx context.Context, loanID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !loan.Status.IsEditable() {
+		return nil, fmt.Errorf("loan is %s and can no longer be edited", loan.Status)
+	}
+
+	terms, err = l.applyProduct(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	terms.MeetingDay, err = l.meetingDay(ctx, loan.BorrowerID, terms.Frequency)
+	if err != nil {
+		return nil, err
 	}
 
+	fees, upfrontFees, err := assessFees(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentSchedules, totalOutstandingAmount, err := l.buildPaymentSchedules(ctx, terms, amortizedFees(fees))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fees {
+		fees[i].LoanID = loan.ID
+	}
+
+	for i := range paymentSchedules {
+		paymentSchedules[i].LoanID = loan.ID
+	}
+
+	loan.ProductID = terms.ProductID
+	loan.Principal = terms.Principal
+	loan.TotalFees = totalFees(fees)
+	loan.NetDisbursed = terms.Principal - upfrontFees
+	loan.InterestRate = terms.InterestRate
+	loan.ServiceFeeRate = terms.ServiceFeeRate
+	loan.Tenor = terms.Tenor
+	loan.Frequency = terms.Frequency
+	loan.StartDate = terms.StartDate
+	loan.AmortizationMethod = terms.AmortizationMethod
+	loan.RebatePolicy = terms.RebatePolicy
+	loan.DayCount = terms.DayCount
+	loan.AllocationOrder = terms.AllocationOrder
+	loan.PenaltyPolicy = terms.PenaltyPolicy
+	loan.CalendarName = terms.CalendarName
+	loan.RollConvention = terms.RollConvention
 	loan.OutstandingAmount = totalOutstandingAmount
-	err = l.loanRepo.UpdateLoan(ctx, &loan, tx)
+	loan.Fees = nil
+	loan.PaymentSchedules = nil
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.DeleteLoanFees(ctx, loan.ID, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.CreateLoanFees(ctx, fees, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.paymentScheduleRepo.DeletePaymentSchedulesByLoanID(ctx, loan.ID, tx)
 	if err != nil {
 		l.transactionManager.Rollback(tx)
 		return nil, err
@@ -98,27 +290,228 @@ func (l *loanUsecase) CreateLoan(ctx context.Context, borrowerID uint, principal
 		return nil, err
 	}
 
-	return assembleCreateLoanResponse(&loan, paymentSchedules), nil
+	loan.Fees = fees
+	return assembleCreateLoanResponse(loan, paymentSchedules), nil
 }
 
-func assembleCreateLoanResponse(loan *domain.Loan, paymentSchedules []domain.PaymentSchedule) *dto.CreateLoanResponse {
+// QuoteLoan computes the schedule a loan with the given terms would have
+// without persisting anything or requiring a borrower.
+func (l *loanUsecase) QuoteLoan(ctx context.Context, terms domain.LoanTerms) (*dto.QuoteLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	terms, err := l.applyProduct(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, upfrontFees, err := assessFees(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentSchedules, totalRepayable, err := l.buildPaymentSchedules(ctx, terms, amortizedFees(fees))
+	if err != nil {
+		return nil, err
+	}
+	netDisbursed := terms.Principal - upfrontFees
+
+	installmentAmounts := make([]money.Money, len(paymentSchedules))
+	for i, ps := range paymentSchedules {
+		installmentAmounts[i] = ps.DueAmount
+	}
+
+	return &dto.QuoteLoanResponse{
+		ProductID:          terms.ProductID,
+		Principal:          terms.Principal,
+		TotalFees:          totalFees(fees),
+		NetDisbursed:       netDisbursed,
+		Fees:               assembleLoanFeeResponses(fees),
+		InterestRate:       terms.InterestRate,
+		Duration:           terms.Tenor,
+		Frequency:          string(terms.Frequency),
+		AmortizationMethod: string(terms.AmortizationMethod),
+		StartDate:          terms.StartDate,
+		TotalInterest:      totalRepayable - terms.Principal - amortizedFees(fees),
+		TotalRepayable:     totalRepayable,
+		EffectiveRate:      amortization.EffectiveAnnualRate(netDisbursed, installmentAmounts, terms.Frequency.PeriodsPerYear()),
+		PaymentSchedules:   assemblePaymentScheduleResponses(paymentSchedules),
+	}, nil
+}
+
+// applyProduct prices the terms from the loan product and checks that the
+// principal and tenor are within the product's bounds.
+func (l *loanUsecase) applyProduct(ctx context.Context, terms domain.LoanTerms) (domain.LoanTerms, error) {
+	product, err := l.productRepo.FindProductByID(ctx, terms.ProductID)
+	if err != nil {
+		return terms, err
+	}
+
+	if err := product.CheckTerms(terms.Principal, terms.Tenor); err != nil {
+		return terms, err
+	}
+
+	terms.InterestRate = product.InterestRate
+	terms.ServiceFeeRate = product.ServiceFeeRate
+	terms.AmortizationMethod = product.AmortizationMethod
+	terms.RebatePolicy = product.RebatePolicy
+	terms.DayCount = product.DayCount
+	terms.AllocationOrder = product.AllocationOrder
+	terms.PenaltyPolicy = product.PenaltyPolicy
+	terms.Fees = product.Fees
+	return terms, nil
+}
+
+// assessFees prices the product fees for a loan and returns them with the
+// part that is deducted from the disbursement.
+func assessFees(terms domain.LoanTerms) ([]domain.LoanFee, money.Money, error) {
+	var upfront money.Money
+	fees := make([]domain.LoanFee, len(terms.Fees))
+
+	for i, productFee := range terms.Fees {
+		fees[i] = domain.LoanFee{
+			Name:   productFee.Name,
+			Type:   productFee.Type,
+			Charge: productFee.Charge,
+			Amount: productFee.AmountFor(terms.Principal),
+		}
+		if productFee.Charge == domain.FeeUpfront {
+			upfront += fees[i].Amount
+		}
+	}
+
+	if upfront >= terms.Principal {
+		return nil, 0, fmt.Errorf("upfront fees exceed the principal")
+	}
+
+	return fees, upfront, nil
+}
+
+func totalFees(fees []domain.LoanFee) money.Money {
+	var total money.Money
+	for _, fee := range fees {
+		total += fee.Amount
+	}
+	return total
+}
+
+func amortizedFees(fees []domain.LoanFee) money.Money {
+	var total money.Money
+	for _, fee := range fees {
+		if fee.Charge == domain.FeeAmortized {
+			total += fee.Amount
+		}
+	}
+	return total
+}
+
+// buildPaymentSchedules computes the installments for the given terms and
+// returns them with their total. The amortized fee is spread evenly over the
+// installments. The schedules are not yet tied to a loan.
+func (l *loanUsecase) buildPaymentSchedules(ctx context.Context, terms domain.LoanTerms, amortizedFee money.Money) ([]domain.PaymentSchedule, money.Money, error) {
+	if !terms.Frequency.IsValid() {
+		return nil, 0, fmt.Errorf("unsupported repayment frequency: %s", terms.Frequency)
+	}
+
+	calculator, err := amortization.NewScheduleCalculator(terms.AmortizationMethod)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	calendar, err := l.findCalendar(ctx, terms.CalendarName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	periodicRate := terms.Frequency.PeriodicRate(terms.InterestRate)
+	installments := calculator.Calculate(terms.Principal, periodicRate, terms.Tenor)
+	feeParts := amortizedFee.Split(len(installments))
+	var total money.Money
+	var paymentSchedules []domain.PaymentSchedule
+
+	for i, installment := range installments {
+		installmentAmount := installment.Principal + installment.Interest + feeParts[i]
+		total += installmentAmount
+
+		dueDate := terms.Frequency.DueDate(terms.StartDate, i+1)
+		if terms.MeetingDay != nil {
+			dueDate = domain.NextWeekday(dueDate, *terms.MeetingDay)
+		}
+
+		paymentSchedules = append(paymentSchedules, domain.PaymentSchedule{
+			DueDate:         calendar.Adjust(dueDate, terms.RollConvention),
+			DueAmount:       installmentAmount,
+			PrincipalAmount: installment.Principal,
+			InterestAmount:  installment.Interest,
+			FeeAmount:       feeParts[i],
+		})
+	}
+
+	return paymentSchedules, total, nil
+}
+
+// findCalendar returns the named holiday calendar. Loans without a calendar
+// still skip weekends when a roll convention is set.
+func (l *loanUsecase) findCalendar(ctx context.Context, name string) (*domain.HolidayCalendar, error) {
+	if name == "" {
+		return &domain.HolidayCalendar{}, nil
+	}
+
+	return l.calendarRepo.FindCalendarByName(ctx, name)
+}
+
+func assemblePaymentScheduleResponses(paymentSchedules []domain.PaymentSchedule) []dto.GetPaymentScheduleResponse {
 	paymentScheduleResponses := make([]dto.GetPaymentScheduleResponse, len(paymentSchedules))
 	for i, ps := range paymentSchedules {
 		paymentScheduleResponses[i] = dto.GetPaymentScheduleResponse{
-			DueAmount: ps.DueAmount,
-			DueDate:   ps.DueDate,
-			Paid:      ps.Paid,
+			DueAmount:       ps.DueAmount,
+			PrincipalAmount: ps.Components().Principal,
+			InterestAmount:  ps.InterestAmount,
+			FeeAmount:       ps.FeeAmount,
+			PenaltyAmount:   ps.PenaltyAmount,
+			PaidAmount:      ps.PaidAmount,
+			DueDate:         ps.DueDate,
+			Paid:            ps.Paid,
+			Status:          string(ps.Status()),
+		}
+	}
+
+	return paymentScheduleResponses
+}
+
+func assembleLoanFeeResponses(fees []domain.LoanFee) []dto.GetLoanFeeResponse {
+	feeResponses := make([]dto.GetLoanFeeResponse, len(fees))
+	for i, fee := range fees {
+		feeResponses[i] = dto.GetLoanFeeResponse{
+			Name:   fee.Name,
+			Type:   string(fee.Type),
+			Charge: string(fee.Charge),
+			Amount: fee.Amount,
 		}
 	}
 
+	return feeResponses
+}
+
+func assembleCreateLoanResponse(loan *domain.Loan, paymentSchedules []domain.PaymentSchedule) *dto.CreateLoanResponse {
 	loanResponse := dto.CreateLoanResponse{
-		ID:                loan.ID,
-		Principal:         loan.Principal,
-		InterestRate:      loan.InterestRate,
-		Duration:          loan.DurationWeeks,
-		StartDate:         loan.StartDate,
-		OutstandingAmount: loan.OutstandingAmount,
-		PaymentSchedules:  paymentScheduleResponses,
+		ID:                 loan.ID,
+		ProductID:          loan.ProductID,
+		Principal:          loan.Principal,
+		TotalFees:          loan.TotalFees,
+		NetDisbursed:       loan.NetDisbursed,
+		Fees:               assembleLoanFeeResponses(loan.Fees),
+		InterestRate:       loan.InterestRate,
+		Duration:           loan.Tenor,
+		Frequency:          string(loan.Frequency),
+		AmortizationMethod: string(loan.AmortizationMethod),
+		StartDate:          loan.StartDate,
+		Calendar:           loan.CalendarName,
+		RollConvention:     string(loan.RollConvention),
+		Status:             string(loan.Status),
+		OutstandingAmount:  loan.OutstandingAmount,
+		RefinancedLoanID:   loan.RefinancedLoanID,
+		PaymentSchedules:   assemblePaymentScheduleResponses(paymentSchedules),
 	}
 
 	return &loanResponse
@@ -142,15 +535,6 @@ func (l *loanUsecase) GetLoanDetails(ctx context.Context, loanID uint) (*dto.Get
 }
 
 func assembleLoanDetailsResponse(loan *domain.Loan, borrower *domain.Borrower) *dto.GetLoanDetailsResponse {
-	paymentScheduleResponses := make([]dto.GetPaymentScheduleResponse, len(loan.PaymentSchedules))
-	for i, ps := range loan.PaymentSchedules {
-		paymentScheduleResponses[i] = dto.GetPaymentScheduleResponse{
-			DueAmount: ps.DueAmount,
-			DueDate:   ps.DueDate,
-			Paid:      ps.Paid,
-		}
-	}
-
 	borrowerResponse := dto.GetBorrowerResponse{
 		ID:        borrower.ID,
 		FirstName: borrower.FirstName,
@@ -160,20 +544,47 @@ func assembleLoanDetailsResponse(loan *domain.Loan, borrower *domain.Borrower) *
 	}
 
 	loanResponse := dto.GetLoanDetailsResponse{
-		Principal:         loan.Principal,
-		InterestRate:      loan.InterestRate,
-		Duration:          loan.DurationWeeks,
-		OutstandingAmount: loan.OutstandingAmount,
-		StartDate:         loan.StartDate,
-		CreatedAt:         loan.CreatedAt,
-		Borrower:          borrowerResponse,
-		PaymentSchedule:   paymentScheduleResponses,
+		ProductID:          loan.ProductID,
+		Principal:          loan.Principal,
+		TotalFees:          loan.TotalFees,
+		NetDisbursed:       loan.NetDisbursed,
+		Fees:               assembleLoanFeeResponses(loan.Fees),
+		InterestRate:       loan.InterestRate,
+		Duration:           loan.Tenor,
+		Frequency:          string(loan.Frequency),
+		AmortizationMethod: string(loan.AmortizationMethod),
+		OutstandingAmount:  loan.OutstandingAmount,
+		CreditBalance:      loan.CreditBalance,
+		TotalPenalties:     totalPenalties(loan.PaymentSchedules),
+		WrittenOffAmount:   loan.WrittenOffAmount,
+		RecoveredAmount:    loan.RecoveredAmount,
+		StartDate:          loan.StartDate,
+		Calendar:           loan.CalendarName,
+		RollConvention:     string(loan.RollConvention),
+		Status:             string(loan.Status),
+		RefinancedLoanID:   loan.RefinancedLoanID,
+		RefinancedByLoanID: loan.RefinancedByLoanID,
+		CreatedAt:          loan.CreatedAt,
+		Borrower:           borrowerResponse,
+		PaymentSchedule:    assemblePaymentScheduleResponses(loan.PaymentSchedules),
+		StatusHistory:      assembleStatusChangeResponses(loan.StatusHistory),
+	}
+	if loan.Approval != nil {
+		loanResponse.Approval = assembleLoanApprovalResponse(loan.Approval)
 	}
 
 	return &loanResponse
 }
 
-func (l *loanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (float64, error) {
+func totalPenalties(paymentSchedules []domain.PaymentSchedule) money.Money {
+	var total money.Money
+	for _, ps := range paymentSchedules {
+		total += ps.PenaltyAmount
+	}
+	return total
+}
+
+func (l *loanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (money.Money, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
 	defer cancel()
 
@@ -184,3 +595,909 @@ func (l *loanUsecase) GetOutstandingAmount(ctx context.Context, loanID uint) (fl
 
 	return loan.OutstandingAmount, nil
 }
+
+// ApproveLoan moves a proposed loan to approved and records who approved it
+// and the evidence they gave.
+func (l *loanUsecase) ApproveLoan(ctx context.Context, loanID uint, approval domain.LoanApproval) (*dto.GetLoanApprovalResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := loan.TransitionTo(domain.LoanApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.CreateStatusChange(ctx, change, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	approval.LoanID = loan.ID
+	err = l.loanRepo.CreateLoanApproval(ctx, &approval, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return assembleLoanApprovalResponse(&approval), nil
+}
+
+func (l *loanUsecase) GetLoanApproval(ctx context.Context, loanID uint) (*dto.GetLoanApprovalResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Approval == nil {
+		return nil, fmt.Errorf("loan has not been approved")
+	}
+
+	return assembleLoanApprovalResponse(loan.Approval), nil
+}
+
+func (l *loanUsecase) DisburseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	return l.transitionLoan(ctx, loanID, domain.LoanDisbursed)
+}
+
+func (l *loanUsecase) CloseLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	return l.transitionLoan(ctx, loanID, domain.LoanClosed)
+}
+
+func (l *loanUsecase) CancelLoan(ctx context.Context, loanID uint) (*dto.LoanStatusChangeResponse, error) {
+	return l.transitionLoan(ctx, loanID, domain.LoanCancelled)
+}
+
+// WriteOffLoan charges off the outstanding balance of a disbursed loan. Its
+// principal is recorded as a loss and the interest, fees and penalties still
+// owed as income forgone. The loan stops accruing penalties and no longer
+// counts towards delinquency; later payments are taken as recoveries.
+func (l *loanUsecase) WriteOffLoan(ctx context.Context, loanID uint, reason string) (*dto.WriteOffLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := loan.TransitionTo(domain.LoanWrittenOff)
+	if err != nil {
+		return nil, err
+	}
+
+	var principal, forgone money.Money
+	for _, schedule := range loan.PaymentSchedules {
+		outstanding := schedule.Outstanding()
+		principal += outstanding.Principal
+		forgone += outstanding.Total() - outstanding.Principal
+	}
+
+	writeOff := &domain.LoanWriteOff{
+		LoanID:        loan.ID,
+		LossAmount:    principal,
+		ForgoneIncome: forgone,
+		Reason:        reason,
+	}
+	entry := domain.NewWriteOffEntry(loan, principal)
+	loan.WrittenOffAmount = loan.OutstandingAmount
+	loan.OutstandingAmount = 0
+	loan.PaymentSchedules = nil
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.CreateStatusChange(ctx, change, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.CreateWriteOff(ctx, writeOff, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if principal > 0 {
+		err = l.ledgerRepo.CreateJournalEntry(ctx, entry, tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return &dto.WriteOffLoanResponse{
+		LoanID:        loan.ID,
+		WriteOffID:    writeOff.ID,
+		LossAmount:    writeOff.LossAmount,
+		ForgoneIncome: writeOff.ForgoneIncome,
+		Reason:        writeOff.Reason,
+		Status:        string(loan.Status),
+		WrittenOffAt:  writeOff.CreatedAt,
+	}, nil
+}
+
+func (l *loanUsecase) GetPortfolio(ctx context.Context) (*dto.PortfolioResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	summary, err := l.loanRepo.GetPortfolioSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PortfolioResponse{
+		ActiveLoans:       summary.ActiveLoans,
+		ActiveOutstanding: summary.ActiveOutstanding,
+		WrittenOffLoans:   summary.WrittenOffLoans,
+		WrittenOffAmount:  summary.WrittenOffAmount,
+		RecoveredAmount:   summary.RecoveredAmount,
+		NetChargeOff:      summary.NetChargeOff(),
+	}, nil
+}
+
+// transitionLoan moves a loan to the next status and records the change in
+// the same transaction.
+func (l *loanUsecase) transitionLoan(ctx context.Context, loanID uint, next domain.LoanStatus) (*dto.LoanStatusChangeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == domain.LoanClosed && loan.OutstandingAmount > 0 {
+		return nil, fmt.Errorf("loan still has %s outstanding", loan.OutstandingAmount)
+	}
+
+	change, err := loan.TransitionTo(next)
+	if err != nil {
+		return nil, err
+	}
+
+	// A refinanced loan is paid off when the loan refinancing it is
+	// disbursed, and released for another refinance if it is cancelled.
+	var settlement *refinanceSettlement
+	var released *domain.Loan
+	if loan.RefinancedLoanID != nil {
+		switch next {
+		case domain.LoanDisbursed:
+			settlement, err = l.settleRefinancedLoan(ctx, loan)
+			if err != nil {
+				return nil, err
+			}
+		case domain.LoanCancelled:
+			released, err = l.loanRepo.FindLoanByID(ctx, *loan.RefinancedLoanID)
+			if err != nil {
+				return nil, err
+			}
+			released.RefinancedByLoanID = nil
+			released.PaymentSchedules = nil
+		}
+	}
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.CreateStatusChange(ctx, change, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if settlement != nil {
+		err = l.recordRefinanceSettlement(ctx, settlement, tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if released != nil {
+		err = l.loanRepo.UpdateLoan(ctx, released, tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	// Money leaves the platform when the loan is disbursed, not when it is
+	// proposed, so that is when the receivable is booked.
+	if next == domain.LoanDisbursed {
+		err = l.ledgerRepo.CreateJournalEntry(ctx, domain.NewDisbursementEntry(loan), tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return assembleStatusChangeResponse(change), nil
+}
+
+// RestructureLoan replaces the unpaid installments of a disbursed loan with a
+// new schedule starting today. The replaced installments are kept as
+// superseded. Their outstanding principal, plus any capitalized arrears, is
+// re-amortized at the new rate over the new tenor, and fees not yet due are
+// spread over the new installments.
+func (l *loanUsecase) RestructureLoan(ctx context.Context, loanID uint, terms domain.RestructureTerms) (*dto.RestructureLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != domain.LoanDisbursed {
+		return nil, fmt.Errorf("only disbursed loans can be restructured, loan is %s", loan.Status)
+	}
+
+	rate := loan.InterestRate
+	if terms.InterestRate != nil {
+		if *terms.InterestRate < 0 {
+			return nil, fmt.Errorf("interest rate must not be negative")
+		}
+		rate = *terms.InterestRate
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var replacedID []uint
+	var keptOutstanding, principal, futureFees money.Money
+	var capitalized domain.ScheduleAllocation
+	for _, schedule := range loan.PaymentSchedules {
+		if schedule.Paid {
+			continue
+		}
+
+		outstanding := schedule.Outstanding()
+		overdue := schedule.DueDate.Before(today)
+		if overdue && !terms.CapitalizeArrears {
+			keptOutstanding += outstanding.Total()
+			continue
+		}
+
+		principal += outstanding.Principal
+		if overdue {
+			capitalized.Interest += outstanding.Interest
+			capitalized.Fee += outstanding.Fee
+			capitalized.Penalty += outstanding.Penalty
+		} else {
+			futureFees += outstanding.Fee
+		}
+		replacedID = append(replacedID, schedule.ID)
+	}
+
+	if len(replacedID) == 0 {
+		return nil, fmt.Errorf("loan has no installments to restructure")
+	}
+	arrears := capitalized.Total()
+
+	tenor := terms.Tenor
+	if tenor == 0 {
+		tenor = len(replacedID)
+	}
+	if tenor < 0 {
+		return nil, fmt.Errorf("tenor must be positive")
+	}
+
+	meetingDay, err := l.meetingDay(ctx, loan.BorrowerID, loan.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentSchedules, total, err := l.buildPaymentSchedules(ctx, domain.LoanTerms{
+		Principal:          principal + arrears,
+		InterestRate:       rate,
+		Tenor:              tenor,
+		Frequency:          loan.Frequency,
+		AmortizationMethod: loan.AmortizationMethod,
+		StartDate:          today,
+		CalendarName:       loan.CalendarName,
+		RollConvention:     loan.RollConvention,
+		MeetingDay:         meetingDay,
+	}, futureFees)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range paymentSchedules {
+		paymentSchedules[i].LoanID = loan.ID
+	}
+
+	restructure := &domain.LoanRestructure{
+		LoanID:              loan.ID,
+		PreviousTenor:       loan.Tenor,
+		NewTenor:            loan.Tenor - len(replacedID) + tenor,
+		PreviousRate:        loan.InterestRate,
+		NewRate:             rate,
+		CapitalizedArrears:  arrears,
+		PreviousOutstanding: loan.OutstandingAmount,
+		NewOutstanding:      keptOutstanding + total,
+		Reason:              terms.Reason,
+	}
+
+	loan.Tenor = restructure.NewTenor
+	loan.InterestRate = rate
+	loan.OutstandingAmount = restructure.NewOutstanding
+	loan.PaymentSchedules = nil
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.CreateRestructure(ctx, restructure, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.paymentScheduleRepo.SupersedePaymentSchedules(ctx, replacedID, restructure.ID, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.paymentScheduleRepo.BulkCreatePaymentSchedule(ctx, paymentSchedules, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if arrears > 0 {
+		err = l.ledgerRepo.CreateJournalEntry(ctx, domain.NewCapitalizationEntry(loan, capitalized), tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return &dto.RestructureLoanResponse{
+		LoanID:              loan.ID,
+		RestructureID:       restructure.ID,
+		PreviousTenor:       restructure.PreviousTenor,
+		Tenor:               restructure.NewTenor,
+		PreviousRate:        restructure.PreviousRate,
+		InterestRate:        restructure.NewRate,
+		CapitalizedArrears:  restructure.CapitalizedArrears,
+		PreviousOutstanding: restructure.PreviousOutstanding,
+		OutstandingAmount:   restructure.NewOutstanding,
+		SupersededSchedules: len(replacedID),
+		PaymentSchedules:    assemblePaymentScheduleResponses(paymentSchedules),
+	}, nil
+}
+
+// DeferInstallments grants a payment holiday on the next upcoming unpaid
+// installments of a disbursed loan. Under DeferralExtendTenor the deferred
+// installments move past the last one. Under DeferralCapitalizeInterest they
+// are dropped, and what they owed is spread over the installments after them
+// together with interest on the deferred principal for the holiday.
+func (l *loanUsecase) DeferInstallments(ctx context.Context, loanID uint, deferral domain.LoanDeferral) (*dto.DeferInstallmentsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	loan, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != domain.LoanDisbursed {
+		return nil, fmt.Errorf("only disbursed loans can be deferred, loan is %s", loan.Status)
+	}
+
+	paymentSchedules := loan.PaymentSchedules
+	sort.SliceStable(paymentSchedules, func(i, j int) bool {
+		return paymentSchedules[i].DueDate.Before(paymentSchedules[j].DueDate)
+	})
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var deferred, remaining []int
+	for i, schedule := range paymentSchedules {
+		if schedule.Paid || schedule.DueDate.Before(today) {
+			continue
+		}
+
+		if len(deferred) < deferral.Installments {
+			deferred = append(deferred, i)
+		} else {
+			remaining = append(remaining, i)
+		}
+	}
+
+	if len(deferred) < deferral.Installments {
+		return nil, fmt.Errorf("loan has only %d upcoming installments to defer", len(deferred))
+	}
+
+	changed := deferred
+	var capitalized domain.ScheduleAllocation
+	switch deferral.Policy {
+	case domain.DeferralExtendTenor:
+		calendar, err := l.findCalendar(ctx, loan.CalendarName)
+		if err != nil {
+			return nil, err
+		}
+
+		meetingDay, err := l.meetingDay(ctx, loan.BorrowerID, loan.Frequency)
+		if err != nil {
+			return nil, err
+		}
+
+		lastDueDate := paymentSchedules[len(paymentSchedules)-1].DueDate
+		for n, i := range deferred {
+			dueDate := loan.Frequency.DueDate(lastDueDate, n+1)
+			if meetingDay != nil {
+				dueDate = domain.NextWeekday(dueDate, *meetingDay)
+			}
+			paymentSchedules[i].DueDate = calendar.Adjust(dueDate, loan.RollConvention)
+		}
+
+		// The loan now runs as many periods longer as installments were moved.
+		loan.Tenor += len(deferred)
+	case domain.DeferralCapitalizeInterest:
+		if len(remaining) == 0 {
+			return nil, fmt.Errorf("no installments left to capitalize the deferred installments into")
+		}
+
+		var total domain.ScheduleAllocation
+		for _, i := range deferred {
+			owed := paymentSchedules[i].Defer()
+			total.Principal += owed.Principal
+			total.Interest += owed.Interest
+			total.Fee += owed.Fee
+			total.Penalty += owed.Penalty
+		}
+
+		// The deferred principal is repaid as many periods later as
+		// installments were deferred, and is charged interest for them.
+		holidayInterest := total.Principal.MulRate(loan.Frequency.PeriodicRate(loan.InterestRate) * float64(len(deferred)))
+		total.Interest += holidayInterest
+
+		principalParts := total.Principal.Split(len(remaining))
+		interestParts := total.Interest.Split(len(remaining))
+		feeParts := total.Fee.Split(len(remaining))
+		penaltyParts := total.Penalty.Split(len(remaining))
+		for n, i := range remaining {
+			paymentSchedules[i].Capitalize(domain.ScheduleAllocation{
+				Principal: principalParts[n],
+				Interest:  interestParts[n],
+				Fee:       feeParts[n],
+				Penalty:   penaltyParts[n],
+			})
+		}
+
+		// Deferred interest and penalties are owed as principal from now on;
+		// deferred fees are still owed as fees.
+		capitalized = domain.ScheduleAllocation{Interest: total.Interest, Penalty: total.Penalty}
+		deferral.CapitalizedAmount = total.Total()
+		deferral.HolidayInterest = holidayInterest
+		loan.OutstandingAmount += holidayInterest
+		loan.Tenor -= len(deferred)
+		changed = append(changed, remaining...)
+	}
+
+	deferral.LoanID = loan.ID
+	loan.PaymentSchedules = nil
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.loanRepo.CreateDeferral(ctx, &deferral, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	for _, i := range deferred {
+		paymentSchedules[i].DeferralID = &deferral.ID
+	}
+
+	for _, i := range changed {
+		err = l.paymentScheduleRepo.UpdatePaymentSchedule(ctx, &paymentSchedules[i], tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = l.loanRepo.UpdateLoan(ctx, loan, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if capitalized.Total() > 0 {
+		err = l.ledgerRepo.CreateJournalEntry(ctx, domain.NewCapitalizationEntry(loan, capitalized), tx)
+		if err != nil {
+			l.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	sort.SliceStable(paymentSchedules, func(i, j int) bool {
+		return paymentSchedules[i].DueDate.Before(paymentSchedules[j].DueDate)
+	})
+
+	return &dto.DeferInstallmentsResponse{
+		LoanID:            loan.ID,
+		DeferralID:        deferral.ID,
+		Installments:      deferral.Installments,
+		Policy:            string(deferral.Policy),
+		Reason:            deferral.Reason,
+		ApproverID:        deferral.ApproverID,
+		CapitalizedAmount: deferral.CapitalizedAmount,
+		HolidayInterest:   deferral.HolidayInterest,
+		Tenor:             loan.Tenor,
+		OutstandingAmount: loan.OutstandingAmount,
+		PaymentSchedules:  assemblePaymentScheduleResponses(paymentSchedules),
+	}, nil
+}
+
+// RefinanceLoan proposes a new loan for the borrower of a disbursed loan whose
+// principal pays off what is still owed on it. The payoff is set aside from
+// what the new loan disburses; the refinanced loan is only settled and closed
+// when the new loan is disbursed, and stays as it is if the new loan is
+// cancelled instead.
+func (l *loanUsecase) RefinanceLoan(ctx context.Context, loanID uint, terms domain.LoanTerms) (*dto.CreateLoanResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.contextTimeout)
+	defer cancel()
+
+	refinanced, err := l.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refinanced.Status != domain.LoanDisbursed {
+		return nil, fmt.Errorf("only disbursed loans can be refinanced, loan is %s", refinanced.Status)
+	}
+
+	if refinanced.RefinancedByLoanID != nil {
+		return nil, fmt.Errorf("loan is already being refinanced by loan %d", *refinanced.RefinancedByLoanID)
+	}
+
+	loan, paymentSchedules, err := l.prepareLoan(ctx, refinanced.BorrowerID, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	payoff := refinancePayoff(refinanced)
+	if payoff > loan.NetDisbursed {
+		return nil, fmt.Errorf("new loan disburses %s, not enough to pay off the outstanding %s", loan.NetDisbursed, payoff)
+	}
+
+	loan.NetDisbursed -= payoff
+	loan.RefinancedLoanID = &refinanced.ID
+	refinanced.PaymentSchedules = nil
+
+	tx := l.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	err = l.insertLoan(ctx, loan, paymentSchedules, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	refinanced.RefinancedByLoanID = &loan.ID
+	err = l.loanRepo.UpdateLoan(ctx, refinanced, tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	err = l.transactionManager.Commit(tx)
+	if err != nil {
+		l.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	loanResponse := assembleCreateLoanResponse(loan, paymentSchedules)
+	loanResponse.RefinancePayoff = payoff
+
+	return loanResponse, nil
+}
+
+// refinancePayoff is what the new loan has to pay to settle a refinanced
+// loan, after its credit balance.
+func refinancePayoff(refinanced *domain.Loan) money.Money {
+	return max(refinanced.OutstandingAmount-refinanced.CreditBalance, 0)
+}
+
+// refinanceSettlement is the payoff of a refinanced loan by the loan
+// refinancing it. What the payoff and the credit balance settle is recorded as
+// a payment on the refinanced loan, so its investors are repaid like for any
+// other payment. Credit left over once the loan is settled is refunded.
+type refinanceSettlement struct {
+	loan        *domain.Loan
+	schedules   []domain.PaymentSchedule
+	settled     domain.ScheduleAllocation
+	payment     *domain.Payment
+	allocations []domain.PaymentAllocation
+	investments []domain.LoanInvestment
+	change      *domain.LoanStatusChange
+	entry       *domain.JournalEntry
+	refund      *domain.Refund
+	refinance   *domain.LoanRefinance
+}
+
+// settleRefinancedLoan works out the payoff of the loan refinanced by loan as
+// it stands at disbursement. Payments the borrower made since the refinance
+// was proposed lower the payoff, and the difference is disbursed to them.
+func (l *loanUsecase) settleRefinancedLoan(ctx context.Context, loan *domain.Loan) (*refinanceSettlement, error) {
+	refinanced, err := l.loanRepo.FindLoanByID(ctx, *loan.RefinancedLoanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refinanced.Status != domain.LoanDisbursed {
+		return nil, fmt.Errorf("refinanced loan %d is %s and can no longer be paid off", refinanced.ID, refinanced.Status)
+	}
+
+	payoff := refinancePayoff(refinanced)
+	available := loan.Principal - loan.UpfrontFees()
+	if payoff > available {
+		return nil, fmt.Errorf("new loan disburses %s, not enough to pay off the outstanding %s", available, payoff)
+	}
+	loan.NetDisbursed = available - payoff
+
+	investments, err := l.investorRepo.GetInvestmentsByLoanID(ctx, refinanced.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	settlement := &refinanceSettlement{
+		loan:        refinanced,
+		payment:     &domain.Payment{LoanID: refinanced.ID},
+		investments: investments,
+		refinance: &domain.LoanRefinance{
+			LoanID:           loan.ID,
+			RefinancedLoanID: refinanced.ID,
+			PayoffAmount:     payoff,
+		},
+	}
+
+	settled := &settlement.settled
+	for _, schedule := range refinanced.PaymentSchedules {
+		if schedule.Paid {
+			continue
+		}
+		applied := schedule.Settle(0)
+		settled.Principal += applied.Principal
+		settled.Interest += applied.Interest
+		settled.Fee += applied.Fee
+		settled.Penalty += applied.Penalty
+		settlement.schedules = append(settlement.schedules, schedule)
+		settlement.allocations = append(settlement.allocations, applied.Allocations(schedule.ID, refinanced.AllocationOrder)...)
+	}
+	settlement.payment.Amount = settled.Total()
+	settlement.payment.Principal = settled.Principal
+	settlement.payment.Interest = settled.Interest
+	settlement.payment.FeeAmount = settled.Fee
+	settlement.payment.Penalty = settled.Penalty
+	settlement.entry = domain.NewRefinancePayoffEntry(refinanced, *settled, payoff)
+
+	settlement.change, err = refinanced.TransitionTo(domain.LoanClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	refinanced.PaymentSchedules = nil
+	refinanced.OutstandingAmount = 0
+	refinanced.CreditBalance -= settled.Total() - payoff
+
+	// The closed loan cannot hold a credit balance, so whatever the settlement
+	// did not use is paid out to the borrower with the new loan.
+	if refinanced.CreditBalance > 0 {
+		settlement.refund = &domain.Refund{
+			LoanID: refinanced.ID,
+			Amount: refinanced.CreditBalance,
+			Reason: fmt.Sprintf("credit balance left when refinanced by loan %d", loan.ID),
+		}
+		refinanced.CreditBalance = 0
+	}
+
+	return settlement, nil
+}
+
+// recordRefinanceSettlement stores the payoff of a refinanced loan inside the
+// caller's transaction.
+func (l *loanUsecase) recordRefinanceSettlement(ctx context.Context, settlement *refinanceSettlement, tx *gorm.DB) error {
+	if settlement.settled.Total() > 0 {
+		if err := l.paymentRepo.CreatePayment(ctx, settlement.payment, tx); err != nil {
+			return err
+		}
+
+		if len(settlement.allocations) > 0 {
+			for i := range settlement.allocations {
+				settlement.allocations[i].PaymentID = settlement.payment.ID
+			}
+			if err := l.paymentRepo.CreatePaymentAllocations(ctx, settlement.allocations, tx); err != nil {
+				return err
+			}
+		}
+
+		if len(settlement.investments) > 0 {
+			returns := domain.DistributeRepayment(settlement.payment, settlement.investments, settlement.loan.ServiceFeeRate)
+			if err := l.investorRepo.CreateInvestorReturns(ctx, returns, tx); err != nil {
+				return err
+			}
+		}
+
+		settlement.entry.PaymentID = &settlement.payment.ID
+		if err := l.ledgerRepo.CreateJournalEntry(ctx, settlement.entry, tx); err != nil {
+			return err
+		}
+	}
+
+	if settlement.refund != nil {
+		if err := l.paymentRepo.CreateRefund(ctx, settlement.refund, tx); err != nil {
+			return err
+		}
+
+		if err := l.ledgerRepo.CreateJournalEntry(ctx, domain.NewRefundEntry(settlement.loan, settlement.refund), tx); err != nil {
+			return err
+		}
+	}
+
+	for i := range settlement.schedules {
+		if err := l.paymentScheduleRepo.UpdatePaymentSchedule(ctx, &settlement.schedules[i], tx); err != nil {
+			return err
+		}
+	}
+
+	if err := l.loanRepo.UpdateLoan(ctx, settlement.loan, tx); err != nil {
+		return err
+	}
+
+	if err := l.loanRepo.CreateStatusChange(ctx, settlement.change, tx); err != nil {
+		return err
+	}
+
+	return l.loanRepo.CreateRefinance(ctx, settlement.refinance, tx)
+}
+
+func assembleLoanApprovalResponse(approval *domain.LoanApproval) *dto.GetLoanApprovalResponse {
+	return &dto.GetLoanApprovalResponse{
+		LoanID:            approval.LoanID,
+		ValidatorID:       approval.ValidatorID,
+		ApprovalDate:      approval.ApprovalDate,
+		ProofOfVisitImage: approval.ProofOfVisitImage,
+		ApprovedAt:        approval.CreatedAt,
+	}
+}
+
+func assembleStatusChangeResponse(change *domain.LoanStatusChange) *dto.LoanStatusChangeResponse {
+	return &dto.LoanStatusChangeResponse{
+		LoanID:     change.LoanID,
+		FromStatus: string(change.FromStatus),
+		ToStatus:   string(change.ToStatus),
+		ChangedAt:  change.CreatedAt,
+	}
+}
+
+func assembleStatusChangeResponses(changes []domain.LoanStatusChange) []dto.LoanStatusChangeResponse {
+	changeResponses := make([]dto.LoanStatusChangeResponse, len(changes))
+	for i := range changes {
+		changeResponses[i] = *assembleStatusChangeResponse(&changes[i])
+	}
+
+	return changeResponses
+}
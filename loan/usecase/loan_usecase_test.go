@@ -9,6 +9,7 @@ import (
 	"github.com/greekrode/loan-engine-amartha/domain"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
 	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	loanUsecase "github.com/greekrode/loan-engine-amartha/loan/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,11 +48,15 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 						DeletedAt: gorm.DeletedAt{Valid: false},
 					},
 					BorrowerID:        1,
-					Principal:         100.00,
+					Principal:         money.FromFloat(100.00),
+					TotalFees:         money.FromFloat(2.00),
+					NetDisbursed:      money.FromFloat(98.00),
 					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
+					Tenor:             52,
+					Frequency:         domain.FrequencyWeekly,
+					OutstandingAmount: money.FromFloat(1000.00),
 					StartDate:         fixedTime,
+					Status:            domain.LoanDisbursed,
 					PaymentSchedules: []domain.PaymentSchedule{
 						{
 							Model: gorm.Model{
@@ -60,11 +65,17 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 								UpdatedAt: fixedTime,
 								DeletedAt: gorm.DeletedAt{Valid: false},
 							},
-							DueAmount: 100.00,
+							DueAmount: money.FromFloat(100.00),
 							DueDate:   fixedTime,
 							Paid:      false,
 						},
 					},
+					Fees: []domain.LoanFee{
+						{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Amount: money.FromFloat(2.00)},
+					},
+					StatusHistory: []domain.LoanStatusChange{
+						{Model: gorm.Model{CreatedAt: fixedTime}, LoanID: 1, FromStatus: domain.LoanInvested, ToStatus: domain.LoanDisbursed},
+					},
 				}, nil)
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -79,11 +90,18 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 				}, nil)
 			},
 			expected: &dto.GetLoanDetailsResponse{
-				Principal:         100.00,
+				Principal:    money.FromFloat(100.00),
+				TotalFees:    money.FromFloat(2.00),
+				NetDisbursed: money.FromFloat(98.00),
+				Fees: []dto.GetLoanFeeResponse{
+					{Name: "Admin", Type: "percentage", Charge: "upfront", Amount: money.FromFloat(2.00)},
+				},
 				InterestRate:      10.00,
-				OutstandingAmount: 1000.00,
+				OutstandingAmount: money.FromFloat(1000.00),
 				Duration:          52,
+				Frequency:         "weekly",
 				StartDate:         fixedTime,
+				Status:            "disbursed",
 				CreatedAt:         fixedTime,
 				Borrower: dto.GetBorrowerResponse{
 					ID:        1,
@@ -94,11 +112,16 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 				},
 				PaymentSchedule: []dto.GetPaymentScheduleResponse{
 					{
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
+						DueAmount:       money.FromFloat(100.00),
+						PrincipalAmount: money.FromFloat(100),
+						DueDate:         fixedTime,
+						Paid:            false,
+						Status:          "unpaid",
 					},
 				},
+				StatusHistory: []dto.LoanStatusChangeResponse{
+					{LoanID: 1, FromStatus: "invested", ToStatus: "disbursed", ChangedAt: fixedTime},
+				},
 			},
 		},
 		{
@@ -122,10 +145,11 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 						DeletedAt: gorm.DeletedAt{Valid: false},
 					},
 					BorrowerID:        1,
-					Principal:         100.00,
+					Principal:         money.FromFloat(100.00),
 					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
+					Tenor:             52,
+					Frequency:         domain.FrequencyWeekly,
+					OutstandingAmount: money.FromFloat(1000.00),
 					StartDate:         fixedTime,
 					PaymentSchedules: []domain.PaymentSchedule{
 						{
@@ -135,7 +159,7 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 								UpdatedAt: fixedTime,
 								DeletedAt: gorm.DeletedAt{Valid: false},
 							},
-							DueAmount: 100.00,
+							DueAmount: money.FromFloat(100.00),
 							DueDate:   fixedTime,
 							Paid:      false,
 						},
@@ -153,9 +177,11 @@ func (s *LoanUsecaseSuite) TestGetLoanDetails() {
 			mockBorrowerRepo := new(mocks.BorrowerRepository)
 			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
 			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
 			mockTransactionmanager := new(mocks.TransactionManager)
 
-			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionmanager, s.timeout)
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionmanager, s.timeout)
 
 			tt.setupMocks(mockBorrowerRepo, mockLoanRepo)
 			result, err := uc.GetLoanDetails(context.TODO(), tt.loanID)
@@ -177,7 +203,7 @@ func (s *LoanUsecaseSuite) TestGetOutstandingAmount() {
 		name          string
 		loanID        uint
 		setupMocks    func(*mocks.LoanRepository)
-		expected      float64
+		expected      money.Money
 		expectedError error
 	}{
 		{
@@ -192,10 +218,11 @@ func (s *LoanUsecaseSuite) TestGetOutstandingAmount() {
 						DeletedAt: gorm.DeletedAt{Valid: false},
 					},
 					BorrowerID:        1,
-					Principal:         100.00,
+					Principal:         money.FromFloat(100.00),
 					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
+					Tenor:             52,
+					Frequency:         domain.FrequencyWeekly,
+					OutstandingAmount: money.FromFloat(1000.00),
 					StartDate:         fixedTime,
 					PaymentSchedules: []domain.PaymentSchedule{
 						{
@@ -205,14 +232,14 @@ func (s *LoanUsecaseSuite) TestGetOutstandingAmount() {
 								UpdatedAt: fixedTime,
 								DeletedAt: gorm.DeletedAt{Valid: false},
 							},
-							DueAmount: 100,
+							DueAmount: money.FromFloat(100),
 							DueDate:   fixedTime,
 							Paid:      false,
 						},
 					},
 				}, nil)
 			},
-			expected:      1000.00,
+			expected:      money.FromFloat(1000),
 			expectedError: nil,
 		},
 		{
@@ -221,7 +248,7 @@ func (s *LoanUsecaseSuite) TestGetOutstandingAmount() {
 			setupMocks: func(mlr *mocks.LoanRepository) {
 				mlr.On("FindLoanByID", mock.Anything, uint(2)).Return(nil, errors.New("not found"))
 			},
-			expected:      0.00,
+			expected:      money.Money(0),
 			expectedError: errors.New("not found"),
 		},
 	}
@@ -231,9 +258,11 @@ func (s *LoanUsecaseSuite) TestGetOutstandingAmount() {
 			mockBorrowerRepo := new(mocks.BorrowerRepository)
 			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
 			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
 			mockTransactionmanager := new(mocks.TransactionManager)
 
-			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionmanager, s.timeout)
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionmanager, s.timeout)
 
 			tt.setupMocks(mockLoanRepo)
 			result, err := uc.GetOutstandingAmount(context.TODO(), tt.loanID)
@@ -254,21 +283,32 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 	tests := []struct {
 		name          string
 		borrowerID    uint
-		principal     float64
-		interestRate  float64
-		durationWeeks int32
-		startDate     time.Time
+		terms         domain.LoanTerms
+		product       *domain.LoanProduct
+		productErr    error
 		setupMocks    func(*mocks.BorrowerRepository, *mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.TransactionManager)
 		expected      *dto.CreateLoanResponse
 		expectedError error
 	}{
 		{
-			name:          "Successful Creation",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Successful Creation",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID: 1,
+				Principal: money.FromFloat(1000.00),
+				Tenor:     2,
+				Frequency: domain.FrequencyWeekly,
+				StartDate: fixedTime,
+			},
+			product: &domain.LoanProduct{
+				Model:              gorm.Model{ID: 1},
+				Name:               "Weekly Micro",
+				MinPrincipal:       money.FromFloat(500.00),
+				MaxPrincipal:       money.FromFloat(10000.00),
+				Tenors:             []int{2, 4},
+				InterestRate:       5.00,
+				AmortizationMethod: domain.AmortizationFlat,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -285,40 +325,348 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 				mtm.On("Commit", mock.Anything).Return(nil)
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
 				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
 				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
 			},
 			expected: &dto.CreateLoanResponse{
-				ID:                0,
-				Principal:         1000.00,
-				InterestRate:      5.00,
-				Duration:          2,
-				StartDate:         fixedTime,
-				OutstandingAmount: 1001.92,
+				ProductID:          1,
+				ID:                 0,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1001.92),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{
+						ID:              0,
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(7 * 24 * time.Hour),
+						Paid:            false,
+						Status:          "unpaid",
+					},
+					{
+						ID:              0,
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(14 * 24 * time.Hour),
+						Paid:            false,
+						Status:          "unpaid",
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Successful Creation With Remainder On Final Installment",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              3,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+			},
+			expected: &dto.CreateLoanResponse{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           3,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1002.88),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{
+						DueAmount:       money.FromFloat(334.29),
+						PrincipalAmount: money.FromFloat(333.33),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(7 * 24 * time.Hour),
+						Status:          "unpaid",
+					},
+					{
+						DueAmount:       money.FromFloat(334.29),
+						PrincipalAmount: money.FromFloat(333.33),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(14 * 24 * time.Hour),
+						Status:          "unpaid",
+					},
+					{
+						DueAmount:       money.FromFloat(334.30),
+						PrincipalAmount: money.FromFloat(333.34),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(21 * 24 * time.Hour),
+						Status:          "unpaid",
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Successful Monthly Creation From Month End",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1200.00),
+				InterestRate:       12.00,
+				Tenor:              3,
+				Frequency:          domain.FrequencyMonthly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+			},
+			expected: &dto.CreateLoanResponse{
+				ProductID:          1,
+				Principal:          money.FromFloat(1200.00),
+				NetDisbursed:       money.FromFloat(1200.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       12.00,
+				Duration:           3,
+				Frequency:          "monthly",
+				AmortizationMethod: "flat",
+				StartDate:          time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1236.00),
 				PaymentSchedules: []dto.GetPaymentScheduleResponse{
 					{
-						ID:        0,
-						DueAmount: 500.96,
-						DueDate:   fixedTime.Add(7 * 24 * time.Hour),
-						Paid:      false,
+						DueAmount:       money.FromFloat(412.00),
+						PrincipalAmount: money.FromFloat(400),
+						InterestAmount:  money.FromFloat(12),
+						DueDate:         time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+						Status:          "unpaid",
+					},
+					{
+						DueAmount:       money.FromFloat(412.00),
+						PrincipalAmount: money.FromFloat(400),
+						InterestAmount:  money.FromFloat(12),
+						DueDate:         time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+						Status:          "unpaid",
 					},
 					{
-						ID:        0,
-						DueAmount: 500.96,
-						DueDate:   fixedTime.Add(14 * 24 * time.Hour),
-						Paid:      false,
+						DueAmount:       money.FromFloat(412.00),
+						PrincipalAmount: money.FromFloat(400),
+						InterestAmount:  money.FromFloat(12),
+						DueDate:         time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+						Status:          "unpaid",
 					},
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			name:          "Borrower Not Found",
-			borrowerID:    2,
-			principal:     500.00,
-			interestRate:  5.00,
-			durationWeeks: 52,
-			startDate:     fixedTime,
+			name:       "Group Member Due On Meeting Day",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
+					Model: gorm.Model{ID: 1},
+					Group: &domain.BorrowerGroup{MeetingDay: time.Tuesday},
+				}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+			},
+			expected: &dto.CreateLoanResponse{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1001.92),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC),
+						Status:          "unpaid",
+					},
+					{
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         time.Date(2023, time.January, 17, 0, 0, 0, 0, time.UTC),
+						Status:          "unpaid",
+					},
+				},
+			},
+		},
+		{
+			name:       "Daily Loan For Group Member",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyDaily,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
+					Model: gorm.Model{ID: 1},
+					Group: &domain.BorrowerGroup{MeetingDay: time.Tuesday},
+				}, nil)
+			},
+			expectedError: errors.New("loans to group members are repaid at the weekly meeting and cannot be daily"),
+		},
+		{
+			name:       "Unsupported Repayment Frequency",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.RepaymentFrequency("yearly"),
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+			},
+			expected:      nil,
+			expectedError: errors.New("unsupported repayment frequency: yearly"),
+		},
+		{
+			name:       "Unsupported Amortization Method",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationMethod("balloon"),
+				StartDate:          fixedTime,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+			},
+			expected:      nil,
+			expectedError: errors.New("unsupported amortization method: balloon"),
+		},
+		{
+			name:       "Product Not Found",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID: 9,
+				Principal: money.FromFloat(1000.00),
+				Tenor:     2,
+				Frequency: domain.FrequencyWeekly,
+				StartDate: fixedTime,
+			},
+			productErr: errors.New("Loan product not found"),
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expected:      nil,
+			expectedError: errors.New("Loan product not found"),
+		},
+		{
+			name:       "Principal Outside Product Range",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID: 1,
+				Principal: money.FromFloat(50000.00),
+				Tenor:     2,
+				Frequency: domain.FrequencyWeekly,
+				StartDate: fixedTime,
+			},
+			product: &domain.LoanProduct{
+				Name:               "Weekly Micro",
+				MinPrincipal:       money.FromFloat(500.00),
+				MaxPrincipal:       money.FromFloat(10000.00),
+				Tenors:             []int{2, 4},
+				InterestRate:       5.00,
+				AmortizationMethod: domain.AmortizationFlat,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expected:      nil,
+			expectedError: errors.New("principal must be between 500.00 and 10000.00"),
+		},
+		{
+			name:       "Tenor Not Offered By Product",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID: 1,
+				Principal: money.FromFloat(1000.00),
+				Tenor:     0,
+				Frequency: domain.FrequencyWeekly,
+				StartDate: fixedTime,
+			},
+			product: &domain.LoanProduct{
+				Name:               "Weekly Micro",
+				MinPrincipal:       money.FromFloat(500.00),
+				MaxPrincipal:       money.FromFloat(10000.00),
+				Tenors:             []int{2, 4},
+				InterestRate:       5.00,
+				AmortizationMethod: domain.AmortizationFlat,
+			},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expected:      nil,
+			expectedError: errors.New("tenor 0 is not offered by product Weekly Micro"),
+		},
+		{
+			name:       "Borrower Not Found",
+			borrowerID: 2,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(500.00),
+				InterestRate:       5.00,
+				Tenor:              52,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(2)).Return(nil, errors.New("borrower not found"))
 			},
@@ -326,12 +674,17 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 			expectedError: errors.New("borrower not found"),
 		},
 		{
-			name:          "Error Creating Loan",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Error Creating Loan",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -353,12 +706,17 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 			expectedError: errors.New("error creating loan"),
 		},
 		{
-			name:          "Error Creating Payment Schedules",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Error Creating Payment Schedules",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -375,6 +733,7 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 				mtm.On("Commit", mock.Anything).Return(nil)
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
 				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
 				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(errors.New("error creating payment schedules"))
 			},
@@ -382,12 +741,17 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 			expectedError: errors.New("error creating payment schedules"),
 		},
 		{
-			name:          "Error Updating Loan",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Error Updating Loan",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -404,18 +768,24 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 				mtm.On("Commit", mock.Anything).Return(nil)
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
 				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(errors.New("error updating loan"))
 			},
 			expected:      nil,
 			expectedError: errors.New("error updating loan"),
 		},
 		{
-			name:          "Error Beginning Transaction",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Error Beginning Transaction",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -436,12 +806,17 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 			expectedError: errors.New("error beginning transaction"),
 		},
 		{
-			name:          "Error Committing Transaction",
-			borrowerID:    1,
-			principal:     1000.00,
-			interestRate:  5.00,
-			durationWeeks: 2,
-			startDate:     fixedTime,
+			name:       "Error Committing Transaction",
+			borrowerID: 1,
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          fixedTime,
+			},
 			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
 				mbr.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{
 					Model: gorm.Model{
@@ -458,6 +833,7 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 				mtm.On("Commit", mock.Anything).Return(errors.New("error committing transaction"))
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
 				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
 				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
 			},
@@ -471,12 +847,1607 @@ func (s *LoanUsecaseSuite) TestCreateLoan() {
 			mockBorrowerRepo := new(mocks.BorrowerRepository)
 			mockLoanRepo := new(mocks.LoanRepository)
 			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
 			mockTransactionManager := new(mocks.TransactionManager)
 
-			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
 
+			if tt.product == nil {
+				tt.product = productFor(tt.terms)
+			}
+			if tt.productErr != nil {
+				mockProductRepo.On("FindProductByID", mock.Anything, tt.terms.ProductID).Return(nil, tt.productErr)
+			} else {
+				mockProductRepo.On("FindProductByID", mock.Anything, tt.terms.ProductID).Return(tt.product, nil)
+			}
 			tt.setupMocks(mockBorrowerRepo, mockLoanRepo, mockPaymentScheduleRepo, mockTransactionManager)
-			result, err := uc.CreateLoan(context.TODO(), tt.borrowerID, tt.principal, tt.interestRate, tt.durationWeeks, tt.startDate)
+			result, err := uc.CreateLoan(context.TODO(), tt.borrowerID, tt.terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestCreateLoanWithHolidayCalendar() {
+	nationalCalendar := &domain.HolidayCalendar{
+		Name: "ID",
+		Holidays: []domain.Holiday{
+			{Date: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), Description: "Nyepi"},
+			{Date: time.Date(2024, time.March, 29, 0, 0, 0, 0, time.UTC), Description: "Good Friday"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		terms            domain.LoanTerms
+		calendarErr      error
+		expectedDueDates []time.Time
+		expectedError    error
+	}{
+		{
+			name: "Following Skips Holiday",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       10.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+				CalendarName:       "ID",
+				RollConvention:     domain.RollFollowing,
+			},
+			expectedDueDates: []time.Time{
+				time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Preceding Rolls Back Before Holiday",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       10.00,
+				Tenor:              1,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+				CalendarName:       "ID",
+				RollConvention:     domain.RollPreceding,
+			},
+			expectedDueDates: []time.Time{
+				time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Modified Following Stays In Month",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       10.00,
+				Tenor:              1,
+				Frequency:          domain.FrequencyMonthly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+				CalendarName:       "ID",
+				RollConvention:     domain.RollModifiedFollowing,
+			},
+			expectedDueDates: []time.Time{
+				time.Date(2024, time.March, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Calendar Not Found",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       10.00,
+				Tenor:              1,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+				CalendarName:       "ID",
+				RollConvention:     domain.RollFollowing,
+			},
+			calendarErr:   errors.New("Holiday calendar not found"),
+			expectedError: errors.New("Holiday calendar not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+			if tt.calendarErr != nil {
+				mockCalendarRepo.On("FindCalendarByName", mock.Anything, "ID").Return(nil, tt.calendarErr)
+			} else {
+				mockCalendarRepo.On("FindCalendarByName", mock.Anything, "ID").Return(nationalCalendar, nil)
+			}
+			mockTransactionManager.On("Begin").Return(&gorm.DB{})
+			mockTransactionManager.On("Commit", mock.Anything).Return(nil)
+			mockLoanRepo.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			mockLoanRepo.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+			mockLoanRepo.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			mockPaymentScheduleRepo.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+
+			mockProductRepo.On("FindProductByID", mock.Anything, tt.terms.ProductID).Return(productFor(tt.terms), nil)
+
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
+
+			result, err := uc.CreateLoan(context.TODO(), 1, tt.terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(s.T(), err)
+			dueDates := make([]time.Time, len(result.PaymentSchedules))
+			for i, schedule := range result.PaymentSchedules {
+				dueDates[i] = schedule.DueDate
+			}
+			assert.Equal(s.T(), tt.expectedDueDates, dueDates)
+			assert.Equal(s.T(), string(tt.terms.RollConvention), result.RollConvention)
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestCreateLoanWithFees() {
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID: 1,
+		Principal: money.FromFloat(1000.00),
+		Tenor:     2,
+		Frequency: domain.FrequencyWeekly,
+		StartDate: fixedTime,
+	}
+
+	tests := []struct {
+		name          string
+		fees          []domain.LoanProductFee
+		expected      *dto.CreateLoanResponse
+		expectedFees  []domain.LoanFee
+		expectedError error
+	}{
+		{
+			name: "Upfront And Amortized Fees",
+			fees: []domain.LoanProductFee{
+				{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Rate: 2},
+				{Name: "Service", Type: domain.FeeFlat, Charge: domain.FeeAmortized, Amount: money.FromFloat(10.00)},
+			},
+			expected: &dto.CreateLoanResponse{
+				ProductID:    1,
+				Principal:    money.FromFloat(1000.00),
+				TotalFees:    money.FromFloat(30.00),
+				NetDisbursed: money.FromFloat(980.00),
+				Fees: []dto.GetLoanFeeResponse{
+					{Name: "Admin", Type: "percentage", Charge: "upfront", Amount: money.FromFloat(20.00)},
+					{Name: "Service", Type: "flat", Charge: "amortized", Amount: money.FromFloat(10.00)},
+				},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1011.92),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(505.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), FeeAmount: money.FromFloat(5.00), DueDate: fixedTime.AddDate(0, 0, 7), Status: "unpaid"},
+					{DueAmount: money.FromFloat(505.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), FeeAmount: money.FromFloat(5.00), DueDate: fixedTime.AddDate(0, 0, 14), Status: "unpaid"},
+				},
+			},
+			expectedFees: []domain.LoanFee{
+				{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Amount: money.FromFloat(20.00)},
+				{Name: "Service", Type: domain.FeeFlat, Charge: domain.FeeAmortized, Amount: money.FromFloat(10.00)},
+			},
+		},
+		{
+			name: "Upfront Fees Exceed Principal",
+			fees: []domain.LoanProductFee{
+				{Name: "Admin", Type: domain.FeeFlat, Charge: domain.FeeUpfront, Amount: money.FromFloat(1000.00)},
+			},
+			expectedError: errors.New("upfront fees exceed the principal"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			product := &domain.LoanProduct{
+				Model:              gorm.Model{ID: 1},
+				Name:               "Weekly Micro",
+				MinPrincipal:       money.FromFloat(500.00),
+				MaxPrincipal:       money.FromFloat(10000.00),
+				Tenors:             []int{2},
+				InterestRate:       5.00,
+				AmortizationMethod: domain.AmortizationFlat,
+				Fees:               tt.fees,
+			}
+			mockProductRepo.On("FindProductByID", mock.Anything, uint(1)).Return(product, nil)
+			mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(1)).Return(&domain.Borrower{}, nil)
+			mockTransactionManager.On("Begin").Return(&gorm.DB{})
+			mockTransactionManager.On("Commit", mock.Anything).Return(nil)
+			mockLoanRepo.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			mockLoanRepo.On("CreateLoanFees", mock.Anything, tt.expectedFees, mock.Anything).Return(nil)
+			mockLoanRepo.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			mockPaymentScheduleRepo.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
+
+			result, err := uc.CreateLoan(context.TODO(), 1, terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+				mockTransactionManager.AssertNotCalled(s.T(), "Begin")
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestQuoteLoan() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		terms         domain.LoanTerms
+		expected      *dto.QuoteLoanResponse
+		expectedError error
+	}{
+		{
+			name: "Flat Weekly Quote",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: domain.AmortizationFlat,
+				StartDate:          startDate,
+				RollConvention:     domain.RollUnadjusted,
+			},
+			expected: &dto.QuoteLoanResponse{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          startDate,
+				TotalInterest:      money.FromFloat(1.92),
+				TotalRepayable:     money.FromFloat(1001.92),
+				EffectiveRate:      6.88,
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC), Status: "unpaid"},
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name: "Unsupported Amortization Method",
+			terms: domain.LoanTerms{
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				InterestRate:       5.00,
+				Tenor:              2,
+				Frequency:          domain.FrequencyWeekly,
+				AmortizationMethod: "balloon",
+				StartDate:          startDate,
+			},
+			expectedError: errors.New("unsupported amortization method: balloon"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockProductRepo.On("FindProductByID", mock.Anything, tt.terms.ProductID).Return(productFor(tt.terms), nil)
+
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
+
+			result, err := uc.QuoteLoan(context.TODO(), tt.terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+
+			mockLoanRepo.AssertNotCalled(s.T(), "CreateLoan", mock.Anything, mock.Anything, mock.Anything)
+			mockTransactionManager.AssertNotCalled(s.T(), "Begin")
+		})
+	}
+}
+
+// productFor returns a product that offers exactly the given terms.
+func productFor(terms domain.LoanTerms) *domain.LoanProduct {
+	return &domain.LoanProduct{
+		Model:              gorm.Model{ID: terms.ProductID},
+		Name:               "Test Product",
+		MinPrincipal:       terms.Principal,
+		MaxPrincipal:       terms.Principal,
+		Tenors:             []int{terms.Tenor},
+		InterestRate:       terms.InterestRate,
+		AmortizationMethod: terms.AmortizationMethod,
+	}
+}
+
+func (s *LoanUsecaseSuite) TestLoanTransitions() {
+	tests := []struct {
+		name          string
+		transition    func(domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error)
+		loan          *domain.Loan
+		setupMocks    func(*mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.LoanStatusChangeResponse
+		expectedError error
+	}{
+		{
+			name: "Cancel Approved Loan",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.CancelLoan(context.TODO(), 1)
+			},
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Status == domain.LoanCancelled
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanApproved,
+					ToStatus:   domain.LoanCancelled,
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.LoanStatusChangeResponse{LoanID: 1, FromStatus: "approved", ToStatus: "cancelled"},
+		},
+		{
+			name: "Disburse Invested Loan",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.DisburseLoan(context.TODO(), 1)
+			},
+			loan: &domain.Loan{
+				Model:        gorm.Model{ID: 1},
+				Status:       domain.LoanInvested,
+				Principal:    money.FromFloat(1000.00),
+				NetDisbursed: money.FromFloat(950.00),
+				Fees:         []domain.LoanFee{{Charge: domain.FeeUpfront, Amount: money.FromFloat(50.00)}},
+			},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil)
+			},
+			expected: &dto.LoanStatusChangeResponse{LoanID: 1, FromStatus: "invested", ToStatus: "disbursed"},
+		},
+		{
+			name: "Disburse Proposed Loan",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.DisburseLoan(context.TODO(), 1)
+			},
+			loan:          &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanProposed},
+			setupMocks:    func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {},
+			expectedError: errors.New("cannot move loan from proposed to disbursed"),
+		},
+		{
+			name: "Close Loan With Outstanding Amount",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.CloseLoan(context.TODO(), 1)
+			},
+			loan:          &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanDisbursed, OutstandingAmount: money.FromFloat(10)},
+			setupMocks:    func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {},
+			expectedError: errors.New("loan still has 10.00 outstanding"),
+		},
+		{
+			name: "Error Recording Status Change",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.CancelLoan(context.TODO(), 1)
+			},
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(errors.New("error recording status change"))
+			},
+			expectedError: errors.New("error recording status change"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			tt.setupMocks(mockLoanRepo, mockTransactionManager)
+			result, err := tt.transition(uc)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestWriteOffLoan() {
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		setupMocks    func(*mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.WriteOffLoanResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Write-Off",
+			loan: &domain.Loan{
+				Model:             gorm.Model{ID: 1},
+				Status:            domain.LoanDisbursed,
+				OutstandingAmount: money.FromFloat(250),
+				PaymentSchedules: []domain.PaymentSchedule{
+					{DueAmount: money.FromFloat(125), PrincipalAmount: money.FromFloat(100), InterestAmount: money.FromFloat(25)},
+					{DueAmount: money.FromFloat(125), PrincipalAmount: money.FromFloat(100), InterestAmount: money.FromFloat(25)},
+				},
+			},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Status == domain.LoanWrittenOff && loan.OutstandingAmount == 0 && loan.WrittenOffAmount == money.FromFloat(250)
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanDisbursed,
+					ToStatus:   domain.LoanWrittenOff,
+				}, mock.Anything).Return(nil)
+				mlr.On("CreateWriteOff", mock.Anything, &domain.LoanWriteOff{
+					LoanID:        1,
+					LossAmount:    money.FromFloat(200),
+					ForgoneIncome: money.FromFloat(50),
+					Reason:        "borrower deceased",
+				}, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.LoanWriteOff).ID = 4
+				}).Return(nil)
+			},
+			expected: &dto.WriteOffLoanResponse{
+				LoanID:        1,
+				WriteOffID:    4,
+				LossAmount:    money.FromFloat(200),
+				ForgoneIncome: money.FromFloat(50),
+				Reason:        "borrower deceased",
+				Status:        "written_off",
+			},
+		},
+		{
+			name:          "Loan Not Disbursed",
+			loan:          &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks:    func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {},
+			expectedError: errors.New("cannot move loan from approved to written_off"),
+		},
+		{
+			name: "Error Recording Write-Off",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanDisbursed, OutstandingAmount: money.FromFloat(250)},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil)
+				mlr.On("CreateWriteOff", mock.Anything, mock.AnythingOfType("*domain.LoanWriteOff"), mock.Anything).Return(errors.New("UNIQUE constraint failed: loan_write_offs.loan_id"))
+			},
+			expectedError: errors.New("UNIQUE constraint failed: loan_write_offs.loan_id"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			tt.setupMocks(mockLoanRepo, mockTransactionManager)
+			result, err := uc.WriteOffLoan(context.TODO(), 1, "borrower deceased")
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+				mockLedgerRepo.AssertCalled(s.T(), "CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+					return len(entry.Postings) == 2 &&
+						entry.Postings[0] == domain.Posting{AccountCode: domain.AccountLoanLoss, Debit: money.FromFloat(200)} &&
+						entry.Postings[1] == domain.Posting{AccountCode: domain.AccountLoansReceivable, Credit: money.FromFloat(200)}
+				}), mock.Anything)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestGetPortfolio() {
+	mockLoanRepo := new(mocks.LoanRepository)
+	uc := loanUsecase.NewLoanUsecase(new(mocks.BorrowerRepository), new(mocks.PaymentScheduleRepository), mockLoanRepo, new(mocks.LoanProductRepository), new(mocks.HolidayCalendarRepository), new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), new(mocks.TransactionManager), s.timeout)
+
+	mockLoanRepo.On("GetPortfolioSummary", mock.Anything).Return(&domain.PortfolioSummary{
+		ActiveLoans:       3,
+		ActiveOutstanding: money.FromFloat(1500),
+		WrittenOffLoans:   1,
+		WrittenOffAmount:  money.FromFloat(200),
+		RecoveredAmount:   money.FromFloat(50),
+	}, nil)
+
+	result, err := uc.GetPortfolio(context.TODO())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &dto.PortfolioResponse{
+		ActiveLoans:       3,
+		ActiveOutstanding: money.FromFloat(1500),
+		WrittenOffLoans:   1,
+		WrittenOffAmount:  money.FromFloat(200),
+		RecoveredAmount:   money.FromFloat(50),
+		NetChargeOff:      money.FromFloat(150),
+	}, result)
+}
+
+func (s *LoanUsecaseSuite) TestApproveLoan() {
+	approvalDate := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	approval := domain.LoanApproval{
+		ValidatorID:       7,
+		ApprovalDate:      approvalDate,
+		ProofOfVisitImage: "visits/loan-1.jpg",
+	}
+
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		setupMocks    func(*mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.GetLoanApprovalResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Approval",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanProposed},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Status == domain.LoanApproved
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanProposed,
+					ToStatus:   domain.LoanApproved,
+				}, mock.Anything).Return(nil)
+				mlr.On("CreateLoanApproval", mock.Anything, &domain.LoanApproval{
+					LoanID:            1,
+					ValidatorID:       7,
+					ApprovalDate:      approvalDate,
+					ProofOfVisitImage: "visits/loan-1.jpg",
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.GetLoanApprovalResponse{
+				LoanID:            1,
+				ValidatorID:       7,
+				ApprovalDate:      approvalDate,
+				ProofOfVisitImage: "visits/loan-1.jpg",
+			},
+		},
+		{
+			name:          "Loan Already Approved",
+			loan:          &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks:    func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {},
+			expectedError: errors.New("cannot move loan from approved to approved"),
+		},
+		{
+			name: "Error Recording Approval",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanProposed},
+			setupMocks: func(mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil)
+				mlr.On("CreateLoanApproval", mock.Anything, mock.AnythingOfType("*domain.LoanApproval"), mock.Anything).Return(errors.New("UNIQUE constraint failed: loan_approvals.loan_id"))
+			},
+			expectedError: errors.New("UNIQUE constraint failed: loan_approvals.loan_id"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			tt.setupMocks(mockLoanRepo, mockTransactionManager)
+			result, err := uc.ApproveLoan(context.TODO(), 1, approval)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestRestructureLoan() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	newRate := 0.0
+	newLoan := func() *domain.Loan {
+		return &domain.Loan{
+			Model:              gorm.Model{ID: 1},
+			Principal:          money.FromFloat(400),
+			Tenor:              4,
+			Frequency:          domain.FrequencyWeekly,
+			AmortizationMethod: domain.AmortizationFlat,
+			OutstandingAmount:  money.FromFloat(305),
+			Status:             domain.LoanDisbursed,
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 1}, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100), PaidAmount: money.FromFloat(100), PrincipalPaid: money.FromFloat(100), DueDate: today.AddDate(0, 0, -14), Paid: true, LoanID: 1},
+				{Model: gorm.Model{ID: 2}, DueAmount: money.FromFloat(105), PrincipalAmount: money.FromFloat(100), PenaltyAmount: money.FromFloat(5), DueDate: today.AddDate(0, 0, -7), LoanID: 1},
+				{Model: gorm.Model{ID: 3}, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100), DueDate: today, LoanID: 1},
+				{Model: gorm.Model{ID: 4}, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(100), DueDate: today.AddDate(0, 0, 7), LoanID: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		terms         domain.RestructureTerms
+		group         *domain.BorrowerGroup
+		setupMocks    func(*mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.TransactionManager)
+		installments  int
+		expected      *dto.RestructureLoanResponse
+		expectedError error
+	}{
+		{
+			name:  "Extend Tenor",
+			loan:  newLoan(),
+			terms: domain.RestructureTerms{Tenor: 4, Reason: "harvest failure"},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateRestructure", mock.Anything, &domain.LoanRestructure{
+					LoanID:              1,
+					PreviousTenor:       4,
+					NewTenor:            6,
+					PreviousOutstanding: money.FromFloat(305),
+					NewOutstanding:      money.FromFloat(305),
+					Reason:              "harvest failure",
+				}, mock.Anything).Return(nil)
+				mpsr.On("SupersedePaymentSchedules", mock.Anything, []uint{3, 4}, uint(0), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedules []domain.PaymentSchedule) bool {
+					return len(schedules) == 4 && schedules[0].DueAmount == money.FromFloat(50) && schedules[0].LoanID == 1
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Tenor == 6 && loan.OutstandingAmount == money.FromFloat(305) && loan.PaymentSchedules == nil
+				}), mock.Anything).Return(nil)
+			},
+			installments: 4,
+			expected: &dto.RestructureLoanResponse{
+				LoanID:              1,
+				PreviousTenor:       4,
+				Tenor:               6,
+				PreviousOutstanding: money.FromFloat(305),
+				OutstandingAmount:   money.FromFloat(305),
+				SupersededSchedules: 2,
+			},
+		},
+		{
+			name:  "Capitalize Arrears",
+			loan:  newLoan(),
+			terms: domain.RestructureTerms{InterestRate: &newRate, CapitalizeArrears: true},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateRestructure", mock.Anything, mock.AnythingOfType("*domain.LoanRestructure"), mock.Anything).Return(nil)
+				mpsr.On("SupersedePaymentSchedules", mock.Anything, []uint{2, 3, 4}, uint(0), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedules []domain.PaymentSchedule) bool {
+					return len(schedules) == 3
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			},
+			installments: 3,
+			expected: &dto.RestructureLoanResponse{
+				LoanID:              1,
+				PreviousTenor:       4,
+				Tenor:               4,
+				CapitalizedArrears:  money.FromFloat(5),
+				PreviousOutstanding: money.FromFloat(305),
+				OutstandingAmount:   money.FromFloat(305),
+				SupersededSchedules: 3,
+			},
+		},
+		{
+			name:  "Align Installments To Group Meeting",
+			loan:  newLoan(),
+			terms: domain.RestructureTerms{Tenor: 4},
+			group: &domain.BorrowerGroup{MeetingDay: today.AddDate(0, 0, 3).Weekday()},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateRestructure", mock.Anything, mock.AnythingOfType("*domain.LoanRestructure"), mock.Anything).Return(nil)
+				mpsr.On("SupersedePaymentSchedules", mock.Anything, []uint{3, 4}, uint(0), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedules []domain.PaymentSchedule) bool {
+					return len(schedules) == 4 && schedules[0].DueDate.Equal(today.AddDate(0, 0, 10)) && schedules[3].DueDate.Equal(today.AddDate(0, 0, 31))
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			},
+			installments: 4,
+			expected: &dto.RestructureLoanResponse{
+				LoanID:              1,
+				PreviousTenor:       4,
+				Tenor:               6,
+				PreviousOutstanding: money.FromFloat(305),
+				OutstandingAmount:   money.FromFloat(305),
+				SupersededSchedules: 2,
+			},
+		},
+		{
+			name: "Loan Not Disbursed",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("only disbursed loans can be restructured, loan is approved"),
+		},
+		{
+			name: "No Installments To Restructure",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanDisbursed, PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 1}, DueAmount: money.FromFloat(100), DueDate: today.AddDate(0, 0, -7), LoanID: 1},
+			}},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("loan has no installments to restructure"),
+		},
+		{
+			name:  "Error Superseding Installments",
+			loan:  newLoan(),
+			terms: domain.RestructureTerms{Tenor: 4},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("CreateRestructure", mock.Anything, mock.AnythingOfType("*domain.LoanRestructure"), mock.Anything).Return(nil)
+				mpsr.On("SupersedePaymentSchedules", mock.Anything, []uint{3, 4}, uint(0), mock.Anything).Return(errors.New("no rows were updated"))
+			},
+			expectedError: errors.New("no rows were updated"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(0)).Return(&domain.Borrower{Group: tt.group}, nil).Maybe()
+			tt.setupMocks(mockLoanRepo, mockPaymentScheduleRepo, mockTransactionManager)
+			result, err := uc.RestructureLoan(context.TODO(), 1, tt.terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Len(s.T(), result.PaymentSchedules, tt.installments)
+				result.PaymentSchedules = nil
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestDeferInstallments() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	newLoan := func() *domain.Loan {
+		schedule := func(id uint, dueDate time.Time) domain.PaymentSchedule {
+			return domain.PaymentSchedule{Model: gorm.Model{ID: id}, DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: dueDate, LoanID: 1}
+		}
+
+		paid := schedule(1, today.AddDate(0, 0, -7))
+		paid.Pay(money.FromFloat(100), nil)
+		return &domain.Loan{
+			Model:             gorm.Model{ID: 1},
+			Tenor:             4,
+			Frequency:         domain.FrequencyWeekly,
+			InterestRate:      52,
+			OutstandingAmount: money.FromFloat(300),
+			Status:            domain.LoanDisbursed,
+			PaymentSchedules:  []domain.PaymentSchedule{paid, schedule(2, today), schedule(3, today.AddDate(0, 0, 7)), schedule(4, today.AddDate(0, 0, 14))},
+		}
+	}
+	deferral := func(installments int, policy domain.DeferralPolicy) domain.LoanDeferral {
+		return domain.LoanDeferral{Installments: installments, Policy: policy, Reason: "Ramadan", ApproverID: 7}
+	}
+
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		deferral      domain.LoanDeferral
+		group         *domain.BorrowerGroup
+		setupMocks    func(*mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.LedgerRepository, *mocks.TransactionManager)
+		expected      *dto.DeferInstallmentsResponse
+		expectedError error
+	}{
+		{
+			name:     "Move Deferred Installment To The End",
+			loan:     newLoan(),
+			deferral: deferral(1, domain.DeferralExtendTenor),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateDeferral", mock.Anything, &domain.LoanDeferral{
+					LoanID:       1,
+					Installments: 1,
+					Policy:       domain.DeferralExtendTenor,
+					Reason:       "Ramadan",
+					ApproverID:   7,
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedule *domain.PaymentSchedule) bool {
+					return schedule.ID == 2 && schedule.DueDate.Equal(today.AddDate(0, 0, 21)) && schedule.DeferralID != nil && !schedule.Deferred
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Tenor == 5 && loan.PaymentSchedules == nil
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.DeferInstallmentsResponse{
+				LoanID:            1,
+				Installments:      1,
+				Policy:            "extend_tenor",
+				Reason:            "Ramadan",
+				ApproverID:        7,
+				Tenor:             5,
+				OutstandingAmount: money.FromFloat(300),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(100), DueDate: today.AddDate(0, 0, -7), Paid: true, Status: "paid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 7), Status: "unpaid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 14), Status: "unpaid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 21), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name:     "Move Deferred Installment To Group Meeting",
+			loan:     newLoan(),
+			deferral: deferral(1, domain.DeferralExtendTenor),
+			group:    &domain.BorrowerGroup{MeetingDay: today.AddDate(0, 0, 3).Weekday()},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateDeferral", mock.Anything, mock.AnythingOfType("*domain.LoanDeferral"), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedule *domain.PaymentSchedule) bool {
+					return schedule.ID == 2 && schedule.DueDate.Equal(today.AddDate(0, 0, 24))
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			},
+			expected: &dto.DeferInstallmentsResponse{
+				LoanID:            1,
+				Installments:      1,
+				Policy:            "extend_tenor",
+				Reason:            "Ramadan",
+				ApproverID:        7,
+				Tenor:             5,
+				OutstandingAmount: money.FromFloat(300),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(100), DueDate: today.AddDate(0, 0, -7), Paid: true, Status: "paid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 7), Status: "unpaid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 14), Status: "unpaid"},
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 24), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name:     "Capitalize Deferred Installment",
+			loan:     newLoan(),
+			deferral: deferral(1, domain.DeferralCapitalizeInterest),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateDeferral", mock.Anything, mock.MatchedBy(func(deferral *domain.LoanDeferral) bool {
+					return deferral.CapitalizedAmount == money.FromFloat(100.90) && deferral.HolidayInterest == money.FromFloat(0.90)
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedule *domain.PaymentSchedule) bool {
+					return schedule.ID == 2 && schedule.Deferred && schedule.DueAmount == 0
+				}), mock.Anything).Return(nil).Once()
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(schedule *domain.PaymentSchedule) bool {
+					return schedule.ID > 2 && schedule.DueAmount == money.FromFloat(150.45) && schedule.PrincipalAmount == money.FromFloat(140.45)
+				}), mock.Anything).Return(nil).Twice()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Tenor == 3 && loan.OutstandingAmount == money.FromFloat(300.90)
+				}), mock.Anything).Return(nil)
+				mler.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+					return entry.Validate() == nil && assert.ObjectsAreEqual(entry.Postings, []domain.Posting{
+						{AccountCode: domain.AccountLoansReceivable, Debit: money.FromFloat(10.90)},
+						{AccountCode: domain.AccountInterestIncome, Credit: money.FromFloat(10.90)},
+					})
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.DeferInstallmentsResponse{
+				LoanID:            1,
+				Installments:      1,
+				Policy:            "capitalize_interest",
+				Reason:            "Ramadan",
+				ApproverID:        7,
+				CapitalizedAmount: money.FromFloat(100.90),
+				HolidayInterest:   money.FromFloat(0.90),
+				Tenor:             3,
+				OutstandingAmount: money.FromFloat(300.90),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(100), PrincipalAmount: money.FromFloat(90), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(100), DueDate: today.AddDate(0, 0, -7), Paid: true, Status: "paid"},
+					{DueDate: today, Paid: true, Status: "deferred"},
+					{DueAmount: money.FromFloat(150.45), PrincipalAmount: money.FromFloat(140.45), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 7), Status: "unpaid"},
+					{DueAmount: money.FromFloat(150.45), PrincipalAmount: money.FromFloat(140.45), InterestAmount: money.FromFloat(10), DueDate: today.AddDate(0, 0, 14), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name:     "Loan Not Disbursed",
+			loan:     &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			deferral: deferral(1, domain.DeferralExtendTenor),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("only disbursed loans can be deferred, loan is approved"),
+		},
+		{
+			name:     "Not Enough Upcoming Installments",
+			loan:     newLoan(),
+			deferral: deferral(4, domain.DeferralExtendTenor),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("loan has only 3 upcoming installments to defer"),
+		},
+		{
+			name:     "Nothing Left To Capitalize Into",
+			loan:     newLoan(),
+			deferral: deferral(3, domain.DeferralCapitalizeInterest),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("no installments left to capitalize the deferred installments into"),
+		},
+		{
+			name:     "Error Recording Deferral",
+			loan:     newLoan(),
+			deferral: deferral(1, domain.DeferralExtendTenor),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mler *mocks.LedgerRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("CreateDeferral", mock.Anything, mock.AnythingOfType("*domain.LoanDeferral"), mock.Anything).Return(errors.New("error recording deferral"))
+			},
+			expectedError: errors.New("error recording deferral"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(0)).Return(&domain.Borrower{Group: tt.group}, nil).Maybe()
+			tt.setupMocks(mockLoanRepo, mockPaymentScheduleRepo, mockLedgerRepo, mockTransactionManager)
+			result, err := uc.DeferInstallments(context.TODO(), 1, tt.deferral)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLedgerRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestRefinanceLoan() {
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID:          1,
+		Principal:          money.FromFloat(1000.00),
+		InterestRate:       5.00,
+		Tenor:              2,
+		Frequency:          domain.FrequencyWeekly,
+		AmortizationMethod: domain.AmortizationFlat,
+		StartDate:          fixedTime,
+	}
+	activeLoan := func(outstanding money.Money) *domain.Loan {
+		return &domain.Loan{
+			Model:             gorm.Model{ID: 1},
+			BorrowerID:        3,
+			Status:            domain.LoanDisbursed,
+			OutstandingAmount: outstanding,
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 11}, LoanID: 1, DueAmount: money.FromFloat(400), PrincipalAmount: money.FromFloat(390), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(400), PrincipalPaid: money.FromFloat(390), InterestPaid: money.FromFloat(10), Paid: true},
+				{Model: gorm.Model{ID: 12}, LoanID: 1, DueAmount: outstanding, PrincipalAmount: outstanding - money.FromFloat(10), InterestAmount: money.FromFloat(10)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		setupMocks    func(*mocks.BorrowerRepository, *mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.TransactionManager)
+		expected      *dto.CreateLoanResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Refinance",
+			loan: activeLoan(money.FromFloat(400)),
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(3)).Return(&domain.Borrower{}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("CreateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.BorrowerID == 3 && *loan.RefinancedLoanID == 1
+				}), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Loan).ID = 2
+				}).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2
+				}), mock.Anything).Return(nil).Once()
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1 && loan.Status == domain.LoanDisbursed && loan.OutstandingAmount == money.FromFloat(400) && *loan.RefinancedByLoanID == 2
+				}), mock.Anything).Return(nil).Once()
+			},
+			expected: &dto.CreateLoanResponse{
+				ID:                 2,
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(600.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1001.92),
+				RefinancedLoanID:   func() *uint { id := uint(1); return &id }(),
+				RefinancePayoff:    money.FromFloat(400),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(7 * 24 * time.Hour),
+						Status:          "unpaid",
+					},
+					{
+						DueAmount:       money.FromFloat(500.96),
+						PrincipalAmount: money.FromFloat(500),
+						InterestAmount:  money.FromFloat(0.96),
+						DueDate:         fixedTime.Add(14 * 24 * time.Hour),
+						Status:          "unpaid",
+					},
+				},
+			},
+		},
+		{
+			name: "Loan Not Disbursed",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("only disbursed loans can be refinanced, loan is approved"),
+		},
+		{
+			name: "Loan Already Being Refinanced",
+			loan: func() *domain.Loan {
+				loan := activeLoan(money.FromFloat(400))
+				refinancedBy := uint(2)
+				loan.RefinancedByLoanID = &refinancedBy
+				return loan
+			}(),
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("loan is already being refinanced by loan 2"),
+		},
+		{
+			name: "New Principal Too Small",
+			loan: activeLoan(money.FromFloat(1500)),
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(3)).Return(&domain.Borrower{}, nil)
+			},
+			expectedError: errors.New("new loan disburses 1000.00, not enough to pay off the outstanding 1500.00"),
+		},
+		{
+			name: "Error Marking Refinanced Loan",
+			loan: activeLoan(money.FromFloat(400)),
+			setupMocks: func(mbr *mocks.BorrowerRepository, mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mtm *mocks.TransactionManager) {
+				mbr.On("FindBorrowerByID", mock.Anything, uint(3)).Return(&domain.Borrower{}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Loan).ID = 2
+				}).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2
+				}), mock.Anything).Return(nil).Once()
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1
+				}), mock.Anything).Return(errors.New("error updating loan")).Once()
+			},
+			expectedError: errors.New("error updating loan"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			mockProductRepo.On("FindProductByID", mock.Anything, uint(1)).Return(productFor(terms), nil)
+			tt.setupMocks(mockBorrowerRepo, mockLoanRepo, mockPaymentScheduleRepo, mockTransactionManager)
+			result, err := uc.RefinanceLoan(context.TODO(), 1, terms)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockLoanRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestRefinanceSettlement() {
+	refinancingLoan := func() *domain.Loan {
+		refinancedID := uint(1)
+		return &domain.Loan{
+			Model:             gorm.Model{ID: 2},
+			BorrowerID:        3,
+			Status:            domain.LoanInvested,
+			Principal:         money.FromFloat(1000),
+			NetDisbursed:      money.FromFloat(600),
+			OutstandingAmount: money.FromFloat(1001.92),
+			RefinancedLoanID:  &refinancedID,
+		}
+	}
+	// refinancedLoan owes 300.00 on its last installment, 100.00 less than
+	// when the refinance was proposed.
+	refinancedLoan := func(status domain.LoanStatus) *domain.Loan {
+		refinancedBy := uint(2)
+		return &domain.Loan{
+			Model:              gorm.Model{ID: 1},
+			BorrowerID:         3,
+			Status:             status,
+			ServiceFeeRate:     10,
+			OutstandingAmount:  money.FromFloat(300),
+			RefinancedByLoanID: &refinancedBy,
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 11}, LoanID: 1, DueAmount: money.FromFloat(400), PrincipalAmount: money.FromFloat(390), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(400), PrincipalPaid: money.FromFloat(390), InterestPaid: money.FromFloat(10), Paid: true},
+				{Model: gorm.Model{ID: 12}, LoanID: 1, DueAmount: money.FromFloat(400), PrincipalAmount: money.FromFloat(390), InterestAmount: money.FromFloat(10), PaidAmount: money.FromFloat(100), PrincipalPaid: money.FromFloat(90), InterestPaid: money.FromFloat(10)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		transition func(domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error)
+		refinanced *domain.Loan
+		setupMocks func(*mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.PaymentRepository, *mocks.InvestorRepository, *mocks.TransactionManager)
+		expected   *dto.LoanStatusChangeResponse
+		// creditReleased is how much of the refinanced loan's credit balance
+		// leaves borrower credit, to settle the loan or to be refunded.
+		creditReleased money.Money
+		expectedError  error
+	}{
+		{
+			name: "Disbursement Pays Off Refinanced Loan",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.DisburseLoan(context.TODO(), 2)
+			},
+			refinanced: refinancedLoan(domain.LoanDisbursed),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.PaymentRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 5}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(800)},
+				}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2 && loan.Status == domain.LoanDisbursed && loan.NetDisbursed == money.FromFloat(700)
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     2,
+					FromStatus: domain.LoanInvested,
+					ToStatus:   domain.LoanDisbursed,
+				}, mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(300),
+					Principal: money.FromFloat(300),
+				}, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 9
+				}).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, []domain.PaymentAllocation{
+					{PaymentID: 9, PaymentScheduleID: 12, Component: domain.ComponentPrincipal, Amount: money.FromFloat(300)},
+				}, mock.Anything).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{PaymentID: 9, LoanID: 1, InvestorID: 7, InvestmentID: 5, Principal: money.FromFloat(300), NetAmount: money.FromFloat(300)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 12 && ps.Paid && ps.PaidAmount == money.FromFloat(400)
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1 && loan.Status == domain.LoanClosed && loan.OutstandingAmount == 0
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanDisbursed,
+					ToStatus:   domain.LoanClosed,
+				}, mock.Anything).Return(nil)
+				mlr.On("CreateRefinance", mock.Anything, &domain.LoanRefinance{
+					LoanID:           2,
+					RefinancedLoanID: 1,
+					PayoffAmount:     money.FromFloat(300),
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.LoanStatusChangeResponse{LoanID: 2, FromStatus: "invested", ToStatus: "disbursed"},
+		},
+		{
+			name: "Credit Balance Larger Than Outstanding",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.DisburseLoan(context.TODO(), 2)
+			},
+			refinanced: func() *domain.Loan {
+				loan := refinancedLoan(domain.LoanDisbursed)
+				loan.CreditBalance = money.FromFloat(350)
+				return loan
+			}(),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.PaymentRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2 && loan.NetDisbursed == money.FromFloat(1000)
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil).Twice()
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(300),
+					Principal: money.FromFloat(300),
+				}, mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.AnythingOfType("[]domain.PaymentAllocation"), mock.Anything).Return(nil)
+				mpr.On("CreateRefund", mock.Anything, &domain.Refund{
+					LoanID: 1,
+					Amount: money.FromFloat(50),
+					Reason: "credit balance left when refinanced by loan 2",
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil).Once()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1 && loan.Status == domain.LoanClosed && loan.CreditBalance == 0
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("CreateRefinance", mock.Anything, &domain.LoanRefinance{
+					LoanID:           2,
+					RefinancedLoanID: 1,
+				}, mock.Anything).Return(nil)
+			},
+			expected:       &dto.LoanStatusChangeResponse{LoanID: 2, FromStatus: "invested", ToStatus: "disbursed"},
+			creditReleased: money.FromFloat(350),
+		},
+		{
+			name: "Refinanced Loan No Longer Open",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.DisburseLoan(context.TODO(), 2)
+			},
+			refinanced: refinancedLoan(domain.LoanWrittenOff),
+			setupMocks: func(*mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.PaymentRepository, *mocks.InvestorRepository, *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("refinanced loan 1 is written_off and can no longer be paid off"),
+		},
+		{
+			name: "Cancellation Releases Refinanced Loan",
+			transition: func(uc domain.LoanUsecase) (*dto.LoanStatusChangeResponse, error) {
+				return uc.CancelLoan(context.TODO(), 2)
+			},
+			refinanced: refinancedLoan(domain.LoanDisbursed),
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.PaymentRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2 && loan.Status == domain.LoanCancelled
+				}), mock.Anything).Return(nil).Once()
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1 && loan.Status == domain.LoanDisbursed && loan.RefinancedByLoanID == nil && loan.OutstandingAmount == money.FromFloat(300)
+				}), mock.Anything).Return(nil).Once()
+			},
+			expected: &dto.LoanStatusChangeResponse{LoanID: 2, FromStatus: "invested", ToStatus: "cancelled"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockPaymentRepo := new(mocks.PaymentRepository)
+			mockInvestorRepo := new(mocks.InvestorRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			// The disbursement puts the payoff in refinance clearing and the
+			// payoff takes it out again.
+			var entries []*domain.JournalEntry
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Run(func(args mock.Arguments) {
+				entries = append(entries, args.Get(1).(*domain.JournalEntry))
+			}).Return(nil).Maybe()
+			uc := loanUsecase.NewLoanUsecase(new(mocks.BorrowerRepository), mockPaymentScheduleRepo, mockLoanRepo, new(mocks.LoanProductRepository), new(mocks.HolidayCalendarRepository), mockPaymentRepo, mockInvestorRepo, mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(2)).Return(refinancingLoan(), nil)
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.refinanced, nil)
+			tt.setupMocks(mockLoanRepo, mockPaymentScheduleRepo, mockPaymentRepo, mockInvestorRepo, mockTransactionManager)
+			result, err := tt.transition(uc)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.expected, result)
+			mockLoanRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockInvestorRepo.AssertExpectations(s.T())
+
+			var clearing, borrowerCredit money.Money
+			for _, entry := range entries {
+				for _, posting := range entry.Postings {
+					switch posting.AccountCode {
+					case domain.AccountRefinanceClearing:
+						clearing += posting.Debit - posting.Credit
+					case domain.AccountBorrowerCredit:
+						borrowerCredit += posting.Debit - posting.Credit
+					}
+				}
+			}
+			assert.Equal(s.T(), money.Money(0), clearing)
+			assert.Equal(s.T(), tt.creditReleased, borrowerCredit)
+		})
+	}
+}
+
+func (s *LoanUsecaseSuite) TestUpdateLoan() {
+	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	terms := domain.LoanTerms{
+		ProductID: 1,
+		Principal: money.FromFloat(1000.00),
+		Tenor:     2,
+		Frequency: domain.FrequencyWeekly,
+		StartDate: fixedTime,
+	}
+	product := &domain.LoanProduct{
+		Model:              gorm.Model{ID: 1},
+		Name:               "Weekly Micro",
+		MinPrincipal:       money.FromFloat(500.00),
+		MaxPrincipal:       money.FromFloat(10000.00),
+		Tenors:             []int{2},
+		InterestRate:       5.00,
+		AmortizationMethod: domain.AmortizationFlat,
+	}
+
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		group         *domain.BorrowerGroup
+		setupMocks    func(*mocks.LoanRepository, *mocks.PaymentScheduleRepository, *mocks.LoanProductRepository, *mocks.TransactionManager)
+		expected      *dto.CreateLoanResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Update Of Proposed Loan",
+			loan: &domain.Loan{
+				Model:     gorm.Model{ID: 1},
+				ProductID: 1,
+				Principal: money.FromFloat(600.00),
+				Tenor:     2,
+				Status:    domain.LoanProposed,
+				PaymentSchedules: []domain.PaymentSchedule{
+					{Model: gorm.Model{ID: 1}, LoanID: 1, DueAmount: money.FromFloat(300.00)},
+				},
+			},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mpr.On("FindProductByID", mock.Anything, uint(1)).Return(product, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.Principal == money.FromFloat(1000.00) && loan.PaymentSchedules == nil
+				}), mock.Anything).Return(nil)
+				mlr.On("DeleteLoanFees", mock.Anything, uint(1), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, []domain.LoanFee{}, mock.Anything).Return(nil)
+				mpsr.On("DeletePaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, []domain.PaymentSchedule{
+					{LoanID: 1, DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500.00), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 7)},
+					{LoanID: 1, DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500.00), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 14)},
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.CreateLoanResponse{
+				ID:                 1,
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1001.92),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 7), Status: "unpaid"},
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 14), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name:  "Align Installments To Group Meeting",
+			loan:  &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanProposed},
+			group: &domain.BorrowerGroup{MeetingDay: time.Tuesday},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mpr.On("FindProductByID", mock.Anything, uint(1)).Return(product, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("DeleteLoanFees", mock.Anything, uint(1), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, []domain.LoanFee{}, mock.Anything).Return(nil)
+				mpsr.On("DeletePaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return(nil)
+				mpsr.On("BulkCreatePaymentSchedule", mock.Anything, mock.AnythingOfType("[]domain.PaymentSchedule"), mock.Anything).Return(nil)
+			},
+			expected: &dto.CreateLoanResponse{
+				ID:                 1,
+				ProductID:          1,
+				Principal:          money.FromFloat(1000.00),
+				NetDisbursed:       money.FromFloat(1000.00),
+				Fees:               []dto.GetLoanFeeResponse{},
+				InterestRate:       5.00,
+				Duration:           2,
+				Frequency:          "weekly",
+				AmortizationMethod: "flat",
+				StartDate:          fixedTime,
+				Status:             "proposed",
+				OutstandingAmount:  money.FromFloat(1001.92),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 9), Status: "unpaid"},
+					{DueAmount: money.FromFloat(500.96), PrincipalAmount: money.FromFloat(500), InterestAmount: money.FromFloat(0.96), DueDate: fixedTime.AddDate(0, 0, 16), Status: "unpaid"},
+				},
+			},
+		},
+		{
+			name: "Approved Loan Is Not Editable",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanApproved},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("loan is approved and can no longer be edited"),
+		},
+		{
+			name: "Error Replacing Payment Schedules",
+			loan: &domain.Loan{Model: gorm.Model{ID: 1}, Status: domain.LoanProposed},
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository, mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mpr.On("FindProductByID", mock.Anything, uint(1)).Return(product, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("DeleteLoanFees", mock.Anything, uint(1), mock.Anything).Return(nil)
+				mlr.On("CreateLoanFees", mock.Anything, mock.AnythingOfType("[]domain.LoanFee"), mock.Anything).Return(nil)
+				mpsr.On("DeletePaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return(errors.New("error deleting payment schedules"))
+			},
+			expectedError: errors.New("error deleting payment schedules"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockBorrowerRepo := new(mocks.BorrowerRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockCalendarRepo := new(mocks.HolidayCalendarRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := loanUsecase.NewLoanUsecase(mockBorrowerRepo, mockPaymentScheduleRepo, mockLoanRepo, mockProductRepo, mockCalendarRepo, new(mocks.PaymentRepository), new(mocks.InvestorRepository), new(mocks.LedgerRepository), mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			mockBorrowerRepo.On("FindBorrowerByID", mock.Anything, uint(0)).Return(&domain.Borrower{Group: tt.group}, nil).Maybe()
+			tt.setupMocks(mockLoanRepo, mockPaymentScheduleRepo, mockProductRepo, mockTransactionManager)
+			result, err := uc.UpdateLoan(context.TODO(), 1, terms)
 			if tt.expectedError != nil {
 				assert.Error(s.T(), err)
 				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/greekrode/loan-engine-amartha/domain"
@@ -13,15 +14,20 @@ type PaymentHandler struct {
 	PaymentUsecase domain.PaymentUsecase
 }
 
-func NewPaymentHandler(g *gin.Engine, p domain.PaymentUsecase) {
+func NewPaymentHandler(g *gin.Engine, p domain.PaymentUsecase, idempotent gin.HandlerFunc) {
 	handler := &PaymentHandler{PaymentUsecase: p}
 
-	g.GET("/payments/:loan_id", handler.RequestPayment)
-	g.POST("/payments/:loan_id", handler.MakePayment)
+	g.GET("/payments/:id", handler.RequestPayment)
+	g.POST("/payments/:id", idempotent, handler.MakePayment)
+	g.POST("/payments/:id/reverse", handler.ReversePayment)
+	g.GET("/loans/:loan_id/payoff", handler.QuotePayoff)
+	g.POST("/loans/:loan_id/payoff", handler.PayOffLoan)
+	g.POST("/groups/:group_id/settlements", handler.SettleGroupArrears)
+	g.POST("/loans/:loan_id/refunds", handler.RefundCredit)
 }
 
 func (p *PaymentHandler) RequestPayment(c *gin.Context) {
-	loanID := c.Param("loan_id")
+	loanID := c.Param("id")
 	parsedLoanID, err := strconv.ParseUint(loanID, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
@@ -39,7 +45,7 @@ func (p *PaymentHandler) RequestPayment(c *gin.Context) {
 }
 
 func (p *PaymentHandler) MakePayment(c *gin.Context) {
-	loanID := c.Param("loan_id")
+	loanID := c.Param("id")
 	parsedLoanID, err := strconv.ParseUint(loanID, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
@@ -53,17 +59,168 @@ func (p *PaymentHandler) MakePayment(c *gin.Context) {
 		return
 	}
 
-	if len(paymentRequest.PaymentSchedulesID) == 0 {
-		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payment schedule ID is required"})
+	if paymentRequest.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payment amount must be positive"})
 		return
 	}
 
-	err = p.PaymentUsecase.MakePayment(ctx, uint(parsedLoanID), paymentRequest.PaymentSchedulesID, paymentRequest.Amount)
+	paymentResponse, err := p.PaymentUsecase.MakePayment(ctx, uint(parsedLoanID), paymentRequest.Amount)
 	if err != nil {
 		c.JSON(500, dto.CommonResponse{Message: err.Error()})
 		return
 	}
 
-	c.JSON(200, dto.CommonResponse{Message: "payment success"})
+	c.JSON(200, paymentResponse)
+}
+
+func (p *PaymentHandler) QuotePayoff(c *gin.Context) {
+	parsedLoanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return
+	}
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("date"); query != "" {
+		date, err = time.Parse("2006-01-02", query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	quote, err := p.PaymentUsecase.QuotePayoff(c.Request.Context(), uint(parsedLoanID), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func (p *PaymentHandler) PayOffLoan(c *gin.Context) {
+	parsedLoanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return
+	}
+
+	var req dto.PayOffLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Amount == nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payoff amount is required"})
+		return
+	}
+	if *req.Amount < 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payoff amount must not be negative"})
+		return
+	}
+
+	quote, err := p.PaymentUsecase.PayOffLoan(c.Request.Context(), uint(parsedLoanID), *req.Amount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// SettleGroupArrears takes one payment at a group meeting that covers the
+// overdue installments of several members.
+func (p *PaymentHandler) SettleGroupArrears(c *gin.Context) {
+	parsedGroupID, err := strconv.ParseUint(c.Param("group_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid group ID format"})
+		return
+	}
+
+	var req dto.SettleGroupArrearsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.PayerID == 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payer ID is required"})
+		return
+	}
+
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "payment amount must be positive"})
+		return
+	}
+
+	settlement, err := p.PaymentUsecase.SettleGroupArrears(c.Request.Context(), uint(parsedGroupID), req.PayerID, req.Amount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, settlement)
+}
+
+// ReversePayment takes back the payment :id, keyed in by mistake.
+func (p *PaymentHandler) ReversePayment(c *gin.Context) {
+	parsedPaymentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid payment ID format"})
+		return
+	}
+
+	var req dto.ReversePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Reason == "" {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "reason is required"})
+		return
+	}
+
+	reversal, err := p.PaymentUsecase.ReversePayment(c.Request.Context(), uint(parsedPaymentID), req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reversal)
+}
+
+// RefundCredit pays overpaid money held as credit on a loan back to the
+// borrower.
+func (p *PaymentHandler) RefundCredit(c *gin.Context) {
+	parsedLoanID, err := strconv.ParseUint(c.Param("loan_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid loan ID format"})
+		return
+	}
+
+	var req dto.RefundCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return
+	}
+
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "refund amount must be positive"})
+		return
+	}
+
+	if req.Reason == "" {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "reason is required"})
+		return
+	}
+
+	refund, err := p.PaymentUsecase.RefundCredit(c.Request.Context(), uint(parsedLoanID), req.Amount, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
 
+	c.JSON(http.StatusCreated, refund)
 }
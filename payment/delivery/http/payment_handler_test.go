@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
 	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	paymentHttp "github.com/greekrode/loan-engine-amartha/payment/delivery/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,18 +21,48 @@ import (
 
 func setupRouter(mockUCase *mocks.PaymentUsecase) *gin.Engine {
 	router := gin.Default()
-	router.GET("/payments/:loan_id", func(c *gin.Context) {
+	router.GET("/payments/:id", func(c *gin.Context) {
 		handler := paymentHttp.PaymentHandler{
 			PaymentUsecase: mockUCase,
 		}
 		handler.RequestPayment(c)
 	})
-	router.POST("/payments/:loan_id", func(c *gin.Context) {
+	router.POST("/payments/:id", func(c *gin.Context) {
 		handler := paymentHttp.PaymentHandler{
 			PaymentUsecase: mockUCase,
 		}
 		handler.MakePayment(c)
 	})
+	router.GET("/loans/:loan_id/payoff", func(c *gin.Context) {
+		handler := paymentHttp.PaymentHandler{
+			PaymentUsecase: mockUCase,
+		}
+		handler.QuotePayoff(c)
+	})
+	router.POST("/loans/:loan_id/payoff", func(c *gin.Context) {
+		handler := paymentHttp.PaymentHandler{
+			PaymentUsecase: mockUCase,
+		}
+		handler.PayOffLoan(c)
+	})
+	router.POST("/groups/:group_id/settlements", func(c *gin.Context) {
+		handler := paymentHttp.PaymentHandler{
+			PaymentUsecase: mockUCase,
+		}
+		handler.SettleGroupArrears(c)
+	})
+	router.POST("/loans/:loan_id/refunds", func(c *gin.Context) {
+		handler := paymentHttp.PaymentHandler{
+			PaymentUsecase: mockUCase,
+		}
+		handler.RefundCredit(c)
+	})
+	router.POST("/payments/:id/reverse", func(c *gin.Context) {
+		handler := paymentHttp.PaymentHandler{
+			PaymentUsecase: mockUCase,
+		}
+		handler.ReversePayment(c)
+	})
 	return router
 }
 
@@ -51,13 +82,15 @@ func TestRequestPayment(t *testing.T) {
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
 				mockUsecase.On("RequestPayment", mock.Anything, uint(1)).Return(&dto.RequestPaymentResponse{
-					TotalDue: 1000.00,
+					TotalDue:     money.FromFloat(1000.00),
+					TotalPenalty: money.FromFloat(50.00),
 					PaymentSchedules: []dto.GetPaymentScheduleResponse{
 						{
 							ID:        1,
-							DueAmount: 1000.00,
+							DueAmount: money.FromFloat(1000.00),
 							DueDate:   time.Time{},
 							Paid:      false,
+							Status:    "unpaid",
 						},
 					},
 				}, nil)
@@ -66,12 +99,19 @@ func TestRequestPayment(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody: `{
 				"total_due": 1000,
+				"total_penalty": 50,
 				"payment_schedules": [
 					{
 						"id": 1,
 						"due_amount": 1000,
+						"principal_amount": 0,
+						"interest_amount": 0,
+						"fee_amount": 0,
+						"penalty_amount": 0,
+						"paid_amount": 0,
 						"due_date": "0001-01-01T00:00:00Z",
-						"paid": false
+						"paid": false,
+						"status": "unpaid"
 					}
 				]
 			}`,
@@ -133,27 +173,42 @@ func TestMakePayment(t *testing.T) {
 			loanID: "1",
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
-				mockUsecase.On("MakePayment", mock.Anything, uint(1), []uint{1}, 1000.00).Return(nil)
+				mockUsecase.On("MakePayment", mock.Anything, uint(1), money.FromFloat(1000)).Return(&dto.MakePaymentResponse{
+					PaymentID:         3,
+					Amount:            money.FromFloat(1000),
+					AppliedAmount:     money.FromFloat(1000),
+					OutstandingAmount: money.FromFloat(500),
+					LoanStatus:        "disbursed",
+					PaymentSchedules:  []dto.GetPaymentScheduleResponse{},
+					Allocations: []dto.PaymentAllocationResponse{
+						{PaymentScheduleID: 4, Component: "principal", Amount: money.FromFloat(1000)},
+					},
+				}, nil)
 				return mockUsecase
 			}(),
-			requestBody: `{
-				"amount": 1000, 
-				"payment_schedules_id": [
-					1
-				]
-			}`,
+			requestBody:    `{"amount": 1000}`,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"message":"payment success"}`,
+			expectedBody: `{
+				"payment_id": 3,
+				"amount": 1000,
+				"applied_amount": 1000,
+				"credit_amount": 0,
+				"outstanding_amount": 500,
+				"credit_balance": 0,
+				"loan_status": "disbursed",
+				"payment_schedules": [],
+				"allocations": [{"payment_schedule_id": 4, "component": "principal", "amount": 1000}]
+			}`,
 		},
 		{
 			name:   "Invalid Loan ID",
 			loanID: "invalid",
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
-				mockUsecase.On("MakePayment", mock.Anything, uint(1), []uint{1}, 1000.00).Return(errors.New("invalid loan id"))
+				mockUsecase.On("MakePayment", mock.Anything, uint(1), money.FromFloat(1000)).Return(nil, errors.New("invalid loan id"))
 				return mockUsecase
 			}(),
-			requestBody:    `{"amount": 1000, "payment_schedules_id": [1]}`,
+			requestBody:    `{"amount": 1000}`,
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"message":"invalid loan ID format"}`,
 		},
@@ -162,10 +217,10 @@ func TestMakePayment(t *testing.T) {
 			loanID: "1",
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
-				mockUsecase.On("MakePayment", mock.Anything, uint(1), []uint{1}, 1000.00).Return(errors.New("internal server error"))
+				mockUsecase.On("MakePayment", mock.Anything, uint(1), money.FromFloat(1000)).Return(nil, errors.New("internal server error"))
 				return mockUsecase
 			}(),
-			requestBody:    `{"amount": 1000, "payment_schedules_id": [1]}`,
+			requestBody:    `{"amount": 1000}`,
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"message":"internal server error"}`,
 		},
@@ -174,31 +229,485 @@ func TestMakePayment(t *testing.T) {
 			loanID: "1",
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
-				mockUsecase.On("MakePayment", mock.Anything, uint(1), []uint{1}, 1000.00).Return(errors.New("invalid request body"))
+				mockUsecase.On("MakePayment", mock.Anything, uint(1), money.FromFloat(1000)).Return(nil, errors.New("invalid request body"))
 				return mockUsecase
 			}(),
-			requestBody:    `{"amount": 1000, "payment_schedules_id": [1],}`,
+			requestBody:    `{"amount": 1000,}`,
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"message":"invalid request body"}`,
 		},
 		{
-			name:   "Payment Schedule ID Required",
-			loanID: "1",
+			name:           "Amount Not Positive",
+			loanID:         "1",
+			mockUsecase:    new(mocks.PaymentUsecase),
+			requestBody:    `{"amount": 0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"payment amount must be positive"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/payments/"+tt.loanID, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestQuotePayoff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	payoffDate := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		mockUsecase    *mocks.PaymentUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Valid Quote",
+			url:  "/loans/1/payoff?date=2024-03-01",
 			mockUsecase: func() *mocks.PaymentUsecase {
 				mockUsecase := new(mocks.PaymentUsecase)
-				mockUsecase.On("MakePayment", mock.Anything, uint(1), []uint{}, 1000.00).Return(errors.New("payment schedule ID is required"))
+				mockUsecase.On("QuotePayoff", mock.Anything, uint(1), payoffDate).Return(&dto.PayoffQuoteResponse{
+					LoanID:           1,
+					PayoffDate:       payoffDate,
+					RebatePolicy:     "full",
+					Principal:        money.FromFloat(500.00),
+					Interest:         money.FromFloat(20.00),
+					Rebate:           money.FromFloat(10.00),
+					PayoffAmount:     money.FromFloat(510.00),
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{},
+				}, nil)
 				return mockUsecase
 			}(),
-			requestBody:    `{"amount": 1000, "payment_schedules_id": []}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"payoff_date": "2024-03-01T00:00:00Z",
+				"rebate_policy": "full",
+				"principal": 500,
+				"interest": 20,
+				"fees": 0,
+				"penalties": 0,
+				"rebate": 10,
+				"credit_applied": 0,
+				"payoff_amount": 510,
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:           "Invalid Date",
+			url:            "/loans/1/payoff?date=01-03-2024",
+			mockUsecase:    new(mocks.PaymentUsecase),
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"message":"payment schedule ID is required"}`,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name: "Usecase Error",
+			url:  "/loans/1/payoff?date=2024-03-01",
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("QuotePayoff", mock.Anything, uint(1), payoffDate).Return(nil, errors.New("payoff date must not be in the past"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"payoff date must not be in the past"}`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			router := setupRouter(tt.mockUsecase)
-			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/payments/"+tt.loanID, bytes.NewBufferString(tt.requestBody))
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", tt.url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestPayOffLoan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockUsecase    *mocks.PaymentUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Payoff",
+			requestBody: `{"amount": 510}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("PayOffLoan", mock.Anything, uint(1), money.FromFloat(510.00)).Return(&dto.PayoffQuoteResponse{
+					LoanID:           1,
+					RebatePolicy:     "none",
+					Principal:        money.FromFloat(500.00),
+					Interest:         money.FromFloat(10.00),
+					PayoffAmount:     money.FromFloat(510.00),
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"payoff_date": "0001-01-01T00:00:00Z",
+				"rebate_policy": "none",
+				"principal": 500,
+				"interest": 10,
+				"fees": 0,
+				"penalties": 0,
+				"rebate": 0,
+				"credit_applied": 0,
+				"payoff_amount": 510,
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:        "Paid Off By Credit Balance",
+			requestBody: `{"amount": 0}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("PayOffLoan", mock.Anything, uint(1), money.Money(0)).Return(&dto.PayoffQuoteResponse{
+					LoanID:           1,
+					RebatePolicy:     "none",
+					Principal:        money.FromFloat(500.00),
+					Interest:         money.FromFloat(10.00),
+					CreditApplied:    money.FromFloat(510.00),
+					PaymentSchedules: []dto.GetPaymentScheduleResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"loan_id": 1,
+				"payoff_date": "0001-01-01T00:00:00Z",
+				"rebate_policy": "none",
+				"principal": 500,
+				"interest": 10,
+				"fees": 0,
+				"penalties": 0,
+				"rebate": 0,
+				"credit_applied": 510,
+				"payoff_amount": 0,
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:           "Missing Amount",
+			requestBody:    `{}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"payoff amount is required"}`,
+		},
+		{
+			name:           "Negative Amount",
+			requestBody:    `{"amount": -5}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"payoff amount must not be negative"}`,
+		},
+		{
+			name:        "Amount Does Not Match",
+			requestBody: `{"amount": 500}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("PayOffLoan", mock.Anything, uint(1), money.FromFloat(500.00)).Return(nil, errors.New("payment amount does not match the payoff amount of 510.00"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"payment amount does not match the payoff amount of 510.00"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/1/payoff", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestSettleGroupArrears(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockUsecase    *mocks.PaymentUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Settlement",
+			requestBody: `{"payer_id": 5, "amount": 110}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("SettleGroupArrears", mock.Anything, uint(3), uint(5), money.FromFloat(110.00)).Return(&dto.GroupSettlementResponse{
+					SettlementID: 9,
+					GroupID:      3,
+					PayerID:      5,
+					Amount:       money.FromFloat(110.00),
+					Payments: []dto.SettlementPaymentResponse{
+						{
+							PaymentID:         12,
+							LoanID:            20,
+							BorrowerID:        6,
+							Amount:            money.FromFloat(110.00),
+							OutstandingAmount: money.FromFloat(110.00),
+							LoanStatus:        "disbursed",
+							Allocations: []dto.PaymentAllocationResponse{
+								{PaymentScheduleID: 21, Component: "principal", Amount: money.FromFloat(110.00)},
+							},
+						},
+					},
+					Debts: []dto.MemberDebtResponse{
+						{ID: 1, SettlementID: 9, CreditorID: 5, DebtorID: 6, Amount: money.FromFloat(110.00), OutstandingAmount: money.FromFloat(110.00)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{
+				"settlement_id": 9,
+				"group_id": 3,
+				"payer_id": 5,
+				"amount": 110,
+				"payments": [
+					{
+						"payment_id": 12,
+						"loan_id": 20,
+						"borrower_id": 6,
+						"amount": 110,
+						"outstanding_amount": 110,
+						"loan_status": "disbursed",
+						"allocations": [{"payment_schedule_id": 21, "component": "principal", "amount": 110}]
+					}
+				],
+				"debts": [
+					{"id": 1, "settlement_id": 9, "creditor_id": 5, "debtor_id": 6, "amount": 110, "repaid_amount": 0, "outstanding_amount": 110, "created_at": "0001-01-01T00:00:00Z"}
+				]
+			}`,
+		},
+		{
+			name:           "Missing Payer",
+			requestBody:    `{"amount": 110}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"payer ID is required"}`,
+		},
+		{
+			name:           "Missing Amount",
+			requestBody:    `{"payer_id": 5}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"payment amount must be positive"}`,
+		},
+		{
+			name:        "Exceeds Overdue Balance",
+			requestBody: `{"payer_id": 5, "amount": 400}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("SettleGroupArrears", mock.Anything, uint(3), uint(5), money.FromFloat(400.00)).Return(nil, errors.New("payment exceeds the group's overdue balance of 330.00"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"payment exceeds the group's overdue balance of 330.00"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/groups/3/settlements", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestReversePayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		paymentID      string
+		requestBody    string
+		mockUsecase    *mocks.PaymentUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Reversal",
+			paymentID:   "9",
+			requestBody: `{"reason": "keyed against the wrong loan"}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("ReversePayment", mock.Anything, uint(9), "keyed against the wrong loan").Return(&dto.PaymentReversalResponse{
+					ReversalID:        2,
+					PaymentID:         9,
+					LoanID:            1,
+					Amount:            money.FromFloat(110.00),
+					Reason:            "keyed against the wrong loan",
+					OutstandingAmount: money.FromFloat(220.00),
+					LoanStatus:        "disbursed",
+					PaymentSchedules:  []dto.GetPaymentScheduleResponse{},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{
+				"reversal_id": 2,
+				"payment_id": 9,
+				"loan_id": 1,
+				"amount": 110,
+				"reason": "keyed against the wrong loan",
+				"outstanding_amount": 220,
+				"credit_balance": 0,
+				"loan_status": "disbursed",
+				"payment_schedules": []
+			}`,
+		},
+		{
+			name:           "Invalid Payment ID",
+			paymentID:      "abc",
+			requestBody:    `{"reason": "keyed against the wrong loan"}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid payment ID format"}`,
+		},
+		{
+			name:           "Missing Reason",
+			paymentID:      "9",
+			requestBody:    `{}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"reason is required"}`,
+		},
+		{
+			name:        "Already Reversed",
+			paymentID:   "9",
+			requestBody: `{"reason": "keyed against the wrong loan"}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("ReversePayment", mock.Anything, uint(9), "keyed against the wrong loan").Return(nil, errors.New("payment has already been reversed"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"payment has already been reversed"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/payments/"+tt.paymentID+"/reverse", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRefundCredit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockUsecase    *mocks.PaymentUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Valid Refund",
+			requestBody: `{"amount": 3, "reason": "overpaid"}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("RefundCredit", mock.Anything, uint(1), money.FromFloat(3.00), "overpaid").Return(&dto.RefundResponse{
+					RefundID:      4,
+					LoanID:        1,
+					Amount:        money.FromFloat(3.00),
+					Reason:        "overpaid",
+					CreditBalance: money.FromFloat(2.00),
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"refund_id": 4, "loan_id": 1, "amount": 3, "reason": "overpaid", "credit_balance": 2}`,
+		},
+		{
+			name:           "Missing Amount",
+			requestBody:    `{"reason": "overpaid"}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"refund amount must be positive"}`,
+		},
+		{
+			name:           "Missing Reason",
+			requestBody:    `{"amount": 3}`,
+			mockUsecase:    new(mocks.PaymentUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"reason is required"}`,
+		},
+		{
+			name:        "Exceeds Credit Balance",
+			requestBody: `{"amount": 8, "reason": "overpaid"}`,
+			mockUsecase: func() *mocks.PaymentUsecase {
+				mockUsecase := new(mocks.PaymentUsecase)
+				mockUsecase.On("RefundCredit", mock.Anything, uint(1), money.FromFloat(8.00), "overpaid").Return(nil, errors.New("refund exceeds the credit balance of 5.00"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"refund exceeds the credit balance of 5.00"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/loans/1/refunds", bytes.NewBufferString(tt.requestBody))
 			req.Header.Set("Content-Type", "application/json")
 
 			require.NoError(t, err)
@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/greekrode/loan-engine-amartha/db"
 	"github.com/greekrode/loan-engine-amartha/domain"
@@ -23,3 +25,51 @@ func (s *sqlitePaymentRepository) CreatePayment(ctx context.Context, payment *do
 
 	return tx.WithContext(ctx).Create(&payment).Error
 }
+
+func (s *sqlitePaymentRepository) CreatePaymentAllocations(ctx context.Context, allocations []domain.PaymentAllocation, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&allocations).Error
+}
+
+func (s *sqlitePaymentRepository) CreateSettlement(ctx context.Context, settlement *domain.GroupSettlement, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Omit("Payments", "Debts").Create(settlement).Error
+}
+
+func (s *sqlitePaymentRepository) CreateReversal(ctx context.Context, reversal *domain.PaymentReversal, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(reversal).Error
+}
+
+func (s *sqlitePaymentRepository) CreateRefund(ctx context.Context, refund *domain.Refund, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(refund).Error
+}
+
+// FindPaymentByID returns the payment with its allocations and, if it has
+// been reversed, its reversal.
+func (s *sqlitePaymentRepository) FindPaymentByID(ctx context.Context, paymentID uint) (*domain.Payment, error) {
+	var payment domain.Payment
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Allocations").Preload("Reversal").First(&payment, paymentID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Payment not found")
+		}
+		return nil, err
+	}
+
+	return &payment, nil
+}
@@ -3,33 +3,57 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/greekrode/loan-engine-amartha/db"
 	"github.com/greekrode/loan-engine-amartha/domain"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	"gorm.io/gorm"
 )
 
 type paymentUsecase struct {
 	paymentRepo         domain.PaymentRepository
 	paymentScheduleRepo domain.PaymentScheduleRepository
 	loanRepo            domain.LoanRepository
+	investorRepo        domain.InvestorRepository
+	groupRepo           domain.BorrowerGroupRepository
+	ledgerRepo          domain.LedgerRepository
 	transactionManager  db.TransactionManager
 	contextTimeout      time.Duration
 }
 
-func NewPaymentUsecase(p domain.PaymentRepository, ps domain.PaymentScheduleRepository, l domain.LoanRepository, tm db.TransactionManager, timeout time.Duration) domain.PaymentUsecase {
+func NewPaymentUsecase(p domain.PaymentRepository, ps domain.PaymentScheduleRepository, l domain.LoanRepository, i domain.InvestorRepository, g domain.BorrowerGroupRepository, lr domain.LedgerRepository, tm db.TransactionManager, timeout time.Duration) domain.PaymentUsecase {
 	return &paymentUsecase{
 		paymentRepo:         p,
 		paymentScheduleRepo: ps,
 		loanRepo:            l,
+		investorRepo:        i,
+		groupRepo:           g,
+		ledgerRepo:          lr,
 		transactionManager:  tm,
 		contextTimeout:      timeout,
 	}
 }
 
-func (p *paymentUsecase) retrieveAndValidateLoanAndSchedules(ctx context.Context, loanID uint) (*domain.Loan, []domain.PaymentSchedule, error) {
+func (p *paymentUsecase) findDisbursedLoan(ctx context.Context, loanID uint) (*domain.Loan, error) {
 	loan, err := p.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != domain.LoanDisbursed {
+		return nil, fmt.Errorf("payments are only accepted for disbursed loans, loan is %s", loan.Status)
+	}
+
+	return loan, nil
+}
+
+func (p *paymentUsecase) retrieveAndValidateLoanAndSchedules(ctx context.Context, loanID uint) (*domain.Loan, []domain.PaymentSchedule, error) {
+	loan, err := p.findDisbursedLoan(ctx, loanID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -49,41 +73,193 @@ func (p *paymentUsecase) RequestPayment(ctx context.Context, loanID uint) (*dto.
 		return nil, err
 	}
 
-	totalDue := 0.0
+	var totalDue, totalPenalty money.Money
 	scheduleResponses := make([]dto.GetPaymentScheduleResponse, len(paymentSchedules))
 	for i, schedule := range paymentSchedules {
-		totalDue += schedule.DueAmount
-		scheduleResponses[i] = dto.GetPaymentScheduleResponse{
-			ID:        schedule.ID,
-			DueDate:   schedule.DueDate,
-			DueAmount: schedule.DueAmount,
-		}
+		totalDue += schedule.DueAmount - schedule.PaidAmount
+		totalPenalty += schedule.Outstanding().Penalty
+		scheduleResponses[i] = assemblePaymentScheduleResponse(schedule)
 	}
 
 	return &dto.RequestPaymentResponse{
 		TotalDue:         totalDue,
+		TotalPenalty:     totalPenalty,
 		PaymentSchedules: scheduleResponses,
 	}, nil
 }
 
-func (p *paymentUsecase) MakePayment(ctx context.Context, loanID uint, paymentSchedulesID []uint, amount float64) error {
-	loan, paymentSchedules, err := p.retrieveAndValidateLoanAndSchedules(ctx, loanID)
+// MakePayment applies the amount to the loan's unpaid installments, oldest
+// first, settling the components of each installment in the loan's
+// allocation order. An installment that cannot be settled in full is left
+// partially paid. Once every installment is paid, whatever remains is kept as a credit
+// balance on the loan. Payments on a written-off loan are taken as recoveries.
+func (p *paymentUsecase) MakePayment(ctx context.Context, loanID uint, amount money.Money) (*dto.MakePaymentResponse, error) {
+	if amount <= 0 {
+		return nil, errors.New("payment amount must be positive")
+	}
+
+	loan, err := p.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch loan.Status {
+	case domain.LoanDisbursed:
+	case domain.LoanWrittenOff:
+		return p.makeRecovery(ctx, loan, amount)
+	default:
+		return nil, fmt.Errorf("payments are only accepted for disbursed loans, loan is %s", loan.Status)
+	}
+
+	investments, err := p.investorRepo.GetInvestmentsByLoanID(ctx, loanID)
 	if err != nil {
+		return nil, err
+	}
+
+	payment := &domain.Payment{
+		LoanID: loanID,
+		Amount: amount,
+	}
+	remaining := amount
+	var paidSchedules []domain.PaymentSchedule
+	var allocations []domain.PaymentAllocation
+	for _, schedule := range unpaidSchedules(loan.PaymentSchedules) {
+		if remaining == 0 {
+			break
+		}
+
+		applied := schedule.Pay(remaining, loan.AllocationOrder)
+		remaining -= applied.Total()
+		addToPayment(payment, applied)
+		paidSchedules = append(paidSchedules, schedule)
+		allocations = append(allocations, applied.Allocations(schedule.ID, loan.AllocationOrder)...)
+	}
+
+	loan.OutstandingAmount -= amount - remaining
+	loan.CreditBalance += remaining
+
+	// A loan that is fully repaid closes with its last payment.
+	var statusChange *domain.LoanStatusChange
+	if loan.OutstandingAmount == 0 {
+		statusChange, err = loan.TransitionTo(domain.LoanClosed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.recordPayment(ctx, loan, payment, allocations, paidSchedules, investments, statusChange, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	scheduleResponses := make([]dto.GetPaymentScheduleResponse, len(paidSchedules))
+	for i, schedule := range paidSchedules {
+		scheduleResponses[i] = assemblePaymentScheduleResponse(schedule)
+	}
+
+	return &dto.MakePaymentResponse{
+		PaymentID:         payment.ID,
+		Amount:            amount,
+		AppliedAmount:     amount - remaining,
+		CreditAmount:      remaining,
+		OutstandingAmount: loan.OutstandingAmount,
+		CreditBalance:     loan.CreditBalance,
+		LoanStatus:        string(loan.Status),
+		PaymentSchedules:  scheduleResponses,
+		Allocations:       assemblePaymentAllocationResponses(allocations),
+	}, nil
+}
+
+// recordPayment stores a payment with its allocations, investor returns and
+// journal entry, and the installments and loan it settled, inside the
+// caller's transaction. statusChange is nil when the payment leaves the loan's
+// status unchanged.
+func (p *paymentUsecase) recordPayment(ctx context.Context, loan *domain.Loan, payment *domain.Payment, allocations []domain.PaymentAllocation, schedules []domain.PaymentSchedule, investments []domain.LoanInvestment, statusChange *domain.LoanStatusChange, tx *gorm.DB) error {
+	if err := p.paymentRepo.CreatePayment(ctx, payment, tx); err != nil {
 		return err
 	}
 
-	totalDue := 0.0
-	for _, schedule := range paymentSchedules {
-		totalDue += schedule.DueAmount
+	if len(allocations) > 0 {
+		linkAllocations(payment, allocations)
+		if err := p.paymentRepo.CreatePaymentAllocations(ctx, allocations, tx); err != nil {
+			return err
+		}
+	}
+
+	if len(investments) > 0 {
+		returns := domain.DistributeRepayment(payment, investments, loan.ServiceFeeRate)
+		if err := p.investorRepo.CreateInvestorReturns(ctx, returns, tx); err != nil {
+			return err
+		}
 	}
 
-	if amount != totalDue {
-		return errors.New("payment amount does not match the total due amount")
+	if err := p.ledgerRepo.CreateJournalEntry(ctx, domain.NewRepaymentEntry(loan, payment), tx); err != nil {
+		return err
+	}
+
+	for i := range schedules {
+		if err := p.paymentScheduleRepo.UpdatePaymentSchedule(ctx, &schedules[i], tx); err != nil {
+			return err
+		}
 	}
 
+	loan.PaymentSchedules = nil
+	if err := p.loanRepo.UpdateLoan(ctx, loan, tx); err != nil {
+		return err
+	}
+
+	if statusChange != nil {
+		if err := p.loanRepo.CreateStatusChange(ctx, statusChange, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeRecovery takes a payment on a written-off loan as a recovery of the
+// charged-off balance. Recoveries settle no installments and are passed on to
+// investors as principal.
+func (p *paymentUsecase) makeRecovery(ctx context.Context, loan *domain.Loan, amount money.Money) (*dto.MakePaymentResponse, error) {
+	unrecovered := loan.WrittenOffAmount - loan.RecoveredAmount
+	if amount > unrecovered {
+		return nil, fmt.Errorf("payment exceeds the unrecovered balance of %s", unrecovered)
+	}
+
+	investments, err := p.investorRepo.GetInvestmentsByLoanID(ctx, loan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &domain.Payment{
+		LoanID:    loan.ID,
+		Amount:    amount,
+		Principal: amount,
+		Recovery:  true,
+	}
+	loan.RecoveredAmount += amount
+	loan.PaymentSchedules = nil
+
 	tx := p.transactionManager.Begin()
 	if tx.Error != nil {
-		return tx.Error
+		return nil, tx.Error
 	}
 
 	defer func() {
@@ -93,25 +269,650 @@ func (p *paymentUsecase) MakePayment(ctx context.Context, loanID uint, paymentSc
 		}
 	}()
 
+	if err := p.paymentRepo.CreatePayment(ctx, payment, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if len(investments) > 0 {
+		returns := domain.DistributeRepayment(payment, investments, loan.ServiceFeeRate)
+		if err := p.investorRepo.CreateInvestorReturns(ctx, returns, tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := p.ledgerRepo.CreateJournalEntry(ctx, domain.NewRecoveryEntry(loan, payment), tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.loanRepo.UpdateLoan(ctx, loan, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return &dto.MakePaymentResponse{
+		PaymentID:         payment.ID,
+		Amount:            amount,
+		AppliedAmount:     amount,
+		OutstandingAmount: loan.OutstandingAmount,
+		CreditBalance:     loan.CreditBalance,
+		LoanStatus:        string(loan.Status),
+		Recovery:          true,
+		RecoveredAmount:   loan.RecoveredAmount,
+		PaymentSchedules:  []dto.GetPaymentScheduleResponse{},
+		Allocations:       []dto.PaymentAllocationResponse{},
+	}, nil
+}
+
+// addToPayment adds the components applied to an installment to the payment
+// totals.
+func addToPayment(payment *domain.Payment, applied domain.ScheduleAllocation) {
+	payment.Principal += applied.Principal
+	payment.Interest += applied.Interest
+	payment.FeeAmount += applied.Fee
+	payment.Penalty += applied.Penalty
+}
+
+func linkAllocations(payment *domain.Payment, allocations []domain.PaymentAllocation) {
+	for i := range allocations {
+		allocations[i].PaymentID = payment.ID
+	}
+}
+
+func assemblePaymentAllocationResponses(allocations []domain.PaymentAllocation) []dto.PaymentAllocationResponse {
+	allocationResponses := make([]dto.PaymentAllocationResponse, len(allocations))
+	for i, allocation := range allocations {
+		allocationResponses[i] = dto.PaymentAllocationResponse{
+			PaymentScheduleID: allocation.PaymentScheduleID,
+			Component:         string(allocation.Component),
+			Amount:            allocation.Amount,
+		}
+	}
+	return allocationResponses
+}
+
+// unpaidSchedules returns the installments still owing, oldest first.
+func unpaidSchedules(paymentSchedules []domain.PaymentSchedule) []domain.PaymentSchedule {
+	var unpaid []domain.PaymentSchedule
+	for _, schedule := range paymentSchedules {
+		if !schedule.Paid {
+			unpaid = append(unpaid, schedule)
+		}
+	}
+
+	sort.SliceStable(unpaid, func(i, j int) bool {
+		return unpaid[i].DueDate.Before(unpaid[j].DueDate)
+	})
+	return unpaid
+}
+
+func assemblePaymentScheduleResponse(schedule domain.PaymentSchedule) dto.GetPaymentScheduleResponse {
+	return dto.GetPaymentScheduleResponse{
+		ID:              schedule.ID,
+		DueDate:         schedule.DueDate,
+		DueAmount:       schedule.DueAmount,
+		PrincipalAmount: schedule.Components().Principal,
+		InterestAmount:  schedule.InterestAmount,
+		FeeAmount:       schedule.FeeAmount,
+		PenaltyAmount:   schedule.PenaltyAmount,
+		PaidAmount:      schedule.PaidAmount,
+		Paid:            schedule.Paid,
+		Status:          string(schedule.Status()),
+	}
+}
+
+// QuotePayoff returns the amount that settles the loan in full on the given
+// date: everything still unpaid, less the interest rebate the loan's policy
+// grants on installments not yet due and less the loan's credit balance.
+func (p *paymentUsecase) QuotePayoff(ctx context.Context, loanID uint, date time.Time) (*dto.PayoffQuoteResponse, error) {
+	if date.Before(today()) {
+		return nil, errors.New("payoff date must not be in the past")
+	}
+
+	loan, err := p.findDisbursedLoan(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assemblePayoffQuote(loan, date), nil
+}
+
+// PayOffLoan settles every remaining installment at today's payoff amount and
+// closes the loan. The credit balance the quote counts towards the payoff is
+// drawn down along with the payment.
+func (p *paymentUsecase) PayOffLoan(ctx context.Context, loanID uint, amount money.Money) (*dto.PayoffQuoteResponse, error) {
+	loan, err := p.findDisbursedLoan(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := assemblePayoffQuote(loan, today())
+	if amount != quote.PayoffAmount {
+		return nil, fmt.Errorf("payment amount does not match the payoff amount of %s", quote.PayoffAmount)
+	}
+
+	investments, err := p.investorRepo.GetInvestmentsByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
 	payment := &domain.Payment{
 		LoanID: loanID,
 		Amount: amount,
 	}
-	if err := p.paymentRepo.CreatePayment(ctx, payment, tx); err != nil {
+	schedules := unpaidSchedules(loan.PaymentSchedules)
+	waivers := waiveInterest(schedules, quote.Rebate)
+	var allocations []domain.PaymentAllocation
+	for i := range schedules {
+		applied := schedules[i].Settle(waivers[i])
+		addToPayment(payment, applied)
+		allocations = append(allocations, applied.Allocations(schedules[i].ID, loan.AllocationOrder)...)
+	}
+
+	loan.CreditBalance -= quote.CreditApplied
+	loan.OutstandingAmount = 0
+	statusChange, err := loan.TransitionTo(domain.LoanClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.recordPayment(ctx, loan, payment, allocations, schedules, investments, statusChange, tx); err != nil {
 		p.transactionManager.Rollback(tx)
-		return err
+		return nil, err
 	}
 
-	if err := p.paymentScheduleRepo.BulkPayPaymentSchedules(ctx, paymentSchedulesID, tx); err != nil {
+	if err := p.transactionManager.Commit(tx); err != nil {
 		p.transactionManager.Rollback(tx)
-		return err
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// settledLoan collects what a group settlement applied to one member's loan.
+type settledLoan struct {
+	loan         *domain.Loan
+	payment      *domain.Payment
+	schedules    []domain.PaymentSchedule
+	allocations  []domain.PaymentAllocation
+	investments  []domain.LoanInvestment
+	statusChange *domain.LoanStatusChange
+}
+
+// SettleGroupArrears applies one payment made at a group meeting to the
+// overdue installments of every member under the group's joint liability
+// (tanggung renteng), oldest due date first. Each loan it reaches gets its own
+// payment. What the payer covers for other members is recorded as debt they
+// owe the payer.
+func (p *paymentUsecase) SettleGroupArrears(ctx context.Context, groupID uint, payerID uint, amount money.Money) (*dto.GroupSettlementResponse, error) {
+	if amount <= 0 {
+		return nil, errors.New("payment amount must be positive")
+	}
+
+	group, err := p.groupRepo.FindGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]uint, len(group.Members))
+	for i, member := range group.Members {
+		memberIDs[i] = member.ID
+	}
+	if !slices.Contains(memberIDs, payerID) {
+		return nil, errors.New("payer must be a member of the group")
+	}
+
+	loans, err := p.loanRepo.GetActiveLoansByBorrowerIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	type installment struct {
+		loan     *domain.Loan
+		schedule *domain.PaymentSchedule
+	}
+	var overdue []installment
+	var totalOverdue money.Money
+	for i := range loans {
+		for j := range loans[i].PaymentSchedules {
+			schedule := &loans[i].PaymentSchedules[j]
+			if schedule.Paid || !schedule.DueDate.Before(today()) {
+				continue
+			}
+			overdue = append(overdue, installment{loan: &loans[i], schedule: schedule})
+			totalOverdue += schedule.Outstanding().Total()
+		}
+	}
+
+	if totalOverdue == 0 {
+		return nil, errors.New("group has no overdue installments")
+	}
+	if amount > totalOverdue {
+		return nil, fmt.Errorf("payment exceeds the group's overdue balance of %s", totalOverdue)
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return overdue[i].schedule.DueDate.Before(overdue[j].schedule.DueDate)
+	})
+
+	var settled []*settledLoan
+	byLoan := make(map[uint]*settledLoan)
+	remaining := amount
+	for _, due := range overdue {
+		if remaining == 0 {
+			break
+		}
+
+		s, ok := byLoan[due.loan.ID]
+		if !ok {
+			s = &settledLoan{loan: due.loan, payment: &domain.Payment{LoanID: due.loan.ID}}
+			byLoan[due.loan.ID] = s
+			settled = append(settled, s)
+		}
+
+		applied := due.schedule.Pay(remaining, due.loan.AllocationOrder)
+		remaining -= applied.Total()
+		s.payment.Amount += applied.Total()
+		addToPayment(s.payment, applied)
+		s.schedules = append(s.schedules, *due.schedule)
+		s.allocations = append(s.allocations, applied.Allocations(due.schedule.ID, due.loan.AllocationOrder)...)
+	}
+
+	var debts []domain.MemberDebt
+	debtIndex := make(map[uint]int)
+	for _, s := range settled {
+		s.loan.OutstandingAmount -= s.payment.Amount
+		if s.loan.OutstandingAmount == 0 {
+			s.statusChange, err = s.loan.TransitionTo(domain.LoanClosed)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.investments, err = p.investorRepo.GetInvestmentsByLoanID(ctx, s.loan.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.loan.BorrowerID == payerID {
+			continue
+		}
+		i, ok := debtIndex[s.loan.BorrowerID]
+		if !ok {
+			i = len(debts)
+			debtIndex[s.loan.BorrowerID] = i
+			debts = append(debts, domain.MemberDebt{
+				GroupID:    group.ID,
+				CreditorID: payerID,
+				DebtorID:   s.loan.BorrowerID,
+			})
+		}
+		debts[i].Amount += s.payment.Amount
+	}
+
+	settlement := &domain.GroupSettlement{
+		GroupID: group.ID,
+		PayerID: payerID,
+		Amount:  amount,
+	}
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.paymentRepo.CreateSettlement(ctx, settlement, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	for _, s := range settled {
+		s.payment.SettlementID = &settlement.ID
+		if err := p.recordPayment(ctx, s.loan, s.payment, s.allocations, s.schedules, s.investments, s.statusChange, tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if len(debts) > 0 {
+		for i := range debts {
+			debts[i].SettlementID = settlement.ID
+		}
+		if err := p.groupRepo.CreateMemberDebts(ctx, debts, tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	paymentResponses := make([]dto.SettlementPaymentResponse, len(settled))
+	for i, s := range settled {
+		paymentResponses[i] = dto.SettlementPaymentResponse{
+			PaymentID:         s.payment.ID,
+			LoanID:            s.loan.ID,
+			BorrowerID:        s.loan.BorrowerID,
+			Amount:            s.payment.Amount,
+			OutstandingAmount: s.loan.OutstandingAmount,
+			LoanStatus:        string(s.loan.Status),
+			Allocations:       assemblePaymentAllocationResponses(s.allocations),
+		}
+	}
+
+	debtResponses := make([]dto.MemberDebtResponse, len(debts))
+	for i, debt := range debts {
+		debtResponses[i] = assembleMemberDebtResponse(debt)
+	}
+
+	return &dto.GroupSettlementResponse{
+		SettlementID: settlement.ID,
+		GroupID:      group.ID,
+		PayerID:      payerID,
+		Amount:       amount,
+		Payments:     paymentResponses,
+		Debts:        debtResponses,
+	}, nil
+}
+
+func assembleMemberDebtResponse(debt domain.MemberDebt) dto.MemberDebtResponse {
+	return dto.MemberDebtResponse{
+		ID:                debt.ID,
+		SettlementID:      debt.SettlementID,
+		CreditorID:        debt.CreditorID,
+		DebtorID:          debt.DebtorID,
+		Amount:            debt.Amount,
+		RepaidAmount:      debt.RepaidAmount,
+		OutstandingAmount: debt.Outstanding(),
+		CreatedAt:         debt.CreatedAt,
+	}
+}
+
+// waiveInterest spreads the rebate over the interest still outstanding on the
+// installments, starting from the last one, since the rebate is earned on
+// installments not yet due.
+func waiveInterest(schedules []domain.PaymentSchedule, rebate money.Money) []money.Money {
+	waivers := make([]money.Money, len(schedules))
+	for i := len(schedules) - 1; i >= 0 && rebate > 0; i-- {
+		waivers[i] = min(rebate, schedules[i].Outstanding().Interest)
+		rebate -= waivers[i]
+	}
+	return waivers
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func assemblePayoffQuote(loan *domain.Loan, date time.Time) *dto.PayoffQuoteResponse {
+	quote := &dto.PayoffQuoteResponse{
+		LoanID:           loan.ID,
+		PayoffDate:       date,
+		RebatePolicy:     string(loan.RebatePolicy),
+		Rebate:           loan.RebatePolicy.Rebate(loan.PaymentSchedules, date),
+		PaymentSchedules: []dto.GetPaymentScheduleResponse{},
+	}
+
+	for _, schedule := range unpaidSchedules(loan.PaymentSchedules) {
+		outstanding := schedule.Outstanding()
+		quote.Principal += outstanding.Principal
+		quote.Interest += outstanding.Interest
+		quote.Fees += outstanding.Fee
+		quote.Penalties += outstanding.Penalty
+		quote.PaymentSchedules = append(quote.PaymentSchedules, assemblePaymentScheduleResponse(schedule))
+	}
+	owed := quote.Principal + quote.Interest + quote.Fees + quote.Penalties - quote.Rebate
+	quote.CreditApplied = min(loan.CreditBalance, owed)
+	quote.PayoffAmount = owed - quote.CreditApplied
+
+	return quote
+}
+
+// ReversePayment takes back a payment keyed in by mistake. The installments
+// it settled are reopened, the loan owes again what the payment paid off and
+// any credit it left is withdrawn; a loan the payment closed is reopened. A
+// reversed recovery is taken off the recovered balance. Investors give back
+// their share and the payment's journal entry is reversed, all in one
+// transaction.
+func (p *paymentUsecase) ReversePayment(ctx context.Context, paymentID uint, reason string) (*dto.PaymentReversalResponse, error) {
+	payment, err := p.paymentRepo.FindPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Reversal != nil {
+		return nil, errors.New("payment has already been reversed")
+	}
+	if payment.SettlementID != nil {
+		return nil, errors.New("payments made in a group settlement cannot be reversed")
+	}
+
+	loan, err := p.loanRepo.FindLoanByID(ctx, payment.LoanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.RefinancedByLoanID != nil {
+		return nil, errors.New("payments on a refinanced loan cannot be reversed")
+	}
+
+	var schedules []domain.PaymentSchedule
+	var statusChange *domain.LoanStatusChange
+	if payment.Recovery {
+		loan.RecoveredAmount -= payment.Amount
+	} else {
+		if loan.Status != domain.LoanDisbursed && loan.Status != domain.LoanClosed {
+			return nil, fmt.Errorf("payments cannot be reversed on a %s loan", loan.Status)
+		}
+
+		credited := payment.Amount - payment.Principal - payment.Interest - payment.FeeAmount - payment.Penalty
+		if credited > loan.CreditBalance {
+			return nil, fmt.Errorf("payment credited %s but the credit balance is only %s", credited, loan.CreditBalance)
+		}
+		loan.CreditBalance -= credited
+
+		applied := domain.SumAllocations(payment.Allocations)
+		for _, schedule := range loan.PaymentSchedules {
+			allocation, ok := applied[schedule.ID]
+			if !ok {
+				continue
+			}
+			if schedule.Deferred {
+				return nil, errors.New("payment settled installments that have since been deferred")
+			}
+
+			before := schedule.Outstanding().Total()
+			schedule.Unpay(allocation)
+			loan.OutstandingAmount += schedule.Outstanding().Total() - before
+			schedules = append(schedules, schedule)
+		}
+
+		if len(schedules) != len(applied) {
+			return nil, errors.New("payment settled installments that have since been restructured")
+		}
+
+		if loan.Status == domain.LoanClosed && loan.OutstandingAmount > 0 {
+			statusChange, err = loan.Reopen()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	returns, err := p.investorRepo.GetReturnsByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal := &domain.PaymentReversal{
+		PaymentID: payment.ID,
+		LoanID:    loan.ID,
+		Amount:    payment.Amount,
+		Reason:    reason,
+	}
+	loan.PaymentSchedules = nil
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.paymentRepo.CreateReversal(ctx, reversal, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if len(returns) > 0 {
+		if err := p.investorRepo.CreateInvestorReturns(ctx, domain.ClawBack(returns), tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := p.ledgerRepo.CreateJournalEntry(ctx, domain.NewReversalEntry(loan, payment), tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	for i := range schedules {
+		if err := p.paymentScheduleRepo.UpdatePaymentSchedule(ctx, &schedules[i], tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
 	}
 
-	loan.OutstandingAmount -= amount
 	if err := p.loanRepo.UpdateLoan(ctx, loan, tx); err != nil {
 		p.transactionManager.Rollback(tx)
-		return err
+		return nil, err
+	}
+
+	if statusChange != nil {
+		if err := p.loanRepo.CreateStatusChange(ctx, statusChange, tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
 	}
 
-	return p.transactionManager.Commit(tx)
+	scheduleResponses := make([]dto.GetPaymentScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		scheduleResponses[i] = assemblePaymentScheduleResponse(schedule)
+	}
+
+	return &dto.PaymentReversalResponse{
+		ReversalID:        reversal.ID,
+		PaymentID:         payment.ID,
+		LoanID:            loan.ID,
+		Amount:            payment.Amount,
+		Reason:            reason,
+		OutstandingAmount: loan.OutstandingAmount,
+		CreditBalance:     loan.CreditBalance,
+		RecoveredAmount:   loan.RecoveredAmount,
+		LoanStatus:        string(loan.Status),
+		PaymentSchedules:  scheduleResponses,
+	}, nil
+}
+
+// RefundCredit pays part or all of a loan's credit balance back to the
+// borrower.
+func (p *paymentUsecase) RefundCredit(ctx context.Context, loanID uint, amount money.Money, reason string) (*dto.RefundResponse, error) {
+	if amount <= 0 {
+		return nil, errors.New("refund amount must be positive")
+	}
+
+	loan, err := p.loanRepo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount > loan.CreditBalance {
+		return nil, fmt.Errorf("refund exceeds the credit balance of %s", loan.CreditBalance)
+	}
+
+	refund := &domain.Refund{
+		LoanID: loan.ID,
+		Amount: amount,
+		Reason: reason,
+	}
+	loan.CreditBalance -= amount
+	loan.PaymentSchedules = nil
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.paymentRepo.CreateRefund(ctx, refund, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.ledgerRepo.CreateJournalEntry(ctx, domain.NewRefundEntry(loan, refund), tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.loanRepo.UpdateLoan(ctx, loan, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return &dto.RefundResponse{
+		RefundID:      refund.ID,
+		LoanID:        loan.ID,
+		Amount:        amount,
+		Reason:        reason,
+		CreditBalance: loan.CreditBalance,
+	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/greekrode/loan-engine-amartha/domain"
 	"github.com/greekrode/loan-engine-amartha/domain/dto"
 	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
 	paymentUsecase "github.com/greekrode/loan-engine-amartha/payment/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,11 +48,13 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 						DeletedAt: gorm.DeletedAt{Valid: false},
 					},
 					BorrowerID:        1,
-					Principal:         100.00,
+					Principal:         money.FromFloat(100.00),
 					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
+					Tenor:             52,
+					Frequency:         domain.FrequencyWeekly,
+					OutstandingAmount: money.FromFloat(1000.00),
 					StartDate:         fixedTime,
+					Status:            domain.LoanDisbursed,
 					PaymentSchedules: []domain.PaymentSchedule{
 						{
 							Model: gorm.Model{
@@ -60,7 +63,7 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 								UpdatedAt: fixedTime,
 								DeletedAt: gorm.DeletedAt{Valid: false},
 							},
-							DueAmount: 100.00,
+							DueAmount: money.FromFloat(100.00),
 							DueDate:   fixedTime,
 							Paid:      false,
 						},
@@ -74,19 +77,21 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 							UpdatedAt: fixedTime,
 							DeletedAt: gorm.DeletedAt{Valid: false},
 						},
-						DueAmount: 100.00,
+						DueAmount: money.FromFloat(100.00),
 						DueDate:   fixedTime,
 						Paid:      false,
 					},
 				}, nil)
 			},
 			expected: &dto.RequestPaymentResponse{
-				TotalDue: 100.00,
+				TotalDue: money.FromFloat(100.00),
 				PaymentSchedules: []dto.GetPaymentScheduleResponse{
 					{
-						ID:        1,
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
+						ID:              1,
+						DueAmount:       money.FromFloat(100.00),
+						PrincipalAmount: money.FromFloat(100.00),
+						DueDate:         fixedTime,
+						Status:          "unpaid",
 					},
 				},
 			},
@@ -101,11 +106,20 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 			expected:      nil,
 			expectedError: errors.New("loan not found"),
 		},
+		{
+			name:   "Loan Not Disbursed",
+			loanID: 1,
+			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository) {
+				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{Status: domain.LoanApproved}, nil)
+			},
+			expected:      nil,
+			expectedError: errors.New("payments are only accepted for disbursed loans, loan is approved"),
+		},
 		{
 			name:   "Error Finding Unpaid Payment Schedules",
 			loanID: 1,
 			setupMocks: func(mlr *mocks.LoanRepository, mpsr *mocks.PaymentScheduleRepository) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{}, nil)
+				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{Status: domain.LoanDisbursed}, nil)
 				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return(nil, errors.New("error finding unpaid payment schedules"))
 			},
 			expected:      nil,
@@ -120,7 +134,7 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 			mockLoanRepo := new(mocks.LoanRepository)
 			mockTransactionmanager := new(mocks.TransactionManager)
 
-			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionmanager, s.timeout)
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, new(mocks.InvestorRepository), new(mocks.BorrowerGroupRepository), new(mocks.LedgerRepository), mockTransactionmanager, s.timeout)
 
 			tt.setupMocks(mockLoanRepo, mockPaymentScheduleRepo)
 			result, err := uc.RequestPayment(context.TODO(), tt.loanID)
@@ -138,281 +152,417 @@ func (s *PaymentUsecaseSuite) TestRequestPayment() {
 func (s *PaymentUsecaseSuite) TestMakePayment() {
 	fixedTime := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
 
+	// disbursedLoan owes two installments of 100.00: a 5.00 fee, 20.00 of
+	// interest and 75.00 of principal each.
+	disbursedLoan := func() *domain.Loan {
+		return &domain.Loan{
+			Model:             gorm.Model{ID: 1},
+			Status:            domain.LoanDisbursed,
+			OutstandingAmount: money.FromFloat(200.00),
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 2}, LoanID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), DueDate: fixedTime.AddDate(0, 0, 14)},
+				{Model: gorm.Model{ID: 1}, LoanID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), DueDate: fixedTime.AddDate(0, 0, 7)},
+			},
+		}
+	}
+
 	tests := []struct {
 		name          string
-		loanID        uint
-		paymentIDs    []uint
-		amount        float64
-		setupMocks    func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.TransactionManager)
+		loan          *domain.Loan
+		amount        money.Money
+		setupMocks    func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.TransactionManager)
+		expected      *dto.MakePaymentResponse
 		expectedError error
 	}{
 		{
-			name:       "Successful Payment",
-			loanID:     1,
-			paymentIDs: []uint{1},
-			amount:     100.00,
-			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{
-					Model: gorm.Model{
-						ID:        1,
-						CreatedAt: fixedTime,
-						UpdatedAt: fixedTime,
-						DeletedAt: gorm.DeletedAt{Valid: false},
-					},
-					BorrowerID:        1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
-					StartDate:         fixedTime,
-					PaymentSchedules: []domain.PaymentSchedule{
-						{
-							Model: gorm.Model{
-								ID:        1,
-								CreatedAt: fixedTime,
-								UpdatedAt: fixedTime,
-								DeletedAt: gorm.DeletedAt{Valid: false},
-							},
-							DueAmount: 100.00,
-							DueDate:   fixedTime,
-							Paid:      false,
-						},
-					},
-				}, nil)
-				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return([]domain.PaymentSchedule{
-					{
-						Model: gorm.Model{
-							ID:        1,
-							CreatedAt: fixedTime,
-							UpdatedAt: fixedTime,
-							DeletedAt: gorm.DeletedAt{Valid: false},
-						},
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
-					},
-				}, nil)
+			name:   "Successful Payment",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
 				mtm.On("Begin").Return(&gorm.DB{})
 				mtm.On("Commit", mock.Anything).Return(nil)
-				mtm.On("Rollback", mock.Anything).Return(nil)
-				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType(
-					"*domain.Payment"), mock.Anything).Return(nil)
-				mpsr.On("BulkPayPaymentSchedules", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil).Return(nil).Return(nil).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(100.00),
+					Principal: money.FromFloat(75.00),
+					Interest:  money.FromFloat(20.00),
+					FeeAmount: money.FromFloat(5.00),
+				}, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 9
+				}).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, []domain.PaymentAllocation{
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentFee, Amount: money.FromFloat(5.00)},
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentInterest, Amount: money.FromFloat(20.00)},
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentPrincipal, Amount: money.FromFloat(75.00)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 1 && ps.Paid && ps.PaidAmount == money.FromFloat(100.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == money.FromFloat(100.00) && loan.Status == domain.LoanDisbursed
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.MakePaymentResponse{
+				PaymentID:         9,
+				Amount:            money.FromFloat(100.00),
+				AppliedAmount:     money.FromFloat(100.00),
+				OutstandingAmount: money.FromFloat(100.00),
+				LoanStatus:        "disbursed",
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{ID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(100.00), DueDate: fixedTime.AddDate(0, 0, 7), Paid: true, Status: "paid"},
+				},
+				Allocations: []dto.PaymentAllocationResponse{
+					{PaymentScheduleID: 1, Component: "fee", Amount: money.FromFloat(5.00)},
+					{PaymentScheduleID: 1, Component: "interest", Amount: money.FromFloat(20.00)},
+					{PaymentScheduleID: 1, Component: "principal", Amount: money.FromFloat(75.00)},
+				},
 			},
-			expectedError: nil,
 		},
 		{
-			name:       "Error Beginning Transaction",
-			loanID:     1,
-			paymentIDs: []uint{1},
-			amount:     100.00,
-			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{
-					Model: gorm.Model{
-						ID:        1,
-						CreatedAt: fixedTime,
-						UpdatedAt: fixedTime,
-						DeletedAt: gorm.DeletedAt{Valid: false},
-					},
-					BorrowerID:        1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
-					StartDate:         fixedTime,
-					PaymentSchedules: []domain.PaymentSchedule{
-						{
-							Model: gorm.Model{
-								ID:        1,
-								CreatedAt: fixedTime,
-								UpdatedAt: fixedTime,
-								DeletedAt: gorm.DeletedAt{Valid: false},
-							},
-							DueAmount: 100.00,
-							DueDate:   fixedTime,
-							Paid:      false,
-						},
-					},
-				}, nil)
-				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return([]domain.PaymentSchedule{
-					{
-						Model: gorm.Model{
-							ID:        1,
-							CreatedAt: fixedTime,
-							UpdatedAt: fixedTime,
-							DeletedAt: gorm.DeletedAt{Valid: false},
-						},
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
-					},
+			name:   "Partial Payment Of Oldest Installment",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(60.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(60.00),
+					Principal: money.FromFloat(35.00),
+					Interest:  money.FromFloat(20.00),
+					FeeAmount: money.FromFloat(5.00),
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 1 && !ps.Paid && ps.PaidAmount == money.FromFloat(60.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == money.FromFloat(140.00)
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.MakePaymentResponse{
+				Amount:            money.FromFloat(60.00),
+				AppliedAmount:     money.FromFloat(60.00),
+				OutstandingAmount: money.FromFloat(140.00),
+				LoanStatus:        "disbursed",
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{ID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(60.00), DueDate: fixedTime.AddDate(0, 0, 7), Status: "partially_paid"},
+				},
+				Allocations: []dto.PaymentAllocationResponse{
+					{PaymentScheduleID: 1, Component: "fee", Amount: money.FromFloat(5.00)},
+					{PaymentScheduleID: 1, Component: "interest", Amount: money.FromFloat(20.00)},
+					{PaymentScheduleID: 1, Component: "principal", Amount: money.FromFloat(35.00)},
+				},
+			},
+		},
+		{
+			name: "Payment Completes A Partially Paid Installment",
+			loan: func() *domain.Loan {
+				loan := disbursedLoan()
+				loan.PaymentSchedules[1].PaidAmount = money.FromFloat(60.00)
+				loan.PaymentSchedules[1].FeePaid = money.FromFloat(5.00)
+				loan.PaymentSchedules[1].InterestPaid = money.FromFloat(20.00)
+				loan.PaymentSchedules[1].PrincipalPaid = money.FromFloat(35.00)
+				loan.OutstandingAmount = money.FromFloat(140.00)
+				return loan
+			}(),
+			amount: money.FromFloat(70.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(70.00),
+					Principal: money.FromFloat(45.00),
+					Interest:  money.FromFloat(20.00),
+					FeeAmount: money.FromFloat(5.00),
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 1 && ps.Paid
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 2 && ps.PaidAmount == money.FromFloat(30.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == money.FromFloat(70.00)
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.MakePaymentResponse{
+				Amount:            money.FromFloat(70.00),
+				AppliedAmount:     money.FromFloat(70.00),
+				OutstandingAmount: money.FromFloat(70.00),
+				LoanStatus:        "disbursed",
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{ID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(100.00), DueDate: fixedTime.AddDate(0, 0, 7), Paid: true, Status: "paid"},
+					{ID: 2, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(30.00), DueDate: fixedTime.AddDate(0, 0, 14), Status: "partially_paid"},
+				},
+				Allocations: []dto.PaymentAllocationResponse{
+					{PaymentScheduleID: 1, Component: "principal", Amount: money.FromFloat(40.00)},
+					{PaymentScheduleID: 2, Component: "fee", Amount: money.FromFloat(5.00)},
+					{PaymentScheduleID: 2, Component: "interest", Amount: money.FromFloat(20.00)},
+					{PaymentScheduleID: 2, Component: "principal", Amount: money.FromFloat(5.00)},
+				},
+			},
+		},
+		{
+			name:   "Overpayment Is Kept As Credit",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(230.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(230.00),
+					Principal: money.FromFloat(150.00),
+					Interest:  money.FromFloat(40.00),
+					FeeAmount: money.FromFloat(10.00),
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil).Twice()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == 0 && loan.CreditBalance == money.FromFloat(30.00) && loan.Status == domain.LoanClosed
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanDisbursed,
+					ToStatus:   domain.LoanClosed,
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.MakePaymentResponse{
+				Amount:        money.FromFloat(230.00),
+				AppliedAmount: money.FromFloat(200.00),
+				CreditAmount:  money.FromFloat(30.00),
+				CreditBalance: money.FromFloat(30.00),
+				LoanStatus:    "closed",
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{
+					{ID: 1, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(100.00), DueDate: fixedTime.AddDate(0, 0, 7), Paid: true, Status: "paid"},
+					{ID: 2, DueAmount: money.FromFloat(100.00), PrincipalAmount: money.FromFloat(75.00), InterestAmount: money.FromFloat(20.00), FeeAmount: money.FromFloat(5.00), PaidAmount: money.FromFloat(100.00), DueDate: fixedTime.AddDate(0, 0, 14), Paid: true, Status: "paid"},
+				},
+				Allocations: []dto.PaymentAllocationResponse{
+					{PaymentScheduleID: 1, Component: "fee", Amount: money.FromFloat(5.00)},
+					{PaymentScheduleID: 1, Component: "interest", Amount: money.FromFloat(20.00)},
+					{PaymentScheduleID: 1, Component: "principal", Amount: money.FromFloat(75.00)},
+					{PaymentScheduleID: 2, Component: "fee", Amount: money.FromFloat(5.00)},
+					{PaymentScheduleID: 2, Component: "interest", Amount: money.FromFloat(20.00)},
+					{PaymentScheduleID: 2, Component: "principal", Amount: money.FromFloat(75.00)},
+				},
+			},
+		},
+		{
+			name: "Allocation Order Settles Principal And Penalty First",
+			loan: func() *domain.Loan {
+				loan := disbursedLoan()
+				loan.AllocationOrder = domain.AllocationOrder{domain.ComponentPrincipal, domain.ComponentPenalty, domain.ComponentInterest, domain.ComponentFee}
+				loan.PaymentSchedules[1].DueAmount = money.FromFloat(110.00)
+				loan.PaymentSchedules[1].PenaltyAmount = money.FromFloat(10.00)
+				loan.OutstandingAmount = money.FromFloat(210.00)
+				return loan
+			}(),
+			amount: money.FromFloat(90.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(90.00),
+					Principal: money.FromFloat(75.00),
+					Interest:  money.FromFloat(5.00),
+					Penalty:   money.FromFloat(10.00),
+				}, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 9
+				}).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, []domain.PaymentAllocation{
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentPrincipal, Amount: money.FromFloat(75.00)},
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentPenalty, Amount: money.FromFloat(10.00)},
+					{PaymentID: 9, PaymentScheduleID: 1, Component: domain.ComponentInterest, Amount: money.FromFloat(5.00)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 1 && ps.PrincipalPaid == money.FromFloat(75.00) && ps.PenaltyPaid == money.FromFloat(10.00) && ps.InterestPaid == money.FromFloat(5.00) && ps.FeePaid == 0
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == money.FromFloat(120.00)
+				}), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:   "Distributes Repayment To Investors",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 3}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(600.00)},
+					{Model: gorm.Model{ID: 4}, LoanID: 1, InvestorID: 8, Amount: money.FromFloat(400.00)},
 				}, nil)
-
-				mtm.On("Begin").Return(&gorm.DB{
-					Error: errors.New("error beginning transaction"),
-				})
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 5
+				}).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{PaymentID: 5, LoanID: 1, InvestorID: 7, InvestmentID: 3, Principal: money.FromFloat(45.00), Interest: money.FromFloat(12.00), NetAmount: money.FromFloat(57.00)},
+					{PaymentID: 5, LoanID: 1, InvestorID: 8, InvestmentID: 4, Principal: money.FromFloat(30.00), Interest: money.FromFloat(8.00), NetAmount: money.FromFloat(38.00)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
 			},
-			expectedError: errors.New("error beginning transaction"),
 		},
 		{
-			name:       "Error Creating Payment",
-			loanID:     1,
-			paymentIDs: []uint{1},
-			amount:     100.00,
-			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{
-					Model: gorm.Model{
-						ID:        1,
-						CreatedAt: fixedTime,
-						UpdatedAt: fixedTime,
-						DeletedAt: gorm.DeletedAt{Valid: false},
-					},
-					BorrowerID:        1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
-					StartDate:         fixedTime,
-					PaymentSchedules: []domain.PaymentSchedule{
-						{
-							Model: gorm.Model{
-								ID:        1,
-								CreatedAt: fixedTime,
-								UpdatedAt: fixedTime,
-								DeletedAt: gorm.DeletedAt{Valid: false},
-							},
-							DueAmount: 100.00,
-							DueDate:   fixedTime,
-							Paid:      false,
-						},
-					},
+			name: "Service Fee Is Kept From Investor Interest",
+			loan: func() *domain.Loan {
+				loan := disbursedLoan()
+				loan.ServiceFeeRate = 20
+				return loan
+			}(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 3}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(600.00)},
+					{Model: gorm.Model{ID: 4}, LoanID: 1, InvestorID: 8, Amount: money.FromFloat(400.00)},
 				}, nil)
-				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return([]domain.PaymentSchedule{
-					{
-						Model: gorm.Model{
-							ID:        1,
-							CreatedAt: fixedTime,
-							UpdatedAt: fixedTime,
-							DeletedAt: gorm.DeletedAt{Valid: false},
-						},
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
-					},
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 5
+				}).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{PaymentID: 5, LoanID: 1, InvestorID: 7, InvestmentID: 3, Principal: money.FromFloat(45.00), Interest: money.FromFloat(12.00), ServiceFee: money.FromFloat(2.40), NetAmount: money.FromFloat(54.60)},
+					{PaymentID: 5, LoanID: 1, InvestorID: 8, InvestmentID: 4, Principal: money.FromFloat(30.00), Interest: money.FromFloat(8.00), ServiceFee: money.FromFloat(1.60), NetAmount: money.FromFloat(36.40)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Recovery On Written-Off Loan",
+			loan: &domain.Loan{
+				Model:            gorm.Model{ID: 1},
+				Status:           domain.LoanWrittenOff,
+				WrittenOffAmount: money.FromFloat(200.00),
+				RecoveredAmount:  money.FromFloat(50.00),
+			},
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 3}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(1000.00)},
 				}, nil)
 				mtm.On("Begin").Return(&gorm.DB{})
 				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(100.00),
+					Principal: money.FromFloat(100.00),
+					Recovery:  true,
+				}, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Payment).ID = 9
+				}).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{PaymentID: 9, LoanID: 1, InvestorID: 7, InvestmentID: 3, Principal: money.FromFloat(100.00), NetAmount: money.FromFloat(100.00)},
+				}, mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.RecoveredAmount == money.FromFloat(150.00) && loan.Status == domain.LoanWrittenOff
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.MakePaymentResponse{
+				PaymentID:        9,
+				Amount:           money.FromFloat(100.00),
+				AppliedAmount:    money.FromFloat(100.00),
+				LoanStatus:       "written_off",
+				Recovery:         true,
+				RecoveredAmount:  money.FromFloat(150.00),
+				PaymentSchedules: []dto.GetPaymentScheduleResponse{},
+				Allocations:      []dto.PaymentAllocationResponse{},
+			},
+		},
+		{
+			name: "Recovery Exceeds Written-Off Balance",
+			loan: &domain.Loan{
+				Model:            gorm.Model{ID: 1},
+				Status:           domain.LoanWrittenOff,
+				WrittenOffAmount: money.FromFloat(200.00),
+				RecoveredAmount:  money.FromFloat(150.00),
+			},
+			amount:        money.FromFloat(100.00),
+			expectedError: errors.New("payment exceeds the unrecovered balance of 50.00"),
+		},
+		{
+			name:          "Amount Not Positive",
+			loan:          disbursedLoan(),
+			amount:        0,
+			expectedError: errors.New("payment amount must be positive"),
+		},
+		{
+			name:          "Loan Already Closed",
+			loan:          &domain.Loan{Status: domain.LoanClosed},
+			amount:        money.FromFloat(100.00),
+			expectedError: errors.New("payments are only accepted for disbursed loans, loan is closed"),
+		},
+		{
+			name:   "Error Beginning Transaction",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{Error: errors.New("error beginning transaction")})
+			},
+			expectedError: errors.New("error beginning transaction"),
+		},
+		{
+			name:   "Error Creating Payment",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(errors.New("error creating payment"))
 			},
 			expectedError: errors.New("error creating payment"),
 		},
 		{
-			name:       "Error Bulk Paying Payment Schedules",
-			loanID:     1,
-			paymentIDs: []uint{1},
-			amount:     100.00,
-			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{
-					Model: gorm.Model{
-						ID:        1,
-						CreatedAt: fixedTime,
-						UpdatedAt: fixedTime,
-						DeletedAt: gorm.DeletedAt{Valid: false},
-					},
-					BorrowerID:        1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
-					StartDate:         fixedTime,
-					PaymentSchedules: []domain.PaymentSchedule{
-						{
-							Model: gorm.Model{
-								ID:        1,
-								CreatedAt: fixedTime,
-								UpdatedAt: fixedTime,
-								DeletedAt: gorm.DeletedAt{Valid: false},
-							},
-							DueAmount: 100.00,
-							DueDate:   fixedTime,
-							Paid:      false,
-						},
-					},
-				}, nil)
-				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return([]domain.PaymentSchedule{
-					{
-						Model: gorm.Model{
-							ID:        1,
-							CreatedAt: fixedTime,
-							UpdatedAt: fixedTime,
-							DeletedAt: gorm.DeletedAt{Valid: false},
-						},
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
-					},
+			name:   "Error Creating Investor Returns",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 3}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(1000.00)},
 				}, nil)
 				mtm.On("Begin").Return(&gorm.DB{})
-				mtm.On("Commit", mock.Anything).Return(nil)
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
-				mpsr.On("BulkPayPaymentSchedules", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error bulk paying payment schedules"))
+				mir.On("CreateInvestorReturns", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating investor returns"))
 			},
-			expectedError: errors.New("error bulk paying payment schedules"),
+			expectedError: errors.New("error creating investor returns"),
 		},
 		{
-			name:       "Error Updating Loan",
-			loanID:     1,
-			paymentIDs: []uint{1},
-			amount:     100.00,
-			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
-				mlr.On("FindLoanByID", mock.Anything, uint(1)).Return(&domain.Loan{
-					Model: gorm.Model{
-						ID:        1,
-						CreatedAt: fixedTime,
-						UpdatedAt: fixedTime,
-						DeletedAt: gorm.DeletedAt{Valid: false},
-					},
-					BorrowerID:        1,
-					Principal:         100.00,
-					InterestRate:      10.00,
-					DurationWeeks:     52,
-					OutstandingAmount: 1000.00,
-					StartDate:         fixedTime,
-					PaymentSchedules: []domain.PaymentSchedule{
-						{
-							Model: gorm.Model{
-								ID:        1,
-								CreatedAt: fixedTime,
-								UpdatedAt: fixedTime,
-								DeletedAt: gorm.DeletedAt{Valid: false},
-							},
-							DueAmount: 100.00,
-							DueDate:   fixedTime,
-							Paid:      false,
-						},
-					},
-				}, nil)
-				mpsr.On("GetUnpaidPaymentSchedulesByLoanID", mock.Anything, uint(1), mock.Anything).Return([]domain.PaymentSchedule{
-					{
-						Model: gorm.Model{
-							ID:        1,
-							CreatedAt: fixedTime,
-							UpdatedAt: fixedTime,
-							DeletedAt: gorm.DeletedAt{Valid: false},
-						},
-						DueAmount: 100.00,
-						DueDate:   fixedTime,
-						Paid:      false,
-					},
-				}, nil)
+			name:   "Error Creating Payment Allocations",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
 				mtm.On("Begin").Return(&gorm.DB{})
-				mtm.On("Commit", mock.Anything).Return(nil)
 				mtm.On("Rollback", mock.Anything).Return(nil)
 				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
-				mpsr.On("BulkPayPaymentSchedules", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating payment allocations"))
+			},
+			expectedError: errors.New("error creating payment allocations"),
+		},
+		{
+			name:   "Error Updating Payment Schedule",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(errors.New("error updating payment schedule"))
+			},
+			expectedError: errors.New("error updating payment schedule"),
+		},
+		{
+			name:   "Error Updating Loan",
+			loan:   disbursedLoan(),
+			amount: money.FromFloat(100.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil)
 				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(errors.New("error updating loan"))
 			},
 			expectedError: errors.New("error updating loan"),
@@ -424,22 +574,765 @@ func (s *PaymentUsecaseSuite) TestMakePayment() {
 			mockPaymentRepo := new(mocks.PaymentRepository)
 			mockLoanRepo := new(mocks.LoanRepository)
 			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockInvestorRepo := new(mocks.InvestorRepository)
 			mockTransactionManager := new(mocks.TransactionManager)
 
-			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, new(mocks.BorrowerGroupRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
 
-			tt.setupMocks(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionManager)
-			err := uc.MakePayment(context.TODO(), tt.loanID, tt.paymentIDs, tt.amount)
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, mockTransactionManager)
+			}
+			mockInvestorRepo.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return(nil, nil).Maybe()
+			mockPaymentRepo.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			result, err := uc.MakePayment(context.TODO(), 1, tt.amount)
 			if tt.expectedError != nil {
 				assert.Error(s.T(), err)
 				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
-			} else {
-				assert.NoError(s.T(), err)
+				return
+			}
+
+			assert.NoError(s.T(), err)
+			if tt.expected != nil {
+				assert.Equal(s.T(), tt.expected, result)
+			}
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+			mockInvestorRepo.AssertExpectations(s.T())
+			mockLedgerRepo.AssertNumberOfCalls(s.T(), "CreateJournalEntry", 1)
+		})
+	}
+}
+
+func payoffLoan() *domain.Loan {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return &domain.Loan{
+		Model:             gorm.Model{ID: 1},
+		Status:            domain.LoanDisbursed,
+		RebatePolicy:      domain.RebateFull,
+		OutstandingAmount: money.FromFloat(220.00),
+		PaymentSchedules: []domain.PaymentSchedule{
+			{Model: gorm.Model{ID: 1}, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, -8), Paid: true},
+			{Model: gorm.Model{ID: 2}, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, -1)},
+			{Model: gorm.Model{ID: 3}, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, 6)},
+		},
+	}
+}
+
+func (s *PaymentUsecaseSuite) TestQuotePayoff() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		date           time.Time
+		policy         domain.RebatePolicy
+		creditBalance  money.Money
+		expectedRebate money.Money
+		expectedCredit money.Money
+		expectedAmount money.Money
+		expectedError  error
+	}{
+		{
+			name:           "Full Rebate Of Future Interest",
+			date:           today,
+			policy:         domain.RebateFull,
+			expectedRebate: money.FromFloat(10.00),
+			expectedAmount: money.FromFloat(210.00),
+		},
+		{
+			name:           "No Rebate",
+			date:           today,
+			policy:         domain.RebateNone,
+			expectedAmount: money.FromFloat(220.00),
+		},
+		{
+			name:           "Payoff After Last Installment",
+			date:           today.AddDate(0, 0, 7),
+			policy:         domain.RebateFull,
+			expectedAmount: money.FromFloat(220.00),
+		},
+		{
+			name:           "Credit Balance Counts Towards Payoff",
+			date:           today,
+			policy:         domain.RebateFull,
+			creditBalance:  money.FromFloat(30.00),
+			expectedRebate: money.FromFloat(10.00),
+			expectedCredit: money.FromFloat(30.00),
+			expectedAmount: money.FromFloat(180.00),
+		},
+		{
+			name:           "Credit Balance Covers Payoff",
+			date:           today,
+			policy:         domain.RebateFull,
+			creditBalance:  money.FromFloat(250.00),
+			expectedRebate: money.FromFloat(10.00),
+			expectedCredit: money.FromFloat(210.00),
+		},
+		{
+			name:          "Payoff Date In The Past",
+			date:          today.AddDate(0, 0, -1),
+			expectedError: errors.New("payoff date must not be in the past"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockLoanRepo := new(mocks.LoanRepository)
+			loan := payoffLoan()
+			loan.RebatePolicy = tt.policy
+			loan.CreditBalance = tt.creditBalance
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(loan, nil)
+
+			uc := paymentUsecase.NewPaymentUsecase(new(mocks.PaymentRepository), new(mocks.PaymentScheduleRepository), mockLoanRepo, new(mocks.InvestorRepository), new(mocks.BorrowerGroupRepository), new(mocks.LedgerRepository), new(mocks.TransactionManager), s.timeout)
+
+			result, err := uc.QuotePayoff(context.TODO(), 1, tt.date)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(money.FromFloat(200.00), result.Principal)
+			s.Equal(money.FromFloat(20.00), result.Interest)
+			s.Equal(tt.expectedRebate, result.Rebate)
+			s.Equal(tt.expectedCredit, result.CreditApplied)
+			s.Equal(tt.expectedAmount, result.PayoffAmount)
+			s.Len(result.PaymentSchedules, 2)
+		})
+	}
+}
+
+func (s *PaymentUsecaseSuite) TestPayOffLoan() {
+	tests := []struct {
+		name          string
+		loan          *domain.Loan
+		amount        money.Money
+		setupMocks    func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.TransactionManager)
+		expectedError error
+	}{
+		{
+			name:   "Successful Payoff",
+			loan:   payoffLoan(),
+			amount: money.FromFloat(210.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return([]domain.LoanInvestment{
+					{Model: gorm.Model{ID: 4}, LoanID: 1, InvestorID: 7, Amount: money.FromFloat(300.00)},
+				}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(210.00),
+					Principal: money.FromFloat(200.00),
+					Interest:  money.FromFloat(10.00),
+				}, mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, []domain.PaymentAllocation{
+					{PaymentScheduleID: 2, Component: domain.ComponentInterest, Amount: money.FromFloat(10.00)},
+					{PaymentScheduleID: 2, Component: domain.ComponentPrincipal, Amount: money.FromFloat(100.00)},
+					{PaymentScheduleID: 3, Component: domain.ComponentPrincipal, Amount: money.FromFloat(100.00)},
+				}, mock.Anything).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{LoanID: 1, InvestorID: 7, InvestmentID: 4, Principal: money.FromFloat(200.00), Interest: money.FromFloat(10.00), NetAmount: money.FromFloat(210.00)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 2 && ps.Paid && ps.InterestPaid == money.FromFloat(10.00) && ps.PaidAmount == money.FromFloat(110.00)
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 3 && ps.Paid && ps.InterestPaid == 0 && ps.PaidAmount == money.FromFloat(100.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == 0 && loan.Status == domain.LoanClosed
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanDisbursed,
+					ToStatus:   domain.LoanClosed,
+				}, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Payoff Using Credit Balance",
+			loan: func() *domain.Loan {
+				loan := payoffLoan()
+				loan.CreditBalance = money.FromFloat(30.00)
+				return loan
+			}(),
+			amount: money.FromFloat(180.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, &domain.Payment{
+					LoanID:    1,
+					Amount:    money.FromFloat(180.00),
+					Principal: money.FromFloat(200.00),
+					Interest:  money.FromFloat(10.00),
+				}, mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.Paid
+				}), mock.Anything).Return(nil).Twice()
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == 0 && loan.CreditBalance == 0 && loan.Status == domain.LoanClosed
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusChange"), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:          "Amount Does Not Match Payoff",
+			loan:          payoffLoan(),
+			amount:        money.FromFloat(220.00),
+			expectedError: errors.New("payment amount does not match the payoff amount of 210.00"),
+		},
+		{
+			name: "Loan Not Disbursed",
+			loan: func() *domain.Loan {
+				loan := payoffLoan()
+				loan.Status = domain.LoanClosed
+				return loan
+			}(),
+			amount:        money.FromFloat(210.00),
+			expectedError: errors.New("payments are only accepted for disbursed loans, loan is closed"),
+		},
+		{
+			name:   "Error Updating Payment Schedule",
+			loan:   payoffLoan(),
+			amount: money.FromFloat(210.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(1)).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(errors.New("error updating payment schedule"))
+			},
+			expectedError: errors.New("error updating payment schedule"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPaymentRepo := new(mocks.PaymentRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockInvestorRepo := new(mocks.InvestorRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, new(mocks.BorrowerGroupRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(tt.loan, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, mockTransactionManager)
+			}
+			result, err := uc.PayOffLoan(context.TODO(), 1, tt.amount)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.amount, result.PayoffAmount)
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+			mockInvestorRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func settlementLoans() []domain.Loan {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return []domain.Loan{
+		{
+			Model:             gorm.Model{ID: 10},
+			BorrowerID:        5,
+			Status:            domain.LoanDisbursed,
+			OutstandingAmount: money.FromFloat(220.00),
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 11}, LoanID: 10, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, -14)},
+				{Model: gorm.Model{ID: 12}, LoanID: 10, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, 7)},
+			},
+		},
+		{
+			Model:             gorm.Model{ID: 20},
+			BorrowerID:        6,
+			Status:            domain.LoanDisbursed,
+			OutstandingAmount: money.FromFloat(220.00),
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 21}, LoanID: 20, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, -21)},
+				{Model: gorm.Model{ID: 22}, LoanID: 20, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, 7)},
+			},
+		},
+		{
+			Model:             gorm.Model{ID: 30},
+			BorrowerID:        7,
+			Status:            domain.LoanDisbursed,
+			OutstandingAmount: money.FromFloat(110.00),
+			PaymentSchedules: []domain.PaymentSchedule{
+				{Model: gorm.Model{ID: 31}, LoanID: 30, DueAmount: money.FromFloat(110.00), PrincipalAmount: money.FromFloat(100.00), InterestAmount: money.FromFloat(10.00), DueDate: today.AddDate(0, 0, -7)},
+			},
+		},
+	}
+}
+
+func (s *PaymentUsecaseSuite) TestSettleGroupArrears() {
+	group := &domain.BorrowerGroup{
+		Model:   gorm.Model{ID: 3},
+		Members: []domain.Borrower{{Model: gorm.Model{ID: 5}}, {Model: gorm.Model{ID: 6}}, {Model: gorm.Model{ID: 7}}},
+	}
+
+	tests := []struct {
+		name             string
+		payerID          uint
+		amount           money.Money
+		loans            []domain.Loan
+		setupMocks       func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.BorrowerGroupRepository, *mocks.TransactionManager)
+		expectedPayments []dto.SettlementPaymentResponse
+		expectedDebts    []money.Money
+		expectedError    error
+	}{
+		{
+			name:    "Covers Every Member Oldest First",
+			payerID: 5,
+			amount:  money.FromFloat(330.00),
+			loans:   settlementLoans(),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mgr *mocks.BorrowerGroupRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, mock.AnythingOfType("uint")).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateSettlement", mock.Anything, &domain.GroupSettlement{GroupID: 3, PayerID: 5, Amount: money.FromFloat(330.00)}, mock.Anything).
+					Run(func(args mock.Arguments) { args.Get(1).(*domain.GroupSettlement).ID = 9 }).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.MatchedBy(func(payment *domain.Payment) bool {
+					return *payment.SettlementID == 9 && payment.Amount == money.FromFloat(110.00) && payment.Interest == money.FromFloat(10.00)
+				}), mock.Anything).Return(nil).Times(3)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.Paid && ps.PaidAmount == money.FromFloat(110.00)
+				}), mock.Anything).Return(nil).Times(3)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     30,
+					FromStatus: domain.LoanDisbursed,
+					ToStatus:   domain.LoanClosed,
+				}, mock.Anything).Return(nil)
+				mgr.On("CreateMemberDebts", mock.Anything, []domain.MemberDebt{
+					{GroupID: 3, SettlementID: 9, CreditorID: 5, DebtorID: 6, Amount: money.FromFloat(110.00)},
+					{GroupID: 3, SettlementID: 9, CreditorID: 5, DebtorID: 7, Amount: money.FromFloat(110.00)},
+				}, mock.Anything).Return(nil)
+			},
+			expectedPayments: []dto.SettlementPaymentResponse{
+				{LoanID: 20, BorrowerID: 6, Amount: money.FromFloat(110.00), OutstandingAmount: money.FromFloat(110.00), LoanStatus: "disbursed"},
+				{LoanID: 10, BorrowerID: 5, Amount: money.FromFloat(110.00), OutstandingAmount: money.FromFloat(110.00), LoanStatus: "disbursed"},
+				{LoanID: 30, BorrowerID: 7, Amount: money.FromFloat(110.00), OutstandingAmount: 0, LoanStatus: "closed"},
+			},
+			expectedDebts: []money.Money{money.FromFloat(110.00), money.FromFloat(110.00)},
+		},
+		{
+			name:    "Partial Settlement",
+			payerID: 5,
+			amount:  money.FromFloat(165.00),
+			loans:   settlementLoans(),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mgr *mocks.BorrowerGroupRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, mock.AnythingOfType("uint")).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateSettlement", mock.Anything, mock.AnythingOfType("*domain.GroupSettlement"), mock.Anything).
+					Run(func(args mock.Arguments) { args.Get(1).(*domain.GroupSettlement).ID = 9 }).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 21 && ps.Paid
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 11 && !ps.Paid && ps.PaidAmount == money.FromFloat(55.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mgr.On("CreateMemberDebts", mock.Anything, []domain.MemberDebt{
+					{GroupID: 3, SettlementID: 9, CreditorID: 5, DebtorID: 6, Amount: money.FromFloat(110.00)},
+				}, mock.Anything).Return(nil)
+			},
+			expectedPayments: []dto.SettlementPaymentResponse{
+				{LoanID: 20, BorrowerID: 6, Amount: money.FromFloat(110.00), OutstandingAmount: money.FromFloat(110.00), LoanStatus: "disbursed"},
+				{LoanID: 10, BorrowerID: 5, Amount: money.FromFloat(55.00), OutstandingAmount: money.FromFloat(165.00), LoanStatus: "disbursed"},
+			},
+			expectedDebts: []money.Money{money.FromFloat(110.00)},
+		},
+		{
+			name:    "Payer Not In Group",
+			payerID: 8,
+			amount:  money.FromFloat(110.00),
+			setupMocks: func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.BorrowerGroupRepository, *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("payer must be a member of the group"),
+		},
+		{
+			name:    "No Overdue Installments",
+			payerID: 5,
+			amount:  money.FromFloat(110.00),
+			loans: func() []domain.Loan {
+				loans := settlementLoans()[:1]
+				loans[0].PaymentSchedules = loans[0].PaymentSchedules[1:]
+				return loans
+			}(),
+			setupMocks: func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.BorrowerGroupRepository, *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("group has no overdue installments"),
+		},
+		{
+			name:    "Amount Exceeds Overdue Balance",
+			payerID: 5,
+			amount:  money.FromFloat(400.00),
+			loans:   settlementLoans(),
+			setupMocks: func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.BorrowerGroupRepository, *mocks.TransactionManager) {
+			},
+			expectedError: errors.New("payment exceeds the group's overdue balance of 330.00"),
+		},
+		{
+			name:    "Error Creating Member Debts",
+			payerID: 5,
+			amount:  money.FromFloat(110.00),
+			loans:   settlementLoans(),
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mgr *mocks.BorrowerGroupRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetInvestmentsByLoanID", mock.Anything, uint(20)).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreateSettlement", mock.Anything, mock.AnythingOfType("*domain.GroupSettlement"), mock.Anything).Return(nil)
+				mpr.On("CreatePayment", mock.Anything, mock.AnythingOfType("*domain.Payment"), mock.Anything).Return(nil)
+				mpr.On("CreatePaymentAllocations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.AnythingOfType("*domain.PaymentSchedule"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan"), mock.Anything).Return(nil)
+				mgr.On("CreateMemberDebts", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating member debts"))
+			},
+			expectedError: errors.New("error creating member debts"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPaymentRepo := new(mocks.PaymentRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockInvestorRepo := new(mocks.InvestorRepository)
+			mockGroupRepo := new(mocks.BorrowerGroupRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, mockGroupRepo, mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			mockGroupRepo.On("FindGroupByID", mock.Anything, uint(3)).Return(group, nil)
+			mockLoanRepo.On("GetActiveLoansByBorrowerIDs", mock.Anything, []uint{5, 6, 7}).Return(tt.loans, nil)
+			tt.setupMocks(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, mockGroupRepo, mockTransactionManager)
+			result, err := uc.SettleGroupArrears(context.TODO(), 3, tt.payerID, tt.amount)
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(uint(9), result.SettlementID)
+			s.Equal(tt.amount, result.Amount)
+			s.Require().Len(result.Payments, len(tt.expectedPayments))
+			for i, expected := range tt.expectedPayments {
+				s.Equal(expected.LoanID, result.Payments[i].LoanID)
+				s.Equal(expected.BorrowerID, result.Payments[i].BorrowerID)
+				s.Equal(expected.Amount, result.Payments[i].Amount)
+				s.Equal(expected.OutstandingAmount, result.Payments[i].OutstandingAmount)
+				s.Equal(expected.LoanStatus, result.Payments[i].LoanStatus)
+			}
+			s.Require().Len(result.Debts, len(tt.expectedDebts))
+			for i, expected := range tt.expectedDebts {
+				s.Equal(expected, result.Debts[i].OutstandingAmount)
+			}
+			mockLedgerRepo.AssertNumberOfCalls(s.T(), "CreateJournalEntry", len(tt.expectedPayments))
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+			mockGroupRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+// reversedPayoff returns payoffLoan after a payoff of 215.00 that waived 10.00
+// of interest, left 5.00 in credit and closed the loan, with the payoff
+// payment.
+func reversedPayoff() (*domain.Loan, *domain.Payment) {
+	loan := payoffLoan()
+	loan.Status = domain.LoanClosed
+	loan.OutstandingAmount = 0
+	loan.CreditBalance = money.FromFloat(5.00)
+	loan.PaymentSchedules[1].Settle(0)
+	loan.PaymentSchedules[2].Settle(money.FromFloat(10.00))
+
+	payment := &domain.Payment{
+		Model:     gorm.Model{ID: 9},
+		LoanID:    1,
+		Amount:    money.FromFloat(215.00),
+		Principal: money.FromFloat(200.00),
+		Interest:  money.FromFloat(10.00),
+		Allocations: []domain.PaymentAllocation{
+			{PaymentID: 9, PaymentScheduleID: 2, Component: domain.ComponentInterest, Amount: money.FromFloat(10.00)},
+			{PaymentID: 9, PaymentScheduleID: 2, Component: domain.ComponentPrincipal, Amount: money.FromFloat(100.00)},
+			{PaymentID: 9, PaymentScheduleID: 3, Component: domain.ComponentPrincipal, Amount: money.FromFloat(100.00)},
+		},
+	}
+	return loan, payment
+}
+
+func (s *PaymentUsecaseSuite) TestReversePayment() {
+	tests := []struct {
+		name          string
+		prepare       func(*domain.Loan, *domain.Payment)
+		setupMocks    func(*mocks.PaymentRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.InvestorRepository, *mocks.TransactionManager)
+		expected      *dto.PaymentReversalResponse
+		expectedError error
+	}{
+		{
+			name:    "Reverses Payoff And Reopens Loan",
+			prepare: func(*domain.Loan, *domain.Payment) {},
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetReturnsByPaymentID", mock.Anything, uint(9)).Return([]domain.InvestorReturn{
+					{PaymentID: 9, LoanID: 1, InvestorID: 7, InvestmentID: 4, Principal: money.FromFloat(200.00), Interest: money.FromFloat(10.00), NetAmount: money.FromFloat(210.00)},
+				}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateReversal", mock.Anything, &domain.PaymentReversal{
+					PaymentID: 9,
+					LoanID:    1,
+					Amount:    money.FromFloat(215.00),
+					Reason:    "keyed against the wrong loan",
+				}, mock.Anything).Return(nil)
+				mir.On("CreateInvestorReturns", mock.Anything, []domain.InvestorReturn{
+					{PaymentID: 9, LoanID: 1, InvestorID: 7, InvestmentID: 4, Principal: money.FromFloat(-200.00), Interest: money.FromFloat(-10.00), NetAmount: money.FromFloat(-210.00)},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 2 && !ps.Paid && ps.PaidAmount == 0 && ps.InterestPaid == 0
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 3 && !ps.Paid && ps.PaidAmount == 0 && ps.PrincipalPaid == 0
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.OutstandingAmount == money.FromFloat(220.00) && loan.CreditBalance == 0 && loan.Status == domain.LoanDisbursed
+				}), mock.Anything).Return(nil)
+				mlr.On("CreateStatusChange", mock.Anything, &domain.LoanStatusChange{
+					LoanID:     1,
+					FromStatus: domain.LoanClosed,
+					ToStatus:   domain.LoanDisbursed,
+				}, mock.Anything).Return(nil)
+			},
+			expected: &dto.PaymentReversalResponse{
+				PaymentID:         9,
+				LoanID:            1,
+				Amount:            money.FromFloat(215.00),
+				Reason:            "keyed against the wrong loan",
+				OutstandingAmount: money.FromFloat(220.00),
+				LoanStatus:        "disbursed",
+			},
+		},
+		{
+			name: "Reverses Recovery",
+			prepare: func(loan *domain.Loan, payment *domain.Payment) {
+				loan.Status = domain.LoanWrittenOff
+				loan.CreditBalance = 0
+				loan.WrittenOffAmount = money.FromFloat(220.00)
+				loan.RecoveredAmount = money.FromFloat(60.00)
+				*payment = domain.Payment{Model: gorm.Model{ID: 9}, LoanID: 1, Amount: money.FromFloat(40.00), Principal: money.FromFloat(40.00), Recovery: true}
+			},
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetReturnsByPaymentID", mock.Anything, uint(9)).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateReversal", mock.Anything, mock.AnythingOfType("*domain.PaymentReversal"), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.RecoveredAmount == money.FromFloat(20.00) && loan.Status == domain.LoanWrittenOff
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.PaymentReversalResponse{
+				PaymentID:       9,
+				LoanID:          1,
+				Amount:          money.FromFloat(40.00),
+				Reason:          "keyed against the wrong loan",
+				RecoveredAmount: money.FromFloat(20.00),
+				LoanStatus:      "written_off",
+			},
+		},
+		{
+			name: "Already Reversed",
+			prepare: func(_ *domain.Loan, payment *domain.Payment) {
+				payment.Reversal = &domain.PaymentReversal{PaymentID: 9}
+			},
+			expectedError: errors.New("payment has already been reversed"),
+		},
+		{
+			name: "Group Settlement Payment",
+			prepare: func(_ *domain.Loan, payment *domain.Payment) {
+				settlementID := uint(3)
+				payment.SettlementID = &settlementID
+			},
+			expectedError: errors.New("payments made in a group settlement cannot be reversed"),
+		},
+		{
+			name: "Credit Already Refunded",
+			prepare: func(loan *domain.Loan, _ *domain.Payment) {
+				loan.CreditBalance = money.FromFloat(2.00)
+			},
+			expectedError: errors.New("payment credited 5.00 but the credit balance is only 2.00"),
+		},
+		{
+			name: "Installments Since Restructured",
+			prepare: func(loan *domain.Loan, _ *domain.Payment) {
+				loan.PaymentSchedules = loan.PaymentSchedules[:2]
+			},
+			expectedError: errors.New("payment settled installments that have since been restructured"),
+		},
+		{
+			name:    "Error Creating Reversal",
+			prepare: func(*domain.Loan, *domain.Payment) {},
+			setupMocks: func(mpr *mocks.PaymentRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mir *mocks.InvestorRepository, mtm *mocks.TransactionManager) {
+				mir.On("GetReturnsByPaymentID", mock.Anything, uint(9)).Return(nil, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreateReversal", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating reversal"))
+			},
+			expectedError: errors.New("error creating reversal"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPaymentRepo := new(mocks.PaymentRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockInvestorRepo := new(mocks.InvestorRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, new(mocks.BorrowerGroupRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			loan, payment := reversedPayoff()
+			tt.prepare(loan, payment)
+			mockPaymentRepo.On("FindPaymentByID", mock.Anything, uint(9)).Return(payment, nil)
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(loan, nil).Maybe()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockPaymentRepo, mockPaymentScheduleRepo, mockLoanRepo, mockInvestorRepo, mockTransactionManager)
 			}
+
+			result, err := uc.ReversePayment(context.TODO(), 9, "keyed against the wrong loan")
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			result.PaymentSchedules = nil
+			s.Equal(tt.expected, result)
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+			mockInvestorRepo.AssertExpectations(s.T())
+			mockLedgerRepo.AssertNumberOfCalls(s.T(), "CreateJournalEntry", 1)
 		})
 	}
 }
 
+func (s *PaymentUsecaseSuite) TestRefundCredit() {
+	tests := []struct {
+		name          string
+		amount        money.Money
+		setupMocks    func(*mocks.PaymentRepository, *mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.RefundResponse
+		expectedError error
+	}{
+		{
+			name:   "Refunds Part Of The Credit Balance",
+			amount: money.FromFloat(3.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateRefund", mock.Anything, &domain.Refund{LoanID: 1, Amount: money.FromFloat(3.00), Reason: "overpaid"}, mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.CreditBalance == money.FromFloat(2.00)
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.RefundResponse{
+				LoanID:        1,
+				Amount:        money.FromFloat(3.00),
+				Reason:        "overpaid",
+				CreditBalance: money.FromFloat(2.00),
+			},
+		},
+		{
+			name:          "Refund Exceeds Credit Balance",
+			amount:        money.FromFloat(6.00),
+			expectedError: errors.New("refund exceeds the credit balance of 5.00"),
+		},
+		{
+			name:   "Error Updating Loan",
+			amount: money.FromFloat(5.00),
+			setupMocks: func(mpr *mocks.PaymentRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error updating loan"))
+			},
+			expectedError: errors.New("error updating loan"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPaymentRepo := new(mocks.PaymentRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			mockLedgerRepo := new(mocks.LedgerRepository)
+			mockLedgerRepo.On("CreateJournalEntry", mock.Anything, mock.MatchedBy(func(entry *domain.JournalEntry) bool {
+				return entry.Validate() == nil
+			}), mock.Anything).Return(nil).Maybe()
+			uc := paymentUsecase.NewPaymentUsecase(mockPaymentRepo, new(mocks.PaymentScheduleRepository), mockLoanRepo, new(mocks.InvestorRepository), new(mocks.BorrowerGroupRepository), mockLedgerRepo, mockTransactionManager, s.timeout)
+
+			loan, _ := reversedPayoff()
+			mockLoanRepo.On("FindLoanByID", mock.Anything, uint(1)).Return(loan, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockPaymentRepo, mockLoanRepo, mockTransactionManager)
+			}
+
+			result, err := uc.RefundCredit(context.TODO(), 1, tt.amount, "overpaid")
+			if tt.expectedError != nil {
+				s.EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.expected, result)
+			mockPaymentRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *PaymentUsecaseSuite) TestRefundCreditNotPositive() {
+	uc := paymentUsecase.NewPaymentUsecase(new(mocks.PaymentRepository), new(mocks.PaymentScheduleRepository), new(mocks.LoanRepository), new(mocks.InvestorRepository), new(mocks.BorrowerGroupRepository), new(mocks.LedgerRepository), new(mocks.TransactionManager), s.timeout)
+
+	_, err := uc.RefundCredit(context.TODO(), 1, 0, "overpaid")
+	s.EqualError(err, "refund amount must be positive")
+}
+
 func TestPaymentUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(PaymentUsecaseSuite))
 }
@@ -38,7 +38,7 @@ func (s *sqlitePaymentScheduleRepository) BulkCreatePaymentSchedule(ctx context.
 func (s *sqlitePaymentScheduleRepository) GetPaymentSchedulesByLoanID(ctx context.Context, loanID uint) ([]domain.PaymentSchedule, error) {
 	var paymentSchedules []domain.PaymentSchedule
 
-	err := s.TransactionManager.GetDB().WithContext(ctx).Where(&domain.PaymentSchedule{LoanID: loanID}).Find(&paymentSchedules).Error
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("loan_id = ? AND superseded = ?", loanID, false).Find(&paymentSchedules).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("Payment schedule not found")
@@ -52,7 +52,7 @@ func (s *sqlitePaymentScheduleRepository) GetPaymentSchedulesByLoanID(ctx contex
 func (s *sqlitePaymentScheduleRepository) GetUnpaidPaymentSchedulesByLoanID(ctx context.Context, loanID uint, date time.Time) ([]domain.PaymentSchedule, error) {
 	var paymentSchedules []domain.PaymentSchedule
 
-	err := s.TransactionManager.GetDB().WithContext(ctx).Where("loan_id = ? AND due_date <= ? AND paid = ?", loanID, date, false).Find(&paymentSchedules).Error
+	err := s.TransactionManager.GetDB().WithContext(ctx).Where("loan_id = ? AND due_date <= ? AND paid = ? AND superseded = ?", loanID, date, false, false).Find(&paymentSchedules).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("Payment schedule not found")
@@ -76,7 +76,11 @@ func (s *sqlitePaymentScheduleRepository) BulkPayPaymentSchedules(ctx context.Co
 		tx = s.TransactionManager.GetDB()
 	}
 
-	result := tx.WithContext(ctx).Model(domain.PaymentSchedule{}).Where("id IN ?", paymentSchedulesID).Update("paid", true)
+	result := tx.WithContext(ctx).Model(domain.PaymentSchedule{}).Where("id IN ?", paymentSchedulesID).Updates(map[string]interface{}{
+		"paid":        true,
+		"paid_at":     time.Now().UTC(),
+		"paid_amount": gorm.Expr("due_amount"),
+	})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -87,3 +91,34 @@ func (s *sqlitePaymentScheduleRepository) BulkPayPaymentSchedules(ctx context.Co
 
 	return nil
 }
+
+// SupersedePaymentSchedules marks the given installments as replaced by a
+// restructure. Superseded installments are kept for history but no longer
+// count towards the loan.
+func (s *sqlitePaymentScheduleRepository) SupersedePaymentSchedules(ctx context.Context, paymentSchedulesID []uint, restructureID uint, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	result := tx.WithContext(ctx).Model(domain.PaymentSchedule{}).Where("id IN ?", paymentSchedulesID).Updates(map[string]interface{}{
+		"superseded":     true,
+		"restructure_id": restructureID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no rows were updated")
+	}
+
+	return nil
+}
+
+func (s *sqlitePaymentScheduleRepository) DeletePaymentSchedulesByLoanID(ctx context.Context, loanID uint, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Where("loan_id = ?", loanID).Delete(&domain.PaymentSchedule{}).Error
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type PenaltyHandler struct {
+	PenaltyUsecase domain.PenaltyUsecase
+}
+
+func NewPenaltyHandler(g *gin.Engine, p domain.PenaltyUsecase) {
+	handler := &PenaltyHandler{PenaltyUsecase: p}
+
+	g.POST("/penalties/accrue", handler.AccruePenalties)
+}
+
+// AccruePenalties runs the penalty accrual for the date in the query string,
+// or for today when none is given.
+func (p *PenaltyHandler) AccruePenalties(c *gin.Context) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("date"); query != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid date format, should be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	response, err := p.PenaltyUsecase.AccruePenalties(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,98 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	penaltyHttp "github.com/greekrode/loan-engine-amartha/penalty/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.PenaltyUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := penaltyHttp.PenaltyHandler{
+		PenaltyUsecase: mockUCase,
+	}
+	router.POST("/penalties/accrue", handler.AccruePenalties)
+	return router
+}
+
+func TestAccruePenalties(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accrualDate := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockUsecase    *mocks.PenaltyUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Valid Accrual",
+			query: "?date=2024-01-11",
+			mockUsecase: func() *mocks.PenaltyUsecase {
+				mockUsecase := new(mocks.PenaltyUsecase)
+				mockUsecase.On("AccruePenalties", mock.Anything, accrualDate).Return(&dto.AccruePenaltiesResponse{
+					AccruedOn:    accrualDate,
+					LoansCharged: 1,
+					TotalPenalty: money.FromFloat(5),
+					Charges: []dto.PenaltyChargeResponse{
+						{LoanID: 1, PaymentScheduleID: 10, Amount: money.FromFloat(5)},
+					},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"accrued_on": "2024-01-11T00:00:00Z",
+				"loans_charged": 1,
+				"total_penalty": 5,
+				"charges": [{"loan_id": 1, "payment_schedule_id": 10, "amount": 5}]
+			}`,
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?date=11-01-2024",
+			mockUsecase:    new(mocks.PenaltyUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid date format, should be YYYY-MM-DD"}`,
+		},
+		{
+			name:  "Usecase Error",
+			query: "?date=2024-01-11",
+			mockUsecase: func() *mocks.PenaltyUsecase {
+				mockUsecase := new(mocks.PenaltyUsecase)
+				mockUsecase.On("AccruePenalties", mock.Anything, accrualDate).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/penalties/accrue"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqlitePenaltyRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLitePenaltyRepository(tm db.TransactionManager) *sqlitePenaltyRepository {
+	return &sqlitePenaltyRepository{TransactionManager: tm}
+}
+
+func (s *sqlitePenaltyRepository) CreatePenaltyCharges(ctx context.Context, charges []domain.PenaltyCharge, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Create(&charges).Error
+}
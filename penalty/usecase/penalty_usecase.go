@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type penaltyUsecase struct {
+	penaltyRepo         domain.PenaltyRepository
+	paymentScheduleRepo domain.PaymentScheduleRepository
+	loanRepo            domain.LoanRepository
+	transactionManager  db.TransactionManager
+	contextTimeout      time.Duration
+}
+
+func NewPenaltyUsecase(p domain.PenaltyRepository, ps domain.PaymentScheduleRepository, l domain.LoanRepository, tm db.TransactionManager, timeout time.Duration) domain.PenaltyUsecase {
+	return &penaltyUsecase{
+		penaltyRepo:         p,
+		paymentScheduleRepo: ps,
+		loanRepo:            l,
+		transactionManager:  tm,
+		contextTimeout:      timeout,
+	}
+}
+
+// AccruePenalties charges every overdue installment the penalty its loan's
+// policy sets for the days up to the given date. Running it again for the
+// same date charges nothing more.
+func (p *penaltyUsecase) AccruePenalties(ctx context.Context, date time.Time) (*dto.AccruePenaltiesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	loans, err := p.loanRepo.GetOverdueLoans(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var charges []domain.PenaltyCharge
+	var chargedSchedules []domain.PaymentSchedule
+	var chargedLoans []domain.Loan
+	for _, loan := range loans {
+		chargedBefore := len(charges)
+		for _, schedule := range loan.PaymentSchedules {
+			charge := loan.PenaltyPolicy.Charge(schedule, date)
+			if charge == 0 {
+				continue
+			}
+
+			schedule.AddPenalty(charge, date)
+			loan.OutstandingAmount += charge
+			chargedSchedules = append(chargedSchedules, schedule)
+			charges = append(charges, domain.PenaltyCharge{
+				LoanID:            loan.ID,
+				PaymentScheduleID: schedule.ID,
+				Amount:            charge,
+				AccruedOn:         date,
+			})
+		}
+
+		if len(charges) > chargedBefore {
+			loan.PaymentSchedules = nil
+			chargedLoans = append(chargedLoans, loan)
+		}
+	}
+
+	response := &dto.AccruePenaltiesResponse{
+		AccruedOn:    date,
+		LoansCharged: len(chargedLoans),
+		Charges:      make([]dto.PenaltyChargeResponse, len(charges)),
+	}
+	for i, charge := range charges {
+		response.TotalPenalty += charge.Amount
+		response.Charges[i] = dto.PenaltyChargeResponse{
+			LoanID:            charge.LoanID,
+			PaymentScheduleID: charge.PaymentScheduleID,
+			Amount:            charge.Amount,
+		}
+	}
+
+	if len(charges) == 0 {
+		return response, nil
+	}
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.penaltyRepo.CreatePenaltyCharges(ctx, charges, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	for i := range chargedSchedules {
+		if err := p.paymentScheduleRepo.UpdatePaymentSchedule(ctx, &chargedSchedules[i], tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	for i := range chargedLoans {
+		if err := p.loanRepo.UpdateLoan(ctx, &chargedLoans[i], tx); err != nil {
+			p.transactionManager.Rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return response, nil
+}
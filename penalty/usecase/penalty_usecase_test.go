@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	penaltyUsecase "github.com/greekrode/loan-engine-amartha/penalty/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PenaltyUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *PenaltyUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+func (s *PenaltyUsecaseSuite) TestAccruePenalties() {
+	accrualDate := time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)
+
+	// overdueLoans returns a loan charging a flat 5.00 per missed installment
+	// and one charging 1% a day, each with one installment three days late.
+	overdueLoans := func() []domain.Loan {
+		return []domain.Loan{
+			{
+				Model:             gorm.Model{ID: 1},
+				Status:            domain.LoanDisbursed,
+				OutstandingAmount: money.FromFloat(200.00),
+				PenaltyPolicy:     domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5.00)},
+				PaymentSchedules: []domain.PaymentSchedule{
+					{Model: gorm.Model{ID: 10}, LoanID: 1, DueAmount: money.FromFloat(100.00), DueDate: accrualDate.AddDate(0, 0, -3)},
+				},
+			},
+			{
+				Model:             gorm.Model{ID: 2},
+				Status:            domain.LoanDisbursed,
+				OutstandingAmount: money.FromFloat(200.00),
+				PenaltyPolicy:     domain.PenaltyPolicy{Type: domain.PenaltyDailyRate, Rate: 1},
+				PaymentSchedules: []domain.PaymentSchedule{
+					{Model: gorm.Model{ID: 20}, LoanID: 2, DueAmount: money.FromFloat(100.00), DueDate: accrualDate.AddDate(0, 0, -3)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		loans         []domain.Loan
+		setupMocks    func(*mocks.PenaltyRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.TransactionManager)
+		expected      *dto.AccruePenaltiesResponse
+		expectedError error
+	}{
+		{
+			name:  "Accrues Penalties On Overdue Installments",
+			loans: overdueLoans(),
+			setupMocks: func(mpr *mocks.PenaltyRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreatePenaltyCharges", mock.Anything, []domain.PenaltyCharge{
+					{LoanID: 1, PaymentScheduleID: 10, Amount: money.FromFloat(5.00), AccruedOn: accrualDate},
+					{LoanID: 2, PaymentScheduleID: 20, Amount: money.FromFloat(3.00), AccruedOn: accrualDate},
+				}, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 10 && ps.PenaltyAmount == money.FromFloat(5.00) && ps.DueAmount == money.FromFloat(105.00) && ps.PenaltyAccruedAt.Equal(accrualDate)
+				}), mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.MatchedBy(func(ps *domain.PaymentSchedule) bool {
+					return ps.ID == 20 && ps.PenaltyAmount == money.FromFloat(3.00) && ps.DueAmount == money.FromFloat(103.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 1 && loan.OutstandingAmount == money.FromFloat(205.00)
+				}), mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+					return loan.ID == 2 && loan.OutstandingAmount == money.FromFloat(203.00)
+				}), mock.Anything).Return(nil)
+			},
+			expected: &dto.AccruePenaltiesResponse{
+				AccruedOn:    accrualDate,
+				LoansCharged: 2,
+				TotalPenalty: money.FromFloat(8.00),
+				Charges: []dto.PenaltyChargeResponse{
+					{LoanID: 1, PaymentScheduleID: 10, Amount: money.FromFloat(5.00)},
+					{LoanID: 2, PaymentScheduleID: 20, Amount: money.FromFloat(3.00)},
+				},
+			},
+		},
+		{
+			name: "Nothing To Charge",
+			loans: func() []domain.Loan {
+				loans := overdueLoans()
+				accruedAt := accrualDate
+				loans[0].PaymentSchedules[0].PenaltyAccruedAt = &accruedAt
+				loans[1].PaymentSchedules[0].PenaltyAccruedAt = &accruedAt
+				return loans
+			}(),
+			setupMocks: func(*mocks.PenaltyRepository, *mocks.PaymentScheduleRepository, *mocks.LoanRepository, *mocks.TransactionManager) {
+			},
+			expected: &dto.AccruePenaltiesResponse{
+				AccruedOn: accrualDate,
+				Charges:   []dto.PenaltyChargeResponse{},
+			},
+		},
+		{
+			name:  "Error Creating Penalty Charges",
+			loans: overdueLoans(),
+			setupMocks: func(mpr *mocks.PenaltyRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePenaltyCharges", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating penalty charges"))
+			},
+			expectedError: errors.New("error creating penalty charges"),
+		},
+		{
+			name:  "Error Updating Payment Schedule",
+			loans: overdueLoans(),
+			setupMocks: func(mpr *mocks.PenaltyRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePenaltyCharges", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error updating payment schedule"))
+			},
+			expectedError: errors.New("error updating payment schedule"),
+		},
+		{
+			name:  "Error Updating Loan",
+			loans: overdueLoans(),
+			setupMocks: func(mpr *mocks.PenaltyRepository, mpsr *mocks.PaymentScheduleRepository, mlr *mocks.LoanRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreatePenaltyCharges", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mpsr.On("UpdatePaymentSchedule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mlr.On("UpdateLoan", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error updating loan"))
+			},
+			expectedError: errors.New("error updating loan"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockPenaltyRepo := new(mocks.PenaltyRepository)
+			mockPaymentScheduleRepo := new(mocks.PaymentScheduleRepository)
+			mockLoanRepo := new(mocks.LoanRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := penaltyUsecase.NewPenaltyUsecase(mockPenaltyRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionManager, s.timeout)
+
+			mockLoanRepo.On("GetOverdueLoans", mock.Anything, accrualDate).Return(tt.loans, nil)
+			tt.setupMocks(mockPenaltyRepo, mockPaymentScheduleRepo, mockLoanRepo, mockTransactionManager)
+
+			result, err := uc.AccruePenalties(context.TODO(), accrualDate)
+			if tt.expectedError != nil {
+				assert.EqualError(s.T(), err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.expected, result)
+			mockPenaltyRepo.AssertExpectations(s.T())
+			mockPaymentScheduleRepo.AssertExpectations(s.T())
+			mockLoanRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *PenaltyUsecaseSuite) TestAccruePenaltiesLoanError() {
+	mockLoanRepo := new(mocks.LoanRepository)
+	mockLoanRepo.On("GetOverdueLoans", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	uc := penaltyUsecase.NewPenaltyUsecase(new(mocks.PenaltyRepository), new(mocks.PaymentScheduleRepository), mockLoanRepo, new(mocks.TransactionManager), s.timeout)
+
+	_, err := uc.AccruePenalties(context.TODO(), time.Now())
+	assert.EqualError(s.T(), err, "database error")
+}
+
+func TestPenaltyUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PenaltyUsecaseSuite))
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type LoanProductHandler struct {
+	LoanProductUsecase domain.LoanProductUsecase
+}
+
+func NewLoanProductHandler(g *gin.Engine, p domain.LoanProductUsecase) {
+	handler := &LoanProductHandler{LoanProductUsecase: p}
+
+	g.POST("/products", handler.CreateProduct)
+	g.GET("/products", handler.GetProducts)
+	g.GET("/products/:product_id", handler.GetProduct)
+	g.PUT("/products/:product_id", handler.UpdateProduct)
+	g.DELETE("/products/:product_id", handler.DeleteProduct)
+}
+
+func (p *LoanProductHandler) CreateProduct(c *gin.Context) {
+	product, ok := bindLoanProduct(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	productResponse, err := p.LoanProductUsecase.CreateProduct(ctx, product)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, productResponse)
+}
+
+func (p *LoanProductHandler) GetProducts(c *gin.Context) {
+	ctx := c.Request.Context()
+	productResponses, err := p.LoanProductUsecase.GetProducts(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, productResponses)
+}
+
+func (p *LoanProductHandler) GetProduct(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid product ID format"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	productResponse, err := p.LoanProductUsecase.GetProduct(ctx, uint(productID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, productResponse)
+}
+
+func (p *LoanProductHandler) UpdateProduct(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid product ID format"})
+		return
+	}
+
+	product, ok := bindLoanProduct(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	productResponse, err := p.LoanProductUsecase.UpdateProduct(ctx, uint(productID), product)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, productResponse)
+}
+
+func (p *LoanProductHandler) DeleteProduct(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid product ID format"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := p.LoanProductUsecase.DeleteProduct(ctx, uint(productID)); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.CommonResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CommonResponse{Message: "product deleted"})
+}
+
+// bindLoanProduct parses and validates a product definition. It writes the
+// error response itself and reports whether the handler should continue.
+func bindLoanProduct(c *gin.Context) (domain.LoanProduct, bool) {
+	var req dto.SaveLoanProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: "invalid request body"})
+		return domain.LoanProduct{}, false
+	}
+
+	method := domain.AmortizationFlat
+	if req.AmortizationMethod != "" {
+		method = domain.AmortizationMethod(req.AmortizationMethod)
+	}
+
+	rebatePolicy := domain.RebateNone
+	if req.RebatePolicy != "" {
+		rebatePolicy = domain.RebatePolicy(req.RebatePolicy)
+	}
+
+	dayCount := domain.DayCountActual365
+	if req.DayCount != "" {
+		dayCount = domain.DayCount(req.DayCount)
+	}
+
+	allocationOrder := domain.DefaultAllocationOrder
+	if len(req.AllocationOrder) > 0 {
+		allocationOrder = make(domain.AllocationOrder, len(req.AllocationOrder))
+		for i, component := range req.AllocationOrder {
+			allocationOrder[i] = domain.AllocationComponent(component)
+		}
+	}
+
+	penaltyType := domain.PenaltyNone
+	if req.PenaltyPolicy.Type != "" {
+		penaltyType = domain.PenaltyType(req.PenaltyPolicy.Type)
+	}
+
+	fees := make([]domain.LoanProductFee, len(req.Fees))
+	for i, feeReq := range req.Fees {
+		charge := domain.FeeUpfront
+		if feeReq.Charge != "" {
+			charge = domain.FeeCharge(feeReq.Charge)
+		}
+
+		fees[i] = domain.LoanProductFee{
+			Name:   feeReq.Name,
+			Type:   domain.FeeType(feeReq.Type),
+			Charge: charge,
+			Amount: feeReq.Amount,
+			Rate:   feeReq.Rate,
+		}
+	}
+
+	product := domain.LoanProduct{
+		Name:               req.Name,
+		MinPrincipal:       req.MinPrincipal,
+		MaxPrincipal:       req.MaxPrincipal,
+		Tenors:             req.Tenors,
+		InterestRate:       req.InterestRate,
+		ServiceFeeRate:     req.ServiceFeeRate,
+		AmortizationMethod: method,
+		RebatePolicy:       rebatePolicy,
+		DayCount:           dayCount,
+		AllocationOrder:    allocationOrder,
+		PenaltyPolicy: domain.PenaltyPolicy{
+			Type:   penaltyType,
+			Amount: req.PenaltyPolicy.Amount,
+			Rate:   req.PenaltyPolicy.Rate,
+			Cap:    req.PenaltyPolicy.Cap,
+		},
+		Fees: fees,
+	}
+
+	if err := product.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.CommonResponse{Message: err.Error()})
+		return domain.LoanProduct{}, false
+	}
+
+	return product, true
+}
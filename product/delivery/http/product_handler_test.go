@@ -0,0 +1,261 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	productHttp "github.com/greekrode/loan-engine-amartha/product/delivery/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(mockUCase *mocks.LoanProductUsecase) *gin.Engine {
+	router := gin.Default()
+	handler := productHttp.LoanProductHandler{
+		LoanProductUsecase: mockUCase,
+	}
+	router.POST("/products", handler.CreateProduct)
+	router.GET("/products/:product_id", handler.GetProduct)
+	router.DELETE("/products/:product_id", handler.DeleteProduct)
+	return router
+}
+
+func TestCreateProduct(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	product := domain.LoanProduct{
+		Name:               "Weekly Micro",
+		MinPrincipal:       money.FromFloat(500),
+		MaxPrincipal:       money.FromFloat(10000),
+		Tenors:             []int{25, 50},
+		InterestRate:       12,
+		ServiceFeeRate:     20,
+		AmortizationMethod: domain.AmortizationFlat,
+		RebatePolicy:       domain.RebateNone,
+		DayCount:           domain.DayCountActual365,
+		AllocationOrder:    domain.DefaultAllocationOrder,
+		PenaltyPolicy:      domain.PenaltyPolicy{Type: domain.PenaltyFlat, Amount: money.FromFloat(5), Cap: money.FromFloat(20)},
+		Fees: []domain.LoanProductFee{
+			{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Rate: 2},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockUsecase    *mocks.LoanProductUsecase
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Valid Product",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25, 50],
+				"interest_rate": 12,
+				"service_fee_rate": 20,
+				"penalty_policy": {"type": "flat", "amount": 5, "cap": 20},
+				"fees": [{"name": "Admin", "type": "percentage", "rate": 2}]
+			}`,
+			mockUsecase: func() *mocks.LoanProductUsecase {
+				mockUsecase := new(mocks.LoanProductUsecase)
+				mockUsecase.On("CreateProduct", mock.Anything, product).Return(&dto.GetLoanProductResponse{
+					ID:                 1,
+					Name:               "Weekly Micro",
+					MinPrincipal:       money.FromFloat(500),
+					MaxPrincipal:       money.FromFloat(10000),
+					Tenors:             []int{25, 50},
+					InterestRate:       12,
+					ServiceFeeRate:     20,
+					AmortizationMethod: "flat",
+					RebatePolicy:       "none",
+					DayCount:           "act/365",
+					AllocationOrder:    []string{"fee", "penalty", "interest", "principal"},
+					PenaltyPolicy:      dto.GetPenaltyPolicyResponse{Type: "flat", Amount: money.FromFloat(5), Cap: money.FromFloat(20)},
+					Fees:               []dto.GetLoanProductFeeResponse{{Name: "Admin", Type: "percentage", Charge: "upfront", Rate: 2}},
+				}, nil)
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{
+				"id": 1,
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25, 50],
+				"interest_rate": 12,
+				"service_fee_rate": 20,
+				"amortization_method": "flat",
+				"rebate_policy": "none",
+				"day_count": "act/365",
+				"allocation_order": ["fee", "penalty", "interest", "principal"],
+				"penalty_policy": {"type": "flat", "amount": 5, "rate": 0, "cap": 20},
+				"fees": [{"name": "Admin", "type": "percentage", "charge": "upfront", "amount": 0, "rate": 2}]
+			}`,
+		},
+		{
+			name: "Zero Tenor",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [0],
+				"interest_rate": 12
+			}`,
+			mockUsecase:    new(mocks.LoanProductUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"tenor must be positive"}`,
+		},
+		{
+			name: "Invalid Allocation Order",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25],
+				"interest_rate": 12,
+				"allocation_order": ["principal", "interest"]
+			}`,
+			mockUsecase:    new(mocks.LoanProductUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"allocation order must list each of fee, penalty, interest and principal once"}`,
+		},
+		{
+			name: "Negative Rate",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25],
+				"interest_rate": -5
+			}`,
+			mockUsecase:    new(mocks.LoanProductUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"interest rate must not be negative"}`,
+		},
+		{
+			name: "Service Fee Rate Above 100",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25],
+				"interest_rate": 12,
+				"service_fee_rate": 120
+			}`,
+			mockUsecase:    new(mocks.LoanProductUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"service fee rate must be between 0 and 100"}`,
+		},
+		{
+			name: "Usecase Error",
+			requestBody: `{
+				"name": "Weekly Micro",
+				"min_principal": 500,
+				"max_principal": 10000,
+				"tenors": [25, 50],
+				"interest_rate": 12,
+				"service_fee_rate": 20,
+				"penalty_policy": {"type": "flat", "amount": 5, "cap": 20},
+				"fees": [{"name": "Admin", "type": "percentage", "rate": 2}]
+			}`,
+			mockUsecase: func() *mocks.LoanProductUsecase {
+				mockUsecase := new(mocks.LoanProductUsecase)
+				mockUsecase.On("CreateProduct", mock.Anything, product).Return(nil, errors.New("internal error"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "POST", "/products", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetProduct(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		productID      string
+		mockUsecase    *mocks.LoanProductUsecase
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "Product Not Found",
+			productID: "9",
+			mockUsecase: func() *mocks.LoanProductUsecase {
+				mockUsecase := new(mocks.LoanProductUsecase)
+				mockUsecase.On("GetProduct", mock.Anything, uint(9)).Return(nil, errors.New("Loan product not found"))
+				return mockUsecase
+			}(),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"Loan product not found"}`,
+		},
+		{
+			name:           "Invalid Product ID",
+			productID:      "abc",
+			mockUsecase:    new(mocks.LoanProductUsecase),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid product ID format"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(tt.mockUsecase)
+			req, err := http.NewRequestWithContext(context.TODO(), "GET", "/products/"+tt.productID, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(mocks.LoanProductUsecase)
+	mockUsecase.On("DeleteProduct", mock.Anything, uint(1)).Return(nil)
+
+	router := setupRouter(mockUsecase)
+	req, err := http.NewRequestWithContext(context.TODO(), "DELETE", "/products/1", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"message":"product deleted"}`, rec.Body.String())
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"gorm.io/gorm"
+)
+
+type sqliteLoanProductRepository struct {
+	TransactionManager db.TransactionManager
+}
+
+func NewSQLiteLoanProductRepository(tm db.TransactionManager) *sqliteLoanProductRepository {
+	return &sqliteLoanProductRepository{TransactionManager: tm}
+}
+
+func (s *sqliteLoanProductRepository) CreateProduct(ctx context.Context, product *domain.LoanProduct, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Omit("Fees").Create(product).Error
+}
+
+func (s *sqliteLoanProductRepository) FindProductByID(ctx context.Context, productID uint) (*domain.LoanProduct, error) {
+	var product domain.LoanProduct
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Fees").First(&product, productID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("Loan product not found")
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (s *sqliteLoanProductRepository) GetProducts(ctx context.Context) ([]domain.LoanProduct, error) {
+	var products []domain.LoanProduct
+
+	err := s.TransactionManager.GetDB().WithContext(ctx).Preload("Fees").Order("id").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (s *sqliteLoanProductRepository) UpdateProduct(ctx context.Context, product *domain.LoanProduct, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	return tx.WithContext(ctx).Omit("Fees").Save(product).Error
+}
+
+func (s *sqliteLoanProductRepository) ReplaceFees(ctx context.Context, productID uint, fees []domain.LoanProductFee, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	err := tx.WithContext(ctx).Where("product_id = ?", productID).Delete(&domain.LoanProductFee{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(fees) == 0 {
+		return nil
+	}
+
+	for i := range fees {
+		fees[i].ProductID = productID
+	}
+
+	return tx.WithContext(ctx).Create(&fees).Error
+}
+
+func (s *sqliteLoanProductRepository) DeleteProduct(ctx context.Context, productID uint, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.TransactionManager.GetDB()
+	}
+
+	result := tx.WithContext(ctx).Delete(&domain.LoanProduct{}, productID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("Loan product not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/db"
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+)
+
+type loanProductUsecase struct {
+	productRepo        domain.LoanProductRepository
+	transactionManager db.TransactionManager
+	contextTimeout     time.Duration
+}
+
+func NewLoanProductUsecase(p domain.LoanProductRepository, tm db.TransactionManager, timeout time.Duration) domain.LoanProductUsecase {
+	return &loanProductUsecase{
+		productRepo:        p,
+		transactionManager: tm,
+		contextTimeout:     timeout,
+	}
+}
+
+func (p *loanProductUsecase) CreateProduct(ctx context.Context, product domain.LoanProduct) (*dto.GetLoanProductResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.productRepo.CreateProduct(ctx, &product, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.productRepo.ReplaceFees(ctx, product.ID, product.Fees, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return assembleLoanProductResponse(&product), nil
+}
+
+func (p *loanProductUsecase) GetProduct(ctx context.Context, productID uint) (*dto.GetLoanProductResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	product, err := p.productRepo.FindProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleLoanProductResponse(product), nil
+}
+
+func (p *loanProductUsecase) GetProducts(ctx context.Context) ([]dto.GetLoanProductResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	products, err := p.productRepo.GetProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productResponses := make([]dto.GetLoanProductResponse, len(products))
+	for i := range products {
+		productResponses[i] = *assembleLoanProductResponse(&products[i])
+	}
+
+	return productResponses, nil
+}
+
+// UpdateProduct replaces the product definition and its fees. Loans already
+// booked keep the rate and method they were created with.
+func (p *loanProductUsecase) UpdateProduct(ctx context.Context, productID uint, product domain.LoanProduct) (*dto.GetLoanProductResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	existing, err := p.productRepo.FindProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Model = existing.Model
+
+	tx := p.transactionManager.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.transactionManager.Rollback(tx)
+			panic(r)
+		}
+	}()
+
+	if err := p.productRepo.UpdateProduct(ctx, &product, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.productRepo.ReplaceFees(ctx, product.ID, product.Fees, tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	if err := p.transactionManager.Commit(tx); err != nil {
+		p.transactionManager.Rollback(tx)
+		return nil, err
+	}
+
+	return assembleLoanProductResponse(&product), nil
+}
+
+func (p *loanProductUsecase) DeleteProduct(ctx context.Context, productID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	return p.productRepo.DeleteProduct(ctx, productID, nil)
+}
+
+func assembleLoanProductResponse(product *domain.LoanProduct) *dto.GetLoanProductResponse {
+	feeResponses := make([]dto.GetLoanProductFeeResponse, len(product.Fees))
+	for i, fee := range product.Fees {
+		feeResponses[i] = dto.GetLoanProductFeeResponse{
+			Name:   fee.Name,
+			Type:   string(fee.Type),
+			Charge: string(fee.Charge),
+			Amount: fee.Amount,
+			Rate:   fee.Rate,
+		}
+	}
+
+	order := product.AllocationOrder.OrDefault()
+	allocationOrder := make([]string, len(order))
+	for i, component := range order {
+		allocationOrder[i] = string(component)
+	}
+
+	return &dto.GetLoanProductResponse{
+		ID:                 product.ID,
+		Name:               product.Name,
+		MinPrincipal:       product.MinPrincipal,
+		MaxPrincipal:       product.MaxPrincipal,
+		Tenors:             product.Tenors,
+		InterestRate:       product.InterestRate,
+		ServiceFeeRate:     product.ServiceFeeRate,
+		AmortizationMethod: string(product.AmortizationMethod),
+		RebatePolicy:       string(product.RebatePolicy),
+		DayCount:           string(product.DayCount),
+		AllocationOrder:    allocationOrder,
+		PenaltyPolicy: dto.GetPenaltyPolicyResponse{
+			Type:   string(product.PenaltyPolicy.Type),
+			Amount: product.PenaltyPolicy.Amount,
+			Rate:   product.PenaltyPolicy.Rate,
+			Cap:    product.PenaltyPolicy.Cap,
+		},
+		Fees: feeResponses,
+	}
+}
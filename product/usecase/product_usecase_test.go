@@ -0,0 +1,181 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greekrode/loan-engine-amartha/domain"
+	"github.com/greekrode/loan-engine-amartha/domain/dto"
+	"github.com/greekrode/loan-engine-amartha/domain/mocks"
+	"github.com/greekrode/loan-engine-amartha/domain/money"
+	productUsecase "github.com/greekrode/loan-engine-amartha/product/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LoanProductUsecaseSuite struct {
+	suite.Suite
+	timeout time.Duration
+}
+
+func (s *LoanProductUsecaseSuite) SetupSuite() {
+	s.timeout = 2 * time.Second
+}
+
+var adminFee = domain.LoanProductFee{Name: "Admin", Type: domain.FeePercentage, Charge: domain.FeeUpfront, Rate: 2}
+
+var weeklyMicro = domain.LoanProduct{
+	Name:               "Weekly Micro",
+	MinPrincipal:       money.FromFloat(500),
+	MaxPrincipal:       money.FromFloat(10000),
+	Tenors:             []int{25, 50},
+	InterestRate:       12,
+	AmortizationMethod: domain.AmortizationFlat,
+	Fees:               []domain.LoanProductFee{adminFee},
+}
+
+var weeklyMicroResponse = &dto.GetLoanProductResponse{
+	ID:                 1,
+	Name:               "Weekly Micro",
+	MinPrincipal:       money.FromFloat(500),
+	MaxPrincipal:       money.FromFloat(10000),
+	Tenors:             []int{25, 50},
+	InterestRate:       12,
+	AmortizationMethod: "flat",
+	AllocationOrder:    []string{"fee", "penalty", "interest", "principal"},
+	Fees: []dto.GetLoanProductFeeResponse{
+		{Name: "Admin", Type: "percentage", Charge: "upfront", Rate: 2},
+	},
+}
+
+func (s *LoanProductUsecaseSuite) TestCreateProduct() {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.LoanProductRepository, *mocks.TransactionManager)
+		expected      *dto.GetLoanProductResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Creation",
+			setupMocks: func(mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("CreateProduct", mock.Anything, mock.AnythingOfType("*domain.LoanProduct"), mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.LoanProduct).ID = 1
+				}).Return(nil)
+				mpr.On("ReplaceFees", mock.Anything, uint(1), []domain.LoanProductFee{adminFee}, mock.Anything).Return(nil)
+			},
+			expected: weeklyMicroResponse,
+		},
+		{
+			name: "Error Creating Product",
+			setupMocks: func(mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreateProduct", mock.Anything, mock.AnythingOfType("*domain.LoanProduct"), mock.Anything).Return(errors.New("UNIQUE constraint failed: loan_products.name"))
+			},
+			expectedError: errors.New("UNIQUE constraint failed: loan_products.name"),
+		},
+		{
+			name: "Error Replacing Fees",
+			setupMocks: func(mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Rollback", mock.Anything).Return(nil)
+				mpr.On("CreateProduct", mock.Anything, mock.AnythingOfType("*domain.LoanProduct"), mock.Anything).Return(nil)
+				mpr.On("ReplaceFees", mock.Anything, uint(0), []domain.LoanProductFee{adminFee}, mock.Anything).Return(errors.New("error replacing fees"))
+			},
+			expectedError: errors.New("error replacing fees"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := productUsecase.NewLoanProductUsecase(mockProductRepo, mockTransactionManager, s.timeout)
+
+			tt.setupMocks(mockProductRepo, mockTransactionManager)
+			result, err := uc.CreateProduct(context.TODO(), weeklyMicro)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *LoanProductUsecaseSuite) TestUpdateProduct() {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.LoanProductRepository, *mocks.TransactionManager)
+		expected      *dto.GetLoanProductResponse
+		expectedError error
+	}{
+		{
+			name: "Successful Update",
+			setupMocks: func(mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mpr.On("FindProductByID", mock.Anything, uint(1)).Return(&domain.LoanProduct{Model: gorm.Model{ID: 1}, Name: "Old Name"}, nil)
+				mtm.On("Begin").Return(&gorm.DB{})
+				mtm.On("Commit", mock.Anything).Return(nil)
+				mpr.On("UpdateProduct", mock.Anything, mock.MatchedBy(func(p *domain.LoanProduct) bool {
+					return p.ID == 1 && p.Name == "Weekly Micro"
+				}), mock.Anything).Return(nil)
+				mpr.On("ReplaceFees", mock.Anything, uint(1), []domain.LoanProductFee{adminFee}, mock.Anything).Return(nil)
+			},
+			expected: weeklyMicroResponse,
+		},
+		{
+			name: "Product Not Found",
+			setupMocks: func(mpr *mocks.LoanProductRepository, mtm *mocks.TransactionManager) {
+				mpr.On("FindProductByID", mock.Anything, uint(1)).Return(nil, errors.New("Loan product not found"))
+			},
+			expectedError: errors.New("Loan product not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockProductRepo := new(mocks.LoanProductRepository)
+			mockTransactionManager := new(mocks.TransactionManager)
+
+			uc := productUsecase.NewLoanProductUsecase(mockProductRepo, mockTransactionManager, s.timeout)
+
+			tt.setupMocks(mockProductRepo, mockTransactionManager)
+			result, err := uc.UpdateProduct(context.TODO(), 1, weeklyMicro)
+			if tt.expectedError != nil {
+				assert.Error(s.T(), err)
+				assert.Equal(s.T(), tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), tt.expected, result)
+			}
+		})
+	}
+}
+
+func (s *LoanProductUsecaseSuite) TestGetProducts() {
+	mockProductRepo := new(mocks.LoanProductRepository)
+	mockTransactionManager := new(mocks.TransactionManager)
+
+	product := weeklyMicro
+	product.ID = 1
+	mockProductRepo.On("GetProducts", mock.Anything).Return([]domain.LoanProduct{product}, nil)
+
+	uc := productUsecase.NewLoanProductUsecase(mockProductRepo, mockTransactionManager, s.timeout)
+
+	result, err := uc.GetProducts(context.TODO())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []dto.GetLoanProductResponse{*weeklyMicroResponse}, result)
+}
+
+func TestLoanProductUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(LoanProductUsecaseSuite))
+}